@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/urfave/cli/v2"
@@ -13,6 +14,9 @@ import (
 	pkgformat "github.com/fsvxavier/nexs-swag/pkg/format"
 	generatorv2 "github.com/fsvxavier/nexs-swag/pkg/generator/v2"
 	generatorv3 "github.com/fsvxavier/nexs-swag/pkg/generator/v3"
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	v2 "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	v3 "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
 	"github.com/fsvxavier/nexs-swag/pkg/parser"
 )
 
@@ -77,6 +81,26 @@ func main() {
 						Value:   "camelcase",
 						Usage:   "Property naming strategy: snakecase, camelcase, pascalcase",
 					},
+					&cli.StringFlag{
+						Name:  "embeddedStrategy",
+						Value: "flatten",
+						Usage: "Embedded struct rendering: flatten (merge promoted fields) or allOf (compose with $ref)",
+					},
+					&cli.StringFlag{
+						Name:  "readWriteSplit",
+						Value: "none",
+						Usage: "Split schemas with readOnly/writeOnly fields: none, inputOutput (UserInput/UserOutput), requestResponse (User.Request/User.Response)",
+					},
+					&cli.StringFlag{
+						Name:  "operationIdStrategy",
+						Value: "none",
+						Usage: "Generate operation IDs without @ID: none, funcName, receiverMethod, methodPath, or a template like {tag}_{func}",
+					},
+					&cli.StringFlag{
+						Name:  "operationIdCase",
+						Value: "none",
+						Usage: "Casing of generated operation IDs: none, camel, pascal, snake, kebab",
+					},
 					&cli.BoolFlag{
 						Name:  "requiredByDefault",
 						Value: false,
@@ -96,7 +120,7 @@ func main() {
 					&cli.IntFlag{
 						Name:  "parseDepth",
 						Value: 100,
-						Usage: "Dependency parse depth",
+						Usage: "Maximum nesting depth of anonymous structs (named types are always referenced)",
 					},
 					&cli.StringFlag{
 						Name:    "markdownFiles",
@@ -136,6 +160,15 @@ func main() {
 						Value: true,
 						Usage: "Validate OpenAPI specification after generation",
 					},
+					&cli.BoolFlag{
+						Name:  "strictConversion",
+						Value: false,
+						Usage: "Fail when converting to the target version loses information",
+					},
+					&cli.StringFlag{
+						Name:  "conversionReport",
+						Usage: "Write conversion diagnostics as JSON to this file",
+					},
 					&cli.IntFlag{
 						Name:    "parseDependencyLevel",
 						Aliases: []string{"pdl"},
@@ -191,6 +224,10 @@ func main() {
 						Value: "",
 						Usage: "State file for @HostState annotation",
 					},
+					&cli.StringSliceFlag{
+						Name:  "overlay",
+						Usage: "OpenAPI Overlay 1.0 file applied to the specification before it is written (repeatable, applied in order)",
+					},
 					&cli.StringFlag{
 						Name:    "openapi-version",
 						Aliases: []string{"ov"},
@@ -246,6 +283,26 @@ func main() {
 						Value:   "camelcase",
 						Usage:   "Property naming strategy: snakecase, camelcase, pascalcase",
 					},
+					&cli.StringFlag{
+						Name:  "embeddedStrategy",
+						Value: "flatten",
+						Usage: "Embedded struct rendering: flatten (merge promoted fields) or allOf (compose with $ref)",
+					},
+					&cli.StringFlag{
+						Name:  "readWriteSplit",
+						Value: "none",
+						Usage: "Split schemas with readOnly/writeOnly fields: none, inputOutput (UserInput/UserOutput), requestResponse (User.Request/User.Response)",
+					},
+					&cli.StringFlag{
+						Name:  "operationIdStrategy",
+						Value: "none",
+						Usage: "Generate operation IDs without @ID: none, funcName, receiverMethod, methodPath, or a template like {tag}_{func}",
+					},
+					&cli.StringFlag{
+						Name:  "operationIdCase",
+						Value: "none",
+						Usage: "Casing of generated operation IDs: none, camel, pascal, snake, kebab",
+					},
 					&cli.BoolFlag{
 						Name:  "requiredByDefault",
 						Value: false,
@@ -265,7 +322,7 @@ func main() {
 					&cli.IntFlag{
 						Name:  "parseDepth",
 						Value: 100,
-						Usage: "Dependency parse depth",
+						Usage: "Maximum nesting depth of anonymous structs (named types are always referenced)",
 					},
 					&cli.StringFlag{
 						Name:    "markdownFiles",
@@ -305,6 +362,15 @@ func main() {
 						Value: true,
 						Usage: "Validate OpenAPI specification after generation",
 					},
+					&cli.BoolFlag{
+						Name:  "strictConversion",
+						Value: false,
+						Usage: "Fail when converting to the target version loses information",
+					},
+					&cli.StringFlag{
+						Name:  "conversionReport",
+						Usage: "Write conversion diagnostics as JSON to this file",
+					},
 					&cli.IntFlag{
 						Name:    "parseDependencyLevel",
 						Aliases: []string{"pdl"},
@@ -360,6 +426,10 @@ func main() {
 						Value: "",
 						Usage: "State file for @HostState annotation",
 					},
+					&cli.StringSliceFlag{
+						Name:  "overlay",
+						Usage: "OpenAPI Overlay 1.0 file applied to the specification before it is written (repeatable, applied in order)",
+					},
 					&cli.StringFlag{
 						Name:    "openapi-version",
 						Aliases: []string{"ov"},
@@ -369,6 +439,117 @@ func main() {
 				},
 				Action: initAction,
 			},
+			{
+				Name:  "convert",
+				Usage: "Convert an existing Swagger 2.0 or OpenAPI 3.x file (JSON or YAML) to another version",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "from",
+						Aliases:  []string{"i"},
+						Required: true,
+						Usage:    "Specification file to convert",
+					},
+					&cli.StringFlag{
+						Name:  "to",
+						Value: "3.1.0",
+						Usage: "Target version: 2.0.0, 3.0.0-3.0.4, 3.1.0-3.1.2, 3.2.0",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output file (default: stdout)",
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "Output format: json or yaml (default: from the output file extension, else json)",
+					},
+					&cli.BoolFlag{
+						Name:    "quiet",
+						Aliases: []string{"q"},
+						Usage:   "Suppress conversion warnings",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Fail when the conversion loses information",
+					},
+					&cli.StringFlag{
+						Name:  "report",
+						Usage: "Write conversion diagnostics as JSON to this file",
+					},
+					&cli.BoolFlag{
+						Name:  "preserve",
+						Usage: "Keep OpenAPI 3.x constructs in x-oas3-* extensions when converting to 2.0",
+					},
+				},
+				Action: convertAction,
+			},
+			{
+				Name:   "bundle",
+				Usage:  "Bundle a specification split across files into one document with only local $refs",
+				Flags:  resolveFlags(),
+				Action: bundleAction,
+			},
+			{
+				Name:   "dereference",
+				Usage:  "Replace every $ref in a specification with the value it points to",
+				Flags:  resolveFlags(),
+				Action: dereferenceAction,
+			},
+			{
+				Name:  "overlay",
+				Usage: "Apply OpenAPI Overlay 1.0 documents to a specification",
+				Flags: append(resolveFlags(), &cli.StringSliceFlag{
+					Name:     "overlay",
+					Required: true,
+					Usage:    "Overlay file (repeatable, applied in order)",
+				}),
+				Action: overlayAction,
+			},
+			{
+				Name:  "merge",
+				Usage: "Merge specifications from several services into one document",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "from",
+						Aliases:  []string{"i"},
+						Required: true,
+						Usage:    "Specification to merge as [name=]file; repeat for each source (name defaults to the file or directory name)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "path-prefix",
+						Usage: "Prefix for the paths of a source, as name=/prefix",
+					},
+					&cli.StringSliceFlag{
+						Name:  "tag-prefix",
+						Usage: "Prefix for the tags of a source, as name=prefix",
+					},
+					&cli.StringFlag{
+						Name:  "title",
+						Usage: "Title of the merged document (default: the first source's)",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Output file (default: stdout)",
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "Output format: json or yaml (default: from the output file extension, else json)",
+					},
+					&cli.BoolFlag{
+						Name:    "quiet",
+						Aliases: []string{"q"},
+						Usage:   "Suppress renames and conflicts",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Fail when two sources define the same operation",
+					},
+				},
+				Action: mergeAction,
+			},
 			{
 				Name:    "fmt",
 				Aliases: []string{"f"},
@@ -404,6 +585,10 @@ func initAction(c *cli.Context) error {
 	formatStr := c.String("format")
 	outputTypes := c.String("outputTypes")
 	propertyStrategy := c.String("propertyStrategy")
+	embeddedStrategy := c.String("embeddedStrategy")
+	readWriteSplit := c.String("readWriteSplit")
+	operationIDStrategy := c.String("operationIdStrategy")
+	operationIDCase := c.String("operationIdCase")
 	requiredByDefault := c.Bool("requiredByDefault")
 	parseInternal := c.Bool("parseInternal")
 	parseDependency := c.Bool("parseDependency")
@@ -415,6 +600,8 @@ func initAction(c *cli.Context) error {
 	parseVendor := c.Bool("parseVendor")
 	quiet := c.Bool("quiet")
 	validate := c.Bool("validate")
+	strictConversion := c.Bool("strictConversion")
+	conversionReport := c.String("conversionReport")
 	parseDependencyLevel := c.Int("parseDependencyLevel")
 	includeTypes := c.String("includeTypes")
 	codeExampleFilesDir := c.String("codeExampleFilesDir")
@@ -426,6 +613,7 @@ func initAction(c *cli.Context) error {
 	parseExtension := c.String("parseExtension")
 	state := c.String("state")
 	openapiVersion := c.String("openapi-version")
+	overlays := c.StringSlice("overlay")
 
 	// Validate and normalize openapi-version
 	openapiVersion = normalizeOpenAPIVersion(openapiVersion)
@@ -488,12 +676,26 @@ func initAction(c *cli.Context) error {
 	p.SetGeneralInfoFile(generalInfo)
 	p.SetExcludePatterns(excludePatterns)
 	p.SetPropertyStrategy(propertyStrategy)
+	if err := p.SetEmbeddedStrategy(embeddedStrategy); err != nil {
+		return err
+	}
+	if err := p.SetReadWriteSplit(readWriteSplit); err != nil {
+		return err
+	}
+	if err := p.SetOperationIDStrategy(operationIDStrategy); err != nil {
+		return err
+	}
+	if err := p.SetOperationIDCase(operationIDCase); err != nil {
+		return err
+	}
 	p.SetRequiredByDefault(requiredByDefault)
 	p.SetParseInternal(parseInternal)
 	p.SetParseDependency(parseDependency)
 	p.SetParseDepth(parseDepth)
 	p.SetMarkdownFilesDir(markdownFiles)
-	p.SetOverridesFile(overridesFile)
+	if err := p.SetOverridesFile(overridesFile); err != nil {
+		return fmt.Errorf("failed to load overrides file: %w", err)
+	}
 	p.SetTagFilters(includeTags, excludeTags)
 	p.SetParseFuncBody(parseFuncBody)
 	p.SetParseVendor(parseVendor)
@@ -513,6 +715,11 @@ func initAction(c *cli.Context) error {
 	if err := p.ParseDir(searchDir); err != nil {
 		return fmt.Errorf("failed to parse directory: %w", err)
 	}
+	if !quiet {
+		for _, warning := range p.Warnings() {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
 
 	// Validate if requested
 	if validate {
@@ -536,7 +743,11 @@ func initAction(c *cli.Context) error {
 			fmt.Printf("Converting OpenAPI 3.1.0 to Swagger %s...\n", openapiVersion)
 		}
 		conv := converter.New()
+		conv.SetStrict(strictConversion)
 		swagger2, err := conv.ConvertToV2(spec)
+		if reportErr := writeConversionReport(conv, conversionReport, spec.GetVersion(), openapiVersion); reportErr != nil {
+			return reportErr
+		}
 		if err != nil {
 			return fmt.Errorf("failed to convert to Swagger 2.0: %w", err)
 		}
@@ -547,6 +758,15 @@ func initAction(c *cli.Context) error {
 			}
 		}
 
+		patched, err := applyOverlays(swagger2, overlays, quiet)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if swagger2, ok = patched.(*v2.Swagger); !ok {
+			return fmt.Errorf("overlay changed the specification version")
+		}
+
 		// Generate Swagger 2.0 output
 		if !quiet {
 			fmt.Printf("Generating Swagger %s documentation in: %s\n", openapiVersion, outputDir)
@@ -558,6 +778,33 @@ func initAction(c *cli.Context) error {
 			return fmt.Errorf("failed to generate documentation: %w", err)
 		}
 	} else {
+		// Rewrite features the target version does not support
+		conv := converter.New()
+		conv.SetStrict(strictConversion)
+		source := spec.GetVersion()
+		err := conv.DownLevel(spec, openapiVersion)
+		if reportErr := writeConversionReport(conv, conversionReport, source, openapiVersion); reportErr != nil {
+			return reportErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert to OpenAPI %s: %w", openapiVersion, err)
+		}
+		if len(conv.GetWarnings()) > 0 && !quiet {
+			fmt.Println("Conversion warnings:")
+			for _, warning := range conv.GetWarnings() {
+				fmt.Printf("  - %s\n", warning)
+			}
+		}
+
+		patched, err := applyOverlays(spec, overlays, quiet)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if spec, ok = patched.(*v3.OpenAPI); !ok {
+			return fmt.Errorf("overlay changed the specification version")
+		}
+
 		// Generate OpenAPI 3.x output
 		if !quiet {
 			fmt.Printf("Generating OpenAPI %s documentation in: %s\n", openapiVersion, outputDir)
@@ -611,6 +858,283 @@ func normalizeOpenAPIVersion(version string) string {
 	return ""
 }
 
+func convertAction(c *cli.Context) error {
+	from := c.String("from")
+	output := c.String("output")
+	format := c.String("format")
+	quiet := c.Bool("quiet")
+	report := c.String("report")
+
+	targetVersion := normalizeOpenAPIVersion(c.String("to"))
+	if targetVersion == "" {
+		return fmt.Errorf("invalid target version. Supported versions: 2.0.0, 3.0.0-3.0.4, 3.1.0-3.1.2, 3.2.0")
+	}
+
+	spec, err := oas.Load(from)
+	if err != nil {
+		return fmt.Errorf("failed to load specification: %w", err)
+	}
+
+	conv := converter.New()
+	conv.SetStrict(c.Bool("strict"))
+	conv.SetPreserve(c.Bool("preserve"))
+	converted, err := conv.Convert(spec, targetVersion)
+	if reportErr := writeConversionReport(conv, report, spec.GetVersion(), targetVersion); reportErr != nil {
+		return reportErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to convert specification: %w", err)
+	}
+
+	// Warnings go to stderr so stdout stays a valid document
+	if len(conv.GetWarnings()) > 0 && !quiet {
+		fmt.Fprintln(os.Stderr, "Conversion warnings:")
+		for _, warning := range conv.GetWarnings() {
+			fmt.Fprintf(os.Stderr, "  - %s\n", warning)
+		}
+	}
+
+	if err := writeSpecification(converted, output, format); err != nil {
+		return err
+	}
+	if output != "" && !quiet {
+		fmt.Fprintf(os.Stderr, "Converted %s (%s) to %s (%s)\n", from, spec.GetVersion(), output, targetVersion)
+	}
+	return nil
+}
+
+// resolveFlags returns the flags shared by the commands that rewrite a single
+// specification file.
+func resolveFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "from",
+			Aliases:  []string{"i"},
+			Required: true,
+			Usage:    "Root specification file",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Output file (default: stdout)",
+		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format: json or yaml (default: from the output file extension, else json)",
+		},
+		&cli.BoolFlag{
+			Name:    "quiet",
+			Aliases: []string{"q"},
+			Usage:   "Suppress output messages",
+		},
+	}
+}
+
+func bundleAction(c *cli.Context) error {
+	spec, err := oas.Bundle(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("failed to bundle specification: %w", err)
+	}
+	return writeResolved(c, spec, "Bundled")
+}
+
+func dereferenceAction(c *cli.Context) error {
+	spec, err := oas.Dereference(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("failed to dereference specification: %w", err)
+	}
+	return writeResolved(c, spec, "Dereferenced")
+}
+
+func mergeAction(c *cli.Context) error {
+	pathPrefixes, err := sourceOptions(c.StringSlice("path-prefix"), "path-prefix")
+	if err != nil {
+		return err
+	}
+	tagPrefixes, err := sourceOptions(c.StringSlice("tag-prefix"), "tag-prefix")
+	if err != nil {
+		return err
+	}
+
+	var sources []oas.MergeSource
+	seen := make(map[string]bool)
+	for _, from := range c.StringSlice("from") {
+		name, file, ok := strings.Cut(from, "=")
+		if !ok {
+			name, file = sourceName(from), from
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate source name %q; name sources as name=file", name)
+		}
+		seen[name] = true
+
+		spec, err := oas.Load(file)
+		if err != nil {
+			return fmt.Errorf("failed to load specification: %w", err)
+		}
+		sources = append(sources, oas.MergeSource{
+			Name:       name,
+			Spec:       spec,
+			PathPrefix: pathPrefixes[name],
+			TagPrefix:  tagPrefixes[name],
+		})
+		delete(pathPrefixes, name)
+		delete(tagPrefixes, name)
+	}
+	for _, unknown := range []map[string]string{pathPrefixes, tagPrefixes} {
+		for name := range unknown {
+			return fmt.Errorf("prefix given for unknown source %q", name)
+		}
+	}
+
+	merged, report, err := oas.Merge(sources...)
+	if err != nil {
+		return fmt.Errorf("failed to merge specifications: %w", err)
+	}
+
+	quiet := c.Bool("quiet")
+	if !quiet {
+		for _, rename := range report.Renames {
+			fmt.Fprintf(os.Stderr, "Renamed %s/%s of %s to %s\n", rename.Section, rename.From, rename.Source, rename.To)
+		}
+	}
+	if len(report.Conflicts) > 0 && (!quiet || c.Bool("strict")) {
+		for _, conflict := range report.Conflicts {
+			fmt.Fprintf(os.Stderr, "Conflict: %s from %s is already defined by %s\n", conflict.Pointer, conflict.Source, conflict.Existing)
+		}
+		if c.Bool("strict") {
+			return fmt.Errorf("found %d conflicting operations", len(report.Conflicts))
+		}
+	}
+
+	if title := c.String("title"); title != "" {
+		switch spec := merged.(type) {
+		case *v3.OpenAPI:
+			spec.Info.Title = title
+		case *v2.Swagger:
+			spec.Info.Title = title
+		}
+	}
+
+	output := c.String("output")
+	if err := writeSpecification(merged, output, c.String("format")); err != nil {
+		return err
+	}
+	if output != "" && !quiet {
+		fmt.Fprintf(os.Stderr, "Merged %d specifications to %s\n", len(sources), output)
+	}
+	return nil
+}
+
+// sourceOptions parses name=value flags into a map keyed by source name.
+func sourceOptions(values []string, flag string) (map[string]string, error) {
+	options := make(map[string]string, len(values))
+	for _, value := range values {
+		name, option, ok := strings.Cut(value, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected name=value", flag, value)
+		}
+		options[name] = option
+	}
+	return options, nil
+}
+
+// sourceName names a merge source after its file, or after its directory
+// when the file has a generic name such as openapi.json.
+func sourceName(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	switch name {
+	case "openapi", "swagger", "docs":
+		if dir := filepath.Base(filepath.Dir(file)); dir != "." && dir != string(filepath.Separator) {
+			return dir
+		}
+	}
+	return name
+}
+
+func overlayAction(c *cli.Context) error {
+	spec, err := oas.Load(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("failed to load specification: %w", err)
+	}
+	patched, err := applyOverlays(spec, c.StringSlice("overlay"), c.Bool("quiet"))
+	if err != nil {
+		return err
+	}
+	return writeResolved(c, patched, "Applied overlays to")
+}
+
+// applyOverlays applies the overlay files to spec in order, printing a
+// warning for each action that selected nothing unless quiet is set.
+func applyOverlays(spec oas.Specification, paths []string, quiet bool) (oas.Specification, error) {
+	for _, path := range paths {
+		overlay, err := oas.LoadOverlay(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load overlay: %w", err)
+		}
+		var warnings []string
+		spec, warnings, err = overlay.Apply(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply overlay %s: %w", path, err)
+		}
+		if !quiet {
+			for _, warning := range warnings {
+				fmt.Fprintf(os.Stderr, "Overlay %s: %s\n", path, warning)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// writeResolved writes the result of the bundle and dereference commands.
+func writeResolved(c *cli.Context, spec oas.Specification, verb string) error {
+	output := c.String("output")
+	if err := writeSpecification(spec, output, c.String("format")); err != nil {
+		return err
+	}
+	if output != "" && !c.Bool("quiet") {
+		fmt.Fprintf(os.Stderr, "%s %s to %s\n", verb, c.String("from"), output)
+	}
+	return nil
+}
+
+// writeSpecification encodes spec to output, or stdout when output is empty.
+// An empty format is taken from the output file extension, defaulting to JSON.
+func writeSpecification(spec oas.Specification, output, format string) error {
+	if format == "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(output)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+
+	data, err := converter.Marshal(spec, format)
+	if err != nil {
+		return fmt.Errorf("failed to encode specification: %w", err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return nil
+}
+
+// writeConversionReport writes the converter's diagnostics to path, if set.
+func writeConversionReport(conv *converter.Converter, path, source, target string) error {
+	if path == "" {
+		return nil
+	}
+	if err := conv.WriteReport(path, source, target); err != nil {
+		return fmt.Errorf("failed to write conversion report: %w", err)
+	}
+	return nil
+}
+
 func fmtAction(c *cli.Context) error {
 	searchDir := c.String("dir")
 	quiet := c.Bool("quiet")
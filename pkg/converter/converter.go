@@ -4,6 +4,10 @@ package converter
 import (
 	"fmt"
 	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
@@ -12,24 +16,46 @@ import (
 
 // Converter handles conversion between OpenAPI versions.
 type Converter struct {
-	warnings []string
+	diagnostics []Diagnostic
+
+	// location holds the JSON pointer tokens of the node being converted.
+	location []string
+
+	// strict makes lossy conversions fail.
+	strict bool
+
+	// preserve keeps 3.x-only constructs in x-oas3-* extensions.
+	preserve bool
+
+	// droppedSchemes are security schemes without a Swagger 2.0 equivalent;
+	// requirements referencing them are removed.
+	droppedSchemes map[string]bool
+
+	// components of the document converted to Swagger 2.0, which has no
+	// reusable request bodies or headers; references to them are inlined.
+	components *openapi.Components
 }
 
 // New creates a new Converter instance.
 func New() *Converter {
 	return &Converter{
-		warnings: make([]string, 0),
+		diagnostics: make([]Diagnostic, 0),
 	}
 }
 
-// GetWarnings returns all conversion warnings.
+// GetWarnings returns all conversion diagnostics formatted as
+// "pointer: message".
 func (c *Converter) GetWarnings() []string {
-	return c.warnings
+	warnings := make([]string, len(c.diagnostics))
+	for i, d := range c.diagnostics {
+		warnings[i] = d.String()
+	}
+	return warnings
 }
 
 // ClearWarnings clears all accumulated warnings.
 func (c *Converter) ClearWarnings() {
-	c.warnings = make([]string, 0)
+	c.diagnostics = make([]Diagnostic, 0)
 }
 
 // ConvertToV2 converts an OpenAPI 3.1.0 specification to Swagger 2.0.
@@ -37,6 +63,20 @@ func (c *Converter) ConvertToV2(spec *openapi.OpenAPI) (*swagger.Swagger, error)
 	if spec == nil {
 		return nil, fmt.Errorf("input specification is nil")
 	}
+	finish := c.begin()
+
+	c.droppedSchemes = nil
+	c.components = spec.Components
+	if spec.Components != nil {
+		for name, scheme := range spec.Components.SecuritySchemes {
+			if scheme != nil && scheme.Type == "mutualTLS" {
+				if c.droppedSchemes == nil {
+					c.droppedSchemes = make(map[string]bool)
+				}
+				c.droppedSchemes[name] = true
+			}
+		}
+	}
 
 	swagger := &swagger.Swagger{
 		Swagger:      "2.0",
@@ -47,34 +87,59 @@ func (c *Converter) ConvertToV2(spec *openapi.OpenAPI) (*swagger.Swagger, error)
 		Security:     c.convertSecurity(spec.Security),
 	}
 
+	swagger.Extensions = copyExtensions(swagger.Extensions, spec.Extensions)
+
 	// Convert servers to host/basePath/schemes
 	if len(spec.Servers) > 0 {
-		host, basePath, schemes := c.parseServerURL(spec.Servers[0].URL)
+		leave := c.enter("servers")
+		if c.preserve && !plainServer(spec.Servers) {
+			c.drop(&swagger.Extensions, preservedServers, spec.Servers, CodeServers, "servers are only partly expressible as host/basePath/schemes in Swagger 2.0")
+		} else if len(spec.Servers) > 1 {
+			c.warn(SeverityWarning, CodeServers, "multiple servers detected: only the first server is converted to host/basePath/schemes in Swagger 2.0")
+		}
+
+		leaveServer := c.enter("0")
+		host, basePath, schemes := c.parseServerURL(spec.Servers[0])
 		swagger.Host = host
 		swagger.BasePath = basePath
 		swagger.Schemes = schemes
-
-		if len(spec.Servers) > 1 {
-			c.warnings = append(c.warnings, "multiple servers detected: only the first server is converted to host/basePath/schemes in Swagger 2.0")
+		if len(spec.Servers[0].Extensions) > 0 && !c.preserve {
+			c.warn(SeverityWarning, CodeServers, "server extensions are not supported in Swagger 2.0 and were ignored")
 		}
+		leaveServer()
+		leave()
 	}
 
 	// Convert components to definitions/parameters/responses/securityDefinitions
 	if spec.Components != nil {
+		leave := c.enter("components", "schemas")
 		swagger.Definitions = c.convertSchemas(spec.Components.Schemas)
+		leave()
 		swagger.Parameters = c.convertParameterDefinitions(spec.Components.Parameters)
 		swagger.Responses = c.convertResponseDefinitions(spec.Components.Responses)
 		swagger.SecurityDefinitions = c.convertSecuritySchemes(spec.Components.SecuritySchemes)
+		if len(spec.Components.Links) > 0 {
+			leave := c.enter("components", "links")
+			c.drop(&swagger.Extensions, preservedLinks, spec.Components.Links, CodeLinks, "components links are not supported in Swagger 2.0 and were ignored")
+			leave()
+		}
 	}
 
 	// Warn about unsupported features
 	if len(spec.Webhooks) > 0 {
-		c.warnings = append(c.warnings, "webhooks are not supported in Swagger 2.0 and were ignored")
+		leave := c.enter("webhooks")
+		c.drop(&swagger.Extensions, preservedWebhooks, spec.Webhooks, CodeWebhooks, "webhooks are not supported in Swagger 2.0 and were ignored")
+		leave()
 	}
 	if spec.JSONSchemaDialect != "" {
-		c.warnings = append(c.warnings, "jsonSchemaDialect is not supported in Swagger 2.0 and was ignored")
+		leave := c.enter("jsonSchemaDialect")
+		c.drop(&swagger.Extensions, preservedJSONSchemaDialect, spec.JSONSchemaDialect, CodeJSONSchemaDialect, "jsonSchemaDialect is not supported in Swagger 2.0 and was ignored")
+		leave()
 	}
 
+	if err := finish(); err != nil {
+		return nil, err
+	}
 	return swagger, nil
 }
 
@@ -85,6 +150,7 @@ func (c *Converter) convertInfo(info openapi.Info) swagger.Info {
 		Description:    info.Description,
 		TermsOfService: info.TermsOfService,
 		Version:        info.Version,
+		Extensions:     copyExtensions(nil, info.Extensions),
 	}
 
 	if info.Contact != nil {
@@ -101,25 +167,25 @@ func (c *Converter) convertInfo(info openapi.Info) swagger.Info {
 			URL:  info.License.URL,
 		}
 		if info.License.Identifier != "" {
-			c.warnings = append(c.warnings, "license.identifier is not supported in Swagger 2.0, only license.url is used")
+			c.warnAt("/info/license/identifier", SeverityWarning, CodeInfo, "license.identifier is not supported in Swagger 2.0, only license.url is used")
 		}
 	}
 
 	if info.Summary != "" {
-		c.warnings = append(c.warnings, "info.summary is not supported in Swagger 2.0 and was ignored")
+		c.warnAt("/info/summary", SeverityWarning, CodeInfo, "info.summary is not supported in Swagger 2.0 and was ignored")
 	}
 
 	return v2Info
 }
 
 // parseServerURL parses an OpenAPI 3.x server URL into host, basePath, and schemes.
-func (c *Converter) parseServerURL(serverURL string) (host, basePath string, schemes []string) {
-	// Handle server URL with variables (replace with defaults or remove)
-	serverURL = c.replaceServerVariables(serverURL)
+func (c *Converter) parseServerURL(server openapi.Server) (host, basePath string, schemes []string) {
+	// Swagger 2.0 has no server variables: substitute their defaults
+	serverURL := c.replaceServerVariables(server.URL, server.Variables)
 
 	parsedURL, err := url.Parse(serverURL)
 	if err != nil {
-		c.warnings = append(c.warnings, fmt.Sprintf("failed to parse server URL %q: %v", serverURL, err))
+		c.warn(SeverityWarning, CodeServers, fmt.Sprintf("failed to parse server URL %q: %v", serverURL, err))
 		return "", "", nil
 	}
 
@@ -136,11 +202,49 @@ func (c *Converter) parseServerURL(serverURL string) (host, basePath string, sch
 	return host, basePath, schemes
 }
 
-// replaceServerVariables replaces server variables with defaults or empty strings.
-func (c *Converter) replaceServerVariables(serverURL string) string {
-	// Simple replacement: {variable} → empty
-	// More sophisticated handling could use server variable defaults
-	return strings.ReplaceAll(strings.ReplaceAll(serverURL, "{", ""), "}", "")
+// serverVariableRegex matches a {variable} in a server URL template.
+var serverVariableRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// replaceServerVariables replaces server variables with their defaults and
+// reports each substitution, since the other enum values are lost.
+func (c *Converter) replaceServerVariables(serverURL string, variables map[string]*openapi.ServerVariable) string {
+	return serverVariableRegex.ReplaceAllStringFunc(serverURL, func(match string) string {
+		name := match[1 : len(match)-1]
+
+		variable := variables[name]
+		if variable == nil {
+			c.warn(c.serverSeverity(), CodeServerVariable, fmt.Sprintf("server variable {%s} in %q has no definition and was removed", name, serverURL))
+			return ""
+		}
+
+		warning := fmt.Sprintf("server variable {%s} in %q was replaced with its default %q", name, serverURL, variable.Default)
+		dropped := slices.DeleteFunc(slices.Clone(variable.Enum), func(value string) bool {
+			return value == variable.Default
+		})
+		severity := SeverityInfo
+		if len(dropped) > 0 {
+			warning += fmt.Sprintf("; other values dropped: %s", strings.Join(dropped, ", "))
+			severity = c.serverSeverity()
+		}
+		c.warn(severity, CodeServerVariable, warning)
+		return variable.Default
+	})
+}
+
+// serverSeverity is the severity of rewriting a server URL: informational
+// when the original servers are preserved.
+func (c *Converter) serverSeverity() Severity {
+	if c.preserve {
+		return SeverityInfo
+	}
+	return SeverityWarning
+}
+
+// plainServer reports whether servers is a single URL that host, basePath
+// and schemes reproduce.
+func plainServer(servers []openapi.Server) bool {
+	server := servers[0]
+	return len(servers) == 1 && server.Description == "" && len(server.Variables) == 0 && len(server.Extensions) == 0
 }
 
 // convertPaths converts OpenAPI Paths to Swagger Paths.
@@ -151,7 +255,9 @@ func (c *Converter) convertPaths(paths openapi.Paths) swagger.Paths {
 
 	v2Paths := make(swagger.Paths, len(paths))
 	for path, pathItem := range paths {
+		leave := c.enter("paths", path)
 		v2Paths[path] = c.convertPathItem(pathItem)
+		leave()
 	}
 
 	return v2Paths
@@ -166,36 +272,50 @@ func (c *Converter) convertPathItem(pathItem *openapi.PathItem) *swagger.PathIte
 	v2PathItem := &swagger.PathItem{
 		Ref:        c.convertRefToV2(pathItem.Ref),
 		Parameters: c.convertParameters(pathItem.Parameters),
+		Extensions: copyExtensions(nil, pathItem.Extensions),
+	}
+
+	if len(pathItem.Servers) > 0 {
+		leave := c.enter("servers")
+		c.drop(&v2PathItem.Extensions, preservedServers, pathItem.Servers, CodeServers, "path-level servers are not supported in Swagger 2.0 and were ignored")
+		leave()
+	}
+
+	convert := func(method string, op *openapi.Operation) *swagger.Operation {
+		defer c.enter(method)()
+		return c.convertOperation(op)
 	}
 
 	if pathItem.Get != nil {
-		v2PathItem.Get = c.convertOperation(pathItem.Get)
+		v2PathItem.Get = convert("get", pathItem.Get)
 	}
 	if pathItem.Put != nil {
-		v2PathItem.Put = c.convertOperation(pathItem.Put)
+		v2PathItem.Put = convert("put", pathItem.Put)
 	}
 	if pathItem.Post != nil {
-		v2PathItem.Post = c.convertOperation(pathItem.Post)
+		v2PathItem.Post = convert("post", pathItem.Post)
 	}
 	if pathItem.Delete != nil {
-		v2PathItem.Delete = c.convertOperation(pathItem.Delete)
+		v2PathItem.Delete = convert("delete", pathItem.Delete)
 	}
 	if pathItem.Options != nil {
-		v2PathItem.Options = c.convertOperation(pathItem.Options)
+		v2PathItem.Options = convert("options", pathItem.Options)
 	}
 	if pathItem.Head != nil {
-		v2PathItem.Head = c.convertOperation(pathItem.Head)
+		v2PathItem.Head = convert("head", pathItem.Head)
 	}
 	if pathItem.Patch != nil {
-		v2PathItem.Patch = c.convertOperation(pathItem.Patch)
+		v2PathItem.Patch = convert("patch", pathItem.Patch)
 	}
 
 	// QUERY method is new in OpenAPI 3.2.0 - not supported in Swagger 2.0
 	// But we still need to process it to generate warnings for its content
 	if pathItem.Query != nil {
-		c.warnings = append(c.warnings, "QUERY HTTP method is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+		leave := c.enter("query")
+		c.warn(SeverityWarning, CodeQueryMethod, "QUERY HTTP method is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
 		// Process the operation to generate warnings for responses, request body, etc.
 		_ = c.convertOperation(pathItem.Query)
+		leave()
 	}
 
 	return v2PathItem
@@ -220,31 +340,34 @@ func (c *Converter) convertOperation(op *openapi.Operation) *swagger.Operation {
 	}
 
 	// Convert RequestBody to body parameter
+	var requestBody *openapi.RequestBody
 	if op.RequestBody != nil {
-		bodyParam := c.convertRequestBodyToParameter(op.RequestBody)
+		leave := c.enter("requestBody")
+		requestBody = c.resolveRequestBody(op.RequestBody)
+		bodyParam := c.convertRequestBodyToParameter(requestBody)
+		leave()
 		if bodyParam != nil {
 			v2Op.Parameters = append(v2Op.Parameters, bodyParam)
 		}
 	}
 
 	// Extract consumes/produces from RequestBody and Responses
-	v2Op.Consumes = c.extractConsumes(op.RequestBody)
+	v2Op.Consumes = c.extractConsumes(requestBody)
 	v2Op.Produces = c.extractProduces(op.Responses)
 
 	// Copy extensions (including x-visibility)
-	if len(op.Extensions) > 0 {
-		v2Op.Extensions = make(map[string]interface{})
-		for k, v := range op.Extensions {
-			v2Op.Extensions[k] = v
-		}
-	}
+	v2Op.Extensions = copyExtensions(v2Op.Extensions, op.Extensions)
 
 	// Warn about unsupported features
 	if len(op.Callbacks) > 0 {
-		c.warnings = append(c.warnings, fmt.Sprintf("operation %q: callbacks are not supported in Swagger 2.0 and were ignored", op.OperationID))
+		leave := c.enter("callbacks")
+		c.drop(&v2Op.Extensions, preservedCallbacks, op.Callbacks, CodeCallbacks, fmt.Sprintf("operation %q: callbacks are not supported in Swagger 2.0 and were ignored", op.OperationID))
+		leave()
 	}
 	if len(op.Servers) > 0 {
-		c.warnings = append(c.warnings, fmt.Sprintf("operation %q: operation-level servers are not supported in Swagger 2.0 and were ignored", op.OperationID))
+		leave := c.enter("servers")
+		c.drop(&v2Op.Extensions, preservedServers, op.Servers, CodeServers, fmt.Sprintf("operation %q: operation-level servers are not supported in Swagger 2.0 and were ignored", op.OperationID))
+		leave()
 	}
 
 	return v2Op
@@ -272,12 +395,16 @@ func (c *Converter) convertParameter(param *openapi.Parameter) *swagger.Paramete
 	if param == nil {
 		return nil
 	}
+	if param.Ref != "" {
+		return &swagger.Parameter{Ref: c.convertRefToV2(param.Ref)}
+	}
 
 	v2Param := &swagger.Parameter{
 		Name:        param.Name,
 		In:          param.In,
 		Description: param.Description,
 		Required:    param.Required,
+		Extensions:  copyExtensions(nil, param.Extensions),
 	}
 
 	// Convert schema to type/format for simple parameters
@@ -310,16 +437,13 @@ func (c *Converter) convertSchemaToParameter(schema *openapi.Schema, param *swag
 	param.Pattern = schema.Pattern
 	param.UniqueItems = schema.UniqueItems
 	param.Enum = schema.Enum
+	if len(param.Enum) == 0 && schema.Const != nil {
+		param.Enum = []interface{}{schema.Const}
+	}
 
 	// Handle pointer fields
-	if schema.Maximum != 0 {
-		v := schema.Maximum
-		param.Maximum = &v
-	}
-	if schema.Minimum != 0 {
-		v := schema.Minimum
-		param.Minimum = &v
-	}
+	param.Maximum = copyFloat(schema.Maximum)
+	param.Minimum = copyFloat(schema.Minimum)
 	if schema.MaxLength != 0 {
 		v := schema.MaxLength
 		param.MaxLength = &v
@@ -413,23 +537,28 @@ func (c *Converter) convertRequestBodyToParameter(rb *openapi.RequestBody) *swag
 	}
 
 	// Warn about OpenAPI 3.2.0 streaming features in request body
+	leave := c.enter("content", contentType)
 	if mediaType.ItemSchema != nil {
-		c.warnings = append(c.warnings, "MediaType.itemSchema for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+		c.warn(SeverityWarning, CodeStreaming, "MediaType.itemSchema for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
 	}
 	if len(mediaType.ItemEncoding) > 0 {
-		c.warnings = append(c.warnings, "MediaType.itemEncoding for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+		c.warn(SeverityWarning, CodeStreaming, "MediaType.itemEncoding for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
 	}
 
+	leaveSchema := c.enter("schema")
 	param := &swagger.Parameter{
 		Name:        "body",
 		In:          "body",
 		Description: rb.Description,
 		Required:    rb.Required,
 		Schema:      c.convertSchema(mediaType.Schema),
+		Extensions:  copyExtensions(nil, rb.Extensions),
 	}
+	leaveSchema()
+	leave()
 
 	if len(rb.Content) > 1 {
-		c.warnings = append(c.warnings, fmt.Sprintf("requestBody has multiple content types: only %q is converted to body parameter", contentType))
+		c.warn(SeverityWarning, CodeRequestBody, fmt.Sprintf("requestBody has multiple content types: only %q is converted to body parameter", contentType))
 	}
 
 	return param
@@ -484,7 +613,13 @@ func (c *Converter) convertResponses(responses openapi.Responses) swagger.Respon
 
 	v2Responses := make(swagger.Responses, len(responses))
 	for code, resp := range responses {
-		v2Responses[code] = c.convertResponse(resp)
+		leave := c.enter("responses", code)
+		if strings.HasSuffix(code, "XX") {
+			c.warn(SeverityWarning, CodeStatusRange, fmt.Sprintf("response status range %s is not supported in Swagger 2.0 and was ignored", code))
+		} else {
+			v2Responses[code] = c.convertResponse(resp)
+		}
+		leave()
 	}
 
 	return v2Responses
@@ -495,40 +630,62 @@ func (c *Converter) convertResponse(resp *openapi.Response) *swagger.Response {
 	if resp == nil {
 		return nil
 	}
+	if resp.Ref != "" {
+		return &swagger.Response{Ref: c.convertRefToV2(resp.Ref)}
+	}
 
 	v2Resp := &swagger.Response{
 		Description: resp.Description,
 		Headers:     c.convertHeaders(resp.Headers),
+		Extensions:  copyExtensions(nil, resp.Extensions),
+	}
+
+	if len(resp.Links) > 0 {
+		names := make([]string, 0, len(resp.Links))
+		for name := range resp.Links {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		leave := c.enter("links")
+		c.drop(&v2Resp.Extensions, preservedLinks, resp.Links, CodeLinks, fmt.Sprintf("response links are not supported in Swagger 2.0 and were dropped: %s", strings.Join(names, ", ")))
+		leave()
 	}
 
 	// Convert content to schema (use first content type, preferring application/json)
 	if len(resp.Content) > 0 {
 		var mediaType *openapi.MediaType
-		if mt, ok := resp.Content["application/json"]; ok {
+		contentType := "application/json"
+		if mt, ok := resp.Content[contentType]; ok {
 			mediaType = mt
 		} else {
 			// Use first available
-			for _, mt := range resp.Content {
+			for ct, mt := range resp.Content {
 				mediaType = mt
+				contentType = ct
 				break
 			}
 		}
 
 		if mediaType != nil && mediaType.Schema != nil {
+			leave := c.enter("content", contentType, "schema")
 			v2Resp.Schema = c.convertSchema(mediaType.Schema)
+			leave()
 		}
 
 		// Warn about OpenAPI 3.2.0 streaming features in all media types
-		for contentType, mt := range resp.Content {
+		for ct, mt := range resp.Content {
 			if mt.ItemSchema != nil {
-				c.warnings = append(c.warnings, "MediaType.itemSchema for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+				leave := c.enter("content", ct, "itemSchema")
+				c.warn(SeverityWarning, CodeStreaming, "MediaType.itemSchema for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+				leave()
 				break // Only warn once
 			}
 			if len(mt.ItemEncoding) > 0 {
-				c.warnings = append(c.warnings, "MediaType.itemEncoding for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+				leave := c.enter("content", ct, "itemEncoding")
+				c.warn(SeverityWarning, CodeStreaming, "MediaType.itemEncoding for streaming is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+				leave()
 				break // Only warn once
 			}
-			_ = contentType // avoid unused variable
 		}
 
 		// Convert examples
@@ -546,12 +703,52 @@ func (c *Converter) convertHeaders(headers map[string]*openapi.Header) map[strin
 
 	v2Headers := make(map[string]*swagger.Header, len(headers))
 	for name, header := range headers {
-		v2Headers[name] = c.convertHeader(header)
+		leave := c.enter("headers", name)
+		if header := c.resolveHeader(header); header != nil {
+			v2Headers[name] = c.convertHeader(header)
+		}
+		leave()
 	}
 
 	return v2Headers
 }
 
+// resolveRequestBody returns the component a request body reference points
+// to, or the request body itself.
+func (c *Converter) resolveRequestBody(rb *openapi.RequestBody) *openapi.RequestBody {
+	if rb.Ref == "" {
+		return rb
+	}
+	var bodies map[string]*openapi.RequestBody
+	if c.components != nil {
+		bodies = c.components.RequestBodies
+	}
+	return resolveComponent(c, bodies, "#/components/requestBodies/", rb.Ref)
+}
+
+// resolveHeader returns the component a header reference points to, or the
+// header itself.
+func (c *Converter) resolveHeader(header *openapi.Header) *openapi.Header {
+	if header == nil || header.Ref == "" {
+		return header
+	}
+	var headers map[string]*openapi.Header
+	if c.components != nil {
+		headers = c.components.Headers
+	}
+	return resolveComponent(c, headers, "#/components/headers/", header.Ref)
+}
+
+// resolveComponent looks up a local component reference, reporting
+// references that cannot be inlined.
+func resolveComponent[V any](c *Converter, components map[string]*V, prefix, ref string) *V {
+	if name, ok := strings.CutPrefix(ref, prefix); ok && components[name] != nil {
+		return components[name]
+	}
+	c.warn(SeverityWarning, CodeReference, fmt.Sprintf("reference %s cannot be inlined in Swagger 2.0 and was dropped", ref))
+	return nil
+}
+
 // convertHeader converts an OpenAPI Header to Swagger Header.
 func (c *Converter) convertHeader(header *openapi.Header) *swagger.Header {
 	if header == nil {
@@ -639,7 +836,9 @@ func (c *Converter) convertSchemas(schemas map[string]*openapi.Schema) map[strin
 
 	v2Schemas := make(map[string]*swagger.Schema, len(schemas))
 	for name, schema := range schemas {
+		leave := c.enter(name)
 		v2Schemas[name] = c.convertSchema(schema)
+		leave()
 	}
 
 	return v2Schemas
@@ -665,6 +864,10 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 		Example:      schema.Example,
 		ReadOnly:     schema.ReadOnly,
 		ExternalDocs: c.convertExternalDocs(schema.ExternalDocs),
+		Extensions:   copyExtensions(nil, schema.Extensions),
+	}
+	if v2Schema.Example == nil && len(schema.Examples) > 0 {
+		v2Schema.Example = schema.Examples[0]
 	}
 
 	// Handle optional numeric/int fields with pointers
@@ -672,14 +875,8 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 		v := schema.MultipleOf
 		v2Schema.MultipleOf = &v
 	}
-	if schema.Maximum != 0 {
-		v := schema.Maximum
-		v2Schema.Maximum = &v
-	}
-	if schema.Minimum != 0 {
-		v := schema.Minimum
-		v2Schema.Minimum = &v
-	}
+	v2Schema.Maximum = copyFloat(schema.Maximum)
+	v2Schema.Minimum = copyFloat(schema.Minimum)
 	if schema.MaxLength != 0 {
 		v := schema.MaxLength
 		v2Schema.MaxLength = &v
@@ -706,20 +903,40 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 	}
 
 	// ExclusiveMaximum/ExclusiveMinimum: boolean in Draft 4, number in 2020-12
-	if schema.ExclusiveMaximum != nil {
-		if val, ok := schema.ExclusiveMaximum.(bool); ok {
-			v2Schema.ExclusiveMaximum = val
+	if val, ok := schema.ExclusiveMaximum.(bool); ok {
+		v2Schema.ExclusiveMaximum = val
+	} else if limit, ok := toFloat(schema.ExclusiveMaximum); ok {
+		v2Schema.Maximum, v2Schema.ExclusiveMaximum = &limit, true
+	}
+	if val, ok := schema.ExclusiveMinimum.(bool); ok {
+		v2Schema.ExclusiveMinimum = val
+	} else if limit, ok := toFloat(schema.ExclusiveMinimum); ok {
+		v2Schema.Minimum, v2Schema.ExclusiveMinimum = &limit, true
+	}
+
+	// const → single-value enum
+	if schema.Const != nil {
+		leave := c.enter("const")
+		rewritten := len(v2Schema.Enum) == 0
+		if rewritten {
+			v2Schema.Enum = []interface{}{schema.Const}
 		}
-	}
-	if schema.ExclusiveMinimum != nil {
-		if val, ok := schema.ExclusiveMinimum.(bool); ok {
-			v2Schema.ExclusiveMinimum = val
+		switch {
+		case c.preserve:
+			c.drop(&v2Schema.Extensions, preservedConst, schema.Const, CodeSchemaKeyword, "const is not supported in Swagger 2.0")
+		case rewritten:
+			c.warn(SeverityInfo, CodeSchemaKeyword, "const is not supported in Swagger 2.0 and was rewritten as a single-value enum")
+		default:
+			c.warn(SeverityWarning, CodeSchemaKeyword, "const is not supported in Swagger 2.0 and was removed; the enum next to it is kept")
 		}
+		leave()
 	}
 
 	// Convert properties
 	if len(schema.Properties) > 0 {
+		leave := c.enter("properties")
 		v2Schema.Properties = c.convertSchemas(schema.Properties)
+		leave()
 	}
 
 	// Convert additionalProperties
@@ -728,20 +945,26 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 		case bool:
 			v2Schema.AdditionalProperties = v
 		case *openapi.Schema:
+			leave := c.enter("additionalProperties")
 			v2Schema.AdditionalProperties = c.convertSchema(v)
+			leave()
 		}
 	}
 
 	// Convert items
 	if schema.Items != nil {
+		leave := c.enter("items")
 		v2Schema.Items = c.convertSchema(schema.Items)
+		leave()
 	}
 
 	// Convert allOf
 	if len(schema.AllOf) > 0 {
 		v2Schema.AllOf = make([]*swagger.Schema, len(schema.AllOf))
 		for i, s := range schema.AllOf {
+			leave := c.enter("allOf", strconv.Itoa(i))
 			v2Schema.AllOf[i] = c.convertSchema(&s)
+			leave()
 		}
 	}
 
@@ -756,11 +979,12 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 	// Handle XML
 	if schema.XML != nil {
 		v2Schema.XML = &swagger.XML{
-			Name:      schema.XML.Name,
-			Namespace: schema.XML.Namespace,
-			Prefix:    schema.XML.Prefix,
-			Attribute: schema.XML.Attribute,
-			Wrapped:   schema.XML.Wrapped,
+			Name:       schema.XML.Name,
+			Namespace:  schema.XML.Namespace,
+			Prefix:     schema.XML.Prefix,
+			Attribute:  schema.XML.Attribute,
+			Wrapped:    schema.XML.Wrapped,
+			Extensions: copyExtensions(nil, schema.XML.Extensions),
 		}
 	}
 
@@ -770,7 +994,7 @@ func (c *Converter) convertSchema(schema *openapi.Schema) *swagger.Schema {
 	}
 
 	// Warn about unsupported Draft 2020-12 features
-	c.warnUnsupportedSchemaFeatures(schema)
+	c.warnUnsupportedSchemaFeatures(schema, v2Schema)
 
 	return v2Schema
 }
@@ -795,21 +1019,48 @@ func (c *Converter) isNullable(t interface{}) bool {
 }
 
 // warnUnsupportedSchemaFeatures warns about JSON Schema 2020-12 features not in Draft 4.
-func (c *Converter) warnUnsupportedSchemaFeatures(schema *openapi.Schema) {
+func (c *Converter) warnUnsupportedSchemaFeatures(schema *openapi.Schema, v2Schema *swagger.Schema) {
+	drop := func(key string, value interface{}, message, keyword string) {
+		leave := c.enter(keyword)
+		c.drop(&v2Schema.Extensions, key, value, CodeSchemaKeyword, message)
+		leave()
+	}
+
 	if len(schema.OneOf) > 0 {
-		c.warnings = append(c.warnings, "oneOf is limited in Swagger 2.0 (use with discriminator only)")
+		drop(preservedOneOf, schema.OneOf, "oneOf is limited in Swagger 2.0 (use with discriminator only)", "oneOf")
 	}
 	if len(schema.AnyOf) > 0 {
-		c.warnings = append(c.warnings, "anyOf is not supported in JSON Schema Draft 4 (Swagger 2.0)")
+		drop(preservedAnyOf, schema.AnyOf, "anyOf is not supported in JSON Schema Draft 4 (Swagger 2.0)", "anyOf")
 	}
 	if schema.Not != nil {
-		c.warnings = append(c.warnings, "not is limited in JSON Schema Draft 4 (Swagger 2.0)")
+		drop(preservedNot, schema.Not, "not is limited in JSON Schema Draft 4 (Swagger 2.0)", "not")
 	}
 	if len(schema.PrefixItems) > 0 {
-		c.warnings = append(c.warnings, "prefixItems is not supported in JSON Schema Draft 4 (Swagger 2.0)")
+		drop(preservedPrefixItems, schema.PrefixItems, "prefixItems is not supported in JSON Schema Draft 4 (Swagger 2.0)", "prefixItems")
+	}
+	if len(schema.Examples) > 1 || (c.preserve && len(schema.Examples) > 0) {
+		drop(preservedExamples, schema.Examples, "examples is not supported in Swagger 2.0; only the first one was kept as example", "examples")
 	}
 	if schema.WriteOnly {
-		c.warnings = append(c.warnings, "writeOnly is not supported in Swagger 2.0")
+		drop(preservedWriteOnly, schema.WriteOnly, "writeOnly is not supported in Swagger 2.0", "writeOnly")
+	}
+	if schema.XML != nil && schema.XML.NodeType != "" {
+		leave := c.enter("xml", "nodeType")
+		c.drop(&v2Schema.XML.Extensions, preservedNodeType, schema.XML.NodeType, CodeSchemaKeyword, "xml nodeType is not supported in Swagger 2.0")
+		leave()
+	}
+
+	// The model keeps the JSON Schema keywords it does not declare ($defs,
+	// if/then/else, ...) with the extensions
+	keywords := make(map[string]interface{})
+	for key, value := range schema.Extensions {
+		if !strings.HasPrefix(key, "x-") {
+			keywords[key] = value
+		}
+	}
+	if len(keywords) > 0 {
+		message := fmt.Sprintf("%s not supported in JSON Schema Draft 4 (Swagger 2.0)", strings.Join(sortedKeys(keywords), ", "))
+		c.drop(&v2Schema.Extensions, preservedKeywords, keywords, CodeSchemaKeyword, message)
 	}
 }
 
@@ -835,7 +1086,9 @@ func (c *Converter) convertResponseDefinitions(responses map[string]*openapi.Res
 
 	v2Responses := make(map[string]*swagger.Response, len(responses))
 	for name, resp := range responses {
+		leave := c.enter("components", "responses", name)
 		v2Responses[name] = c.convertResponse(resp)
+		leave()
 	}
 
 	return v2Responses
@@ -849,7 +1102,14 @@ func (c *Converter) convertSecuritySchemes(schemes map[string]*openapi.SecurityS
 
 	v2Schemes := make(map[string]*swagger.SecurityScheme, len(schemes))
 	for name, scheme := range schemes {
-		v2Schemes[name] = c.convertSecurityScheme(scheme)
+		leave := c.enter("components", "securitySchemes", name)
+		if v2Scheme := c.convertSecurityScheme(scheme); v2Scheme != nil {
+			v2Schemes[name] = v2Scheme
+		}
+		leave()
+	}
+	if len(v2Schemes) == 0 {
+		return nil
 	}
 
 	return v2Schemes
@@ -863,6 +1123,7 @@ func (c *Converter) convertSecurityScheme(scheme *openapi.SecurityScheme) *swagg
 
 	v2Scheme := &swagger.SecurityScheme{
 		Description: scheme.Description,
+		Extensions:  copyExtensions(nil, scheme.Extensions),
 	}
 
 	// Map OpenAPI 3.x types to Swagger 2.0 types
@@ -879,7 +1140,15 @@ func (c *Converter) convertSecurityScheme(scheme *openapi.SecurityScheme) *swagg
 			}
 			v2Scheme.Extensions["x-bearer-format"] = scheme.BearerFormat
 		} else {
-			c.warnings = append(c.warnings, fmt.Sprintf("http scheme %q is not directly supported in Swagger 2.0", scheme.Scheme))
+			// Other schemes (digest, hoba, ...) are sent in the Authorization header
+			v2Scheme.Type = "apiKey"
+			v2Scheme.In = "header"
+			v2Scheme.Name = "Authorization"
+			if v2Scheme.Extensions == nil {
+				v2Scheme.Extensions = make(map[string]interface{})
+			}
+			v2Scheme.Extensions["x-http-scheme"] = scheme.Scheme
+			c.warn(SeverityInfo, CodeSecurityScheme, fmt.Sprintf("http scheme %q is not supported in Swagger 2.0, converted to an Authorization header apiKey with x-http-scheme", scheme.Scheme))
 		}
 	case "apiKey":
 		v2Scheme.Type = "apiKey"
@@ -891,13 +1160,24 @@ func (c *Converter) convertSecurityScheme(scheme *openapi.SecurityScheme) *swagg
 
 		// Warn about OpenAPI 3.2.0 features
 		if scheme.OAuth2MetadataURL != "" {
-			c.warnings = append(c.warnings, "OAuth2MetadataURL is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+			leave := c.enter("oauth2MetadataUrl")
+			c.warn(SeverityWarning, CodeSecurityScheme, "OAuth2MetadataURL is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+			leave()
 		}
 	case "openIdConnect":
-		c.warnings = append(c.warnings, "openIdConnect is not supported in Swagger 2.0, converted to oauth2")
+		c.warn(SeverityWarning, CodeSecurityScheme, fmt.Sprintf("openIdConnect is not supported in Swagger 2.0, converted to oauth2 without flows; the discovery URL %q is kept in x-openid-connect-url", scheme.OpenIDConnectURL))
 		v2Scheme.Type = "oauth2"
+		if scheme.OpenIDConnectURL != "" {
+			if v2Scheme.Extensions == nil {
+				v2Scheme.Extensions = make(map[string]interface{})
+			}
+			v2Scheme.Extensions["x-openid-connect-url"] = scheme.OpenIDConnectURL
+		}
+	case "mutualTLS":
+		c.warn(SeverityWarning, CodeSecurityScheme, "mutualTLS security schemes are not supported in Swagger 2.0 and were removed along with the requirements using them")
+		return nil
 	default:
-		c.warnings = append(c.warnings, fmt.Sprintf("security scheme type %q is not supported in Swagger 2.0", scheme.Type))
+		c.warn(SeverityWarning, CodeSecurityScheme, fmt.Sprintf("security scheme type %q is not supported in Swagger 2.0", scheme.Type))
 	}
 
 	// Warn about deprecated field (OpenAPI 3.2.0)
@@ -906,7 +1186,9 @@ func (c *Converter) convertSecurityScheme(scheme *openapi.SecurityScheme) *swagg
 			v2Scheme.Extensions = make(map[string]interface{})
 		}
 		v2Scheme.Extensions["x-deprecated"] = true
-		c.warnings = append(c.warnings, "SecurityScheme.deprecated is not natively supported in Swagger 2.0, converted to x-deprecated extension")
+		leave := c.enter("deprecated")
+		c.warn(SeverityInfo, CodeSecurityScheme, "SecurityScheme.deprecated is not natively supported in Swagger 2.0, converted to x-deprecated extension")
+		leave()
 	}
 
 	return v2Scheme
@@ -917,6 +1199,7 @@ func (c *Converter) convertOAuth2Flows(scheme *openapi.SecurityScheme, v2Scheme
 	if scheme.Flows == nil {
 		return
 	}
+	defer c.enter("flows")()
 
 	// Swagger 2.0 supports only one flow at a time
 	// Priority: implicit > password > application > authorizationCode
@@ -955,27 +1238,52 @@ func (c *Converter) convertOAuth2Flows(scheme *openapi.SecurityScheme, v2Scheme
 	}
 	if scheme.Flows.DeviceAuthorization != nil {
 		flowCount++
-		c.warnings = append(c.warnings, "DeviceAuthorization OAuth2 flow is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+		leave := c.enter("deviceAuthorization")
+		c.warn(SeverityWarning, CodeOAuthFlow, "DeviceAuthorization OAuth2 flow is not supported in Swagger 2.0 (OpenAPI 3.2.0 feature) and was ignored")
+		leave()
 	}
 	if flowCount > 1 {
-		c.warnings = append(c.warnings, "multiple OAuth2 flows detected: Swagger 2.0 supports only one flow per security scheme")
+		c.warn(SeverityWarning, CodeOAuthFlow, "multiple OAuth2 flows detected: Swagger 2.0 supports only one flow per security scheme")
 	}
 }
 
 // convertSecurity converts security requirements.
 func (c *Converter) convertSecurity(security []openapi.SecurityRequirement) []swagger.SecurityRequirement {
-	if len(security) == 0 {
+	// An empty, non-nil list is an explicit opt-out and is preserved
+	if security == nil {
 		return nil
 	}
 
-	v2Security := make([]swagger.SecurityRequirement, len(security))
+	v2Security := make([]swagger.SecurityRequirement, 0, len(security))
 	for i, req := range security {
-		v2Security[i] = swagger.SecurityRequirement(req)
+		if name, dropped := c.droppedScheme(req); dropped {
+			leave := c.enter("security", strconv.Itoa(i))
+			c.warn(SeverityWarning, CodeSecurityRequirement, fmt.Sprintf("security requirement using %q was removed: the scheme is not supported in Swagger 2.0", name))
+			leave()
+			continue
+		}
+		v2Security = append(v2Security, swagger.SecurityRequirement(req))
+	}
+
+	// Dropping every alternative must not turn into an explicit opt-out
+	if len(v2Security) == 0 && len(security) > 0 {
+		return nil
 	}
 
 	return v2Security
 }
 
+// droppedScheme returns the first scheme of a requirement that was removed
+// during conversion.
+func (c *Converter) droppedScheme(req openapi.SecurityRequirement) (string, bool) {
+	for name := range req {
+		if c.droppedSchemes[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
 // convertTags converts tags.
 func (c *Converter) convertTags(tags []openapi.Tag) []swagger.Tag {
 	if len(tags) == 0 {
@@ -988,6 +1296,7 @@ func (c *Converter) convertTags(tags []openapi.Tag) []swagger.Tag {
 			Name:         tag.Name,
 			Description:  tag.Description,
 			ExternalDocs: c.convertExternalDocs(tag.ExternalDocs),
+			Extensions:   copyExtensions(nil, tag.Extensions),
 		}
 	}
 
@@ -1015,6 +1324,7 @@ func (c *Converter) ConvertToV3(swagger *swagger.Swagger) (*openapi.OpenAPI, err
 	if swagger == nil {
 		return nil, fmt.Errorf("input specification is nil")
 	}
+	finish := c.begin()
 
 	spec := &openapi.OpenAPI{
 		OpenAPI:      "3.1.0",
@@ -1023,6 +1333,7 @@ func (c *Converter) ConvertToV3(swagger *swagger.Swagger) (*openapi.OpenAPI, err
 		Tags:         c.convertTagsToV3(swagger.Tags),
 		ExternalDocs: c.convertExternalDocsToV3(swagger.ExternalDocs),
 		Security:     c.convertSecurityToV3(swagger.Security),
+		Extensions:   copyExtensions(nil, swagger.Extensions),
 	}
 
 	// Convert host/basePath/schemes to servers
@@ -1040,11 +1351,26 @@ func (c *Converter) ConvertToV3(swagger *swagger.Swagger) (*openapi.OpenAPI, err
 		}
 	}
 
+	// Restore constructs preserved by ConvertToV2
+	c.restore(&spec.Extensions, preservedServers, &spec.Servers)
+	c.restore(&spec.Extensions, preservedWebhooks, &spec.Webhooks)
+	c.restore(&spec.Extensions, preservedJSONSchemaDialect, &spec.JSONSchemaDialect)
+	var links map[string]*openapi.Link
+	if c.restore(&spec.Extensions, preservedLinks, &links) {
+		if spec.Components == nil {
+			spec.Components = &openapi.Components{}
+		}
+		spec.Components.Links = links
+	}
+
 	// Handle global consumes/produces
 	if len(swagger.Consumes) > 0 || len(swagger.Produces) > 0 {
-		c.warnings = append(c.warnings, "global consumes/produces are not directly supported in OpenAPI 3.1.0, applied to operations where missing")
+		c.warn(SeverityInfo, CodeMediaTypes, "global consumes/produces are not directly supported in OpenAPI 3.1.0, applied to operations where missing")
 	}
 
+	if err := finish(); err != nil {
+		return nil, err
+	}
 	return spec, nil
 }
 
@@ -1055,6 +1381,7 @@ func (c *Converter) convertInfoToV3(info swagger.Info) openapi.Info {
 		Description:    info.Description,
 		TermsOfService: info.TermsOfService,
 		Version:        info.Version,
+		Extensions:     copyExtensions(nil, info.Extensions),
 	}
 
 	if info.Contact != nil {
@@ -1109,7 +1436,9 @@ func (c *Converter) convertPathsToV3(paths swagger.Paths) openapi.Paths {
 
 	v3Paths := make(openapi.Paths, len(paths))
 	for path, pathItem := range paths {
+		leave := c.enter("paths", path)
 		v3Paths[path] = c.convertPathItemToV3(pathItem)
+		leave()
 	}
 
 	return v3Paths
@@ -1124,30 +1453,38 @@ func (c *Converter) convertPathItemToV3(pathItem *swagger.PathItem) *openapi.Pat
 	v3PathItem := &openapi.PathItem{
 		Ref:        c.convertRefToV3(pathItem.Ref),
 		Parameters: c.convertParametersToV3(pathItem.Parameters),
+		Extensions: copyExtensions(nil, pathItem.Extensions),
+	}
+
+	convert := func(method string, op *swagger.Operation) *openapi.Operation {
+		defer c.enter(method)()
+		return c.convertOperationToV3(op)
 	}
 
 	if pathItem.Get != nil {
-		v3PathItem.Get = c.convertOperationToV3(pathItem.Get)
+		v3PathItem.Get = convert("get", pathItem.Get)
 	}
 	if pathItem.Put != nil {
-		v3PathItem.Put = c.convertOperationToV3(pathItem.Put)
+		v3PathItem.Put = convert("put", pathItem.Put)
 	}
 	if pathItem.Post != nil {
-		v3PathItem.Post = c.convertOperationToV3(pathItem.Post)
+		v3PathItem.Post = convert("post", pathItem.Post)
 	}
 	if pathItem.Delete != nil {
-		v3PathItem.Delete = c.convertOperationToV3(pathItem.Delete)
+		v3PathItem.Delete = convert("delete", pathItem.Delete)
 	}
 	if pathItem.Options != nil {
-		v3PathItem.Options = c.convertOperationToV3(pathItem.Options)
+		v3PathItem.Options = convert("options", pathItem.Options)
 	}
 	if pathItem.Head != nil {
-		v3PathItem.Head = c.convertOperationToV3(pathItem.Head)
+		v3PathItem.Head = convert("head", pathItem.Head)
 	}
 	if pathItem.Patch != nil {
-		v3PathItem.Patch = c.convertOperationToV3(pathItem.Patch)
+		v3PathItem.Patch = convert("patch", pathItem.Patch)
 	}
 
+	c.restore(&v3PathItem.Extensions, preservedServers, &v3PathItem.Servers)
+
 	return v3PathItem
 }
 
@@ -1166,6 +1503,7 @@ func (c *Converter) convertOperationToV3(op *swagger.Operation) *openapi.Operati
 		Responses:    c.convertResponsesToV3(op.Responses, op.Produces),
 		Deprecated:   op.Deprecated,
 		Security:     c.convertSecurityToV3(op.Security),
+		Extensions:   copyExtensions(nil, op.Extensions),
 	}
 
 	// Convert parameters, separating body parameters into requestBody
@@ -1176,6 +1514,9 @@ func (c *Converter) convertOperationToV3(op *swagger.Operation) *openapi.Operati
 		v3Op.RequestBody = c.convertBodyParameterToRequestBody(bodyParam, op.Consumes)
 	}
 
+	c.restore(&v3Op.Extensions, preservedCallbacks, &v3Op.Callbacks)
+	c.restore(&v3Op.Extensions, preservedServers, &v3Op.Servers)
+
 	return v3Op
 }
 
@@ -1189,7 +1530,7 @@ func (c *Converter) separateBodyParameter(params []*swagger.Parameter) (nonBody
 	for _, param := range params {
 		if param.In == "body" {
 			if body != nil {
-				c.warnings = append(c.warnings, "multiple body parameters detected: only the last one is converted")
+				c.warn(SeverityWarning, CodeRequestBody, "multiple body parameters detected: only the last one is converted")
 			}
 			body = param
 		} else {
@@ -1221,6 +1562,7 @@ func (c *Converter) convertBodyParameterToRequestBody(param *swagger.Parameter,
 		Description: param.Description,
 		Content:     content,
 		Required:    param.Required,
+		Extensions:  copyExtensions(nil, param.Extensions),
 	}
 }
 
@@ -1249,6 +1591,9 @@ func (c *Converter) convertParameterToV3(param *swagger.Parameter) *openapi.Para
 	if param == nil {
 		return nil
 	}
+	if param.Ref != "" {
+		return &openapi.Parameter{Ref: c.convertRefToV3(param.Ref)}
+	}
 
 	v3Param := &openapi.Parameter{
 		Name:            param.Name,
@@ -1256,6 +1601,7 @@ func (c *Converter) convertParameterToV3(param *swagger.Parameter) *openapi.Para
 		Description:     param.Description,
 		Required:        param.Required,
 		AllowEmptyValue: param.AllowEmptyValue,
+		Extensions:      copyExtensions(nil, param.Extensions, "x-deprecated", "x-nullable"),
 	}
 
 	// Convert type/format to schema
@@ -1285,12 +1631,8 @@ func (c *Converter) convertParameterPropertiesToSchema(param *swagger.Parameter)
 	}
 
 	// Copy numeric/string constraints
-	if param.Maximum != nil {
-		schema.Maximum = *param.Maximum
-	}
-	if param.Minimum != nil {
-		schema.Minimum = *param.Minimum
-	}
+	schema.Maximum = copyFloat(param.Maximum)
+	schema.Minimum = copyFloat(param.Minimum)
 	if param.MaxLength != nil {
 		schema.MaxLength = *param.MaxLength
 	}
@@ -1336,12 +1678,8 @@ func (c *Converter) convertItemsToSchema(items *swagger.Items) *openapi.Schema {
 		Enum:    items.Enum,
 	}
 
-	if items.Maximum != nil {
-		schema.Maximum = *items.Maximum
-	}
-	if items.Minimum != nil {
-		schema.Minimum = *items.Minimum
-	}
+	schema.Maximum = copyFloat(items.Maximum)
+	schema.Minimum = copyFloat(items.Minimum)
 
 	// Handle nested arrays
 	if items.Items != nil {
@@ -1370,10 +1708,14 @@ func (c *Converter) convertResponseToV3(resp *swagger.Response, produces []strin
 	if resp == nil {
 		return nil
 	}
+	if resp.Ref != "" {
+		return &openapi.Response{Ref: c.convertRefToV3(resp.Ref)}
+	}
 
 	v3Resp := &openapi.Response{
 		Description: resp.Description,
 		Headers:     c.convertHeadersToV3(resp.Headers),
+		Extensions:  copyExtensions(nil, resp.Extensions),
 	}
 
 	// Convert schema to content
@@ -1401,6 +1743,8 @@ func (c *Converter) convertResponseToV3(resp *swagger.Response, produces []strin
 		v3Resp.Content = content
 	}
 
+	c.restore(&v3Resp.Extensions, preservedLinks, &v3Resp.Links)
+
 	return v3Resp
 }
 
@@ -1431,12 +1775,8 @@ func (c *Converter) convertHeaderToV3(header *swagger.Header) *openapi.Header {
 		Enum:    header.Enum,
 	}
 
-	if header.Maximum != nil {
-		schema.Maximum = *header.Maximum
-	}
-	if header.Minimum != nil {
-		schema.Minimum = *header.Minimum
-	}
+	schema.Maximum = copyFloat(header.Maximum)
+	schema.Minimum = copyFloat(header.Minimum)
 
 	return &openapi.Header{
 		Description: header.Description,
@@ -1466,7 +1806,6 @@ func (c *Converter) convertSchemaToV3(schema *swagger.Schema) *openapi.Schema {
 
 	v3Schema := &openapi.Schema{
 		Ref:          c.convertRefToV3(schema.Ref),
-		Type:         schema.Type,
 		Format:       schema.Format,
 		Title:        schema.Title,
 		Description:  schema.Description,
@@ -1479,16 +1818,17 @@ func (c *Converter) convertSchemaToV3(schema *swagger.Schema) *openapi.Schema {
 		UniqueItems:  schema.UniqueItems,
 	}
 
+	// Type is an interface{} in 3.x, so an empty string would not be omitted
+	if schema.Type != "" {
+		v3Schema.Type = schema.Type
+	}
+
 	// Copy numeric/string constraints
 	if schema.MultipleOf != nil {
 		v3Schema.MultipleOf = *schema.MultipleOf
 	}
-	if schema.Maximum != nil {
-		v3Schema.Maximum = *schema.Maximum
-	}
-	if schema.Minimum != nil {
-		v3Schema.Minimum = *schema.Minimum
-	}
+	v3Schema.Maximum = copyFloat(schema.Maximum)
+	v3Schema.Minimum = copyFloat(schema.Minimum)
 	if schema.MaxLength != nil {
 		v3Schema.MaxLength = *schema.MaxLength
 	}
@@ -1511,12 +1851,12 @@ func (c *Converter) convertSchemaToV3(schema *swagger.Schema) *openapi.Schema {
 		v3Schema.MinProperties = *schema.MinProperties
 	}
 
-	// ExclusiveMaximum/ExclusiveMinimum: boolean in Draft 4, can be boolean or number in 2020-12
-	if schema.ExclusiveMaximum {
-		v3Schema.ExclusiveMaximum = true
+	// ExclusiveMaximum/ExclusiveMinimum: boolean flags in Draft 4, the bound itself in 2020-12
+	if schema.ExclusiveMaximum && schema.Maximum != nil {
+		v3Schema.ExclusiveMaximum, v3Schema.Maximum = *schema.Maximum, nil
 	}
-	if schema.ExclusiveMinimum {
-		v3Schema.ExclusiveMinimum = true
+	if schema.ExclusiveMinimum && schema.Minimum != nil {
+		v3Schema.ExclusiveMinimum, v3Schema.Minimum = *schema.Minimum, nil
 	}
 
 	// Convert properties
@@ -1553,12 +1893,14 @@ func (c *Converter) convertSchemaToV3(schema *swagger.Schema) *openapi.Schema {
 	// Handle XML
 	if schema.XML != nil {
 		v3Schema.XML = &openapi.XML{
-			Name:      schema.XML.Name,
-			Namespace: schema.XML.Namespace,
-			Prefix:    schema.XML.Prefix,
-			Attribute: schema.XML.Attribute,
-			Wrapped:   schema.XML.Wrapped,
+			Name:       schema.XML.Name,
+			Namespace:  schema.XML.Namespace,
+			Prefix:     schema.XML.Prefix,
+			Attribute:  schema.XML.Attribute,
+			Wrapped:    schema.XML.Wrapped,
+			Extensions: copyExtensions(nil, schema.XML.Extensions),
 		}
+		c.restore(&v3Schema.XML.Extensions, preservedNodeType, &v3Schema.XML.NodeType)
 	}
 
 	// Handle discriminator (string in v2, object in v3)
@@ -1584,9 +1926,39 @@ func (c *Converter) convertSchemaToV3(schema *swagger.Schema) *openapi.Schema {
 		}
 	}
 
+	c.restore(&v3Schema.Extensions, preservedOneOf, &v3Schema.OneOf)
+	c.restore(&v3Schema.Extensions, preservedAnyOf, &v3Schema.AnyOf)
+	c.restore(&v3Schema.Extensions, preservedNot, &v3Schema.Not)
+	c.restore(&v3Schema.Extensions, preservedPrefixItems, &v3Schema.PrefixItems)
+	c.restore(&v3Schema.Extensions, preservedWriteOnly, &v3Schema.WriteOnly)
+
+	// const and examples replaced enum and example in Swagger 2.0
+	if c.restore(&v3Schema.Extensions, preservedConst, &v3Schema.Const) && reflect.DeepEqual(v3Schema.Enum, []interface{}{v3Schema.Const}) {
+		v3Schema.Enum = nil
+	}
+	if c.restore(&v3Schema.Extensions, preservedExamples, &v3Schema.Examples) && len(v3Schema.Examples) > 0 && reflect.DeepEqual(v3Schema.Example, v3Schema.Examples[0]) {
+		v3Schema.Example = nil
+	}
+
+	var keywords map[string]interface{}
+	if c.restore(&v3Schema.Extensions, preservedKeywords, &keywords) {
+		v3Schema.Extensions = copyKeywords(v3Schema.Extensions, keywords)
+	}
+
 	return v3Schema
 }
 
+// copyKeywords adds JSON Schema keywords to the extensions of a schema.
+func copyKeywords(extensions, keywords map[string]interface{}) map[string]interface{} {
+	for key, value := range keywords {
+		if extensions == nil {
+			extensions = make(map[string]interface{}, len(keywords))
+		}
+		extensions[key] = value
+	}
+	return extensions
+}
+
 // convertParameterDefinitionsToV3 converts component parameters.
 func (c *Converter) convertParameterDefinitionsToV3(params map[string]*swagger.Parameter) map[string]*openapi.Parameter {
 	if len(params) == 0 {
@@ -1623,7 +1995,9 @@ func (c *Converter) convertSecurityDefinitionsToV3(schemes map[string]*swagger.S
 
 	v3Schemes := make(map[string]*openapi.SecurityScheme, len(schemes))
 	for name, scheme := range schemes {
+		leave := c.enter("securityDefinitions", name)
 		v3Schemes[name] = c.convertSecuritySchemeToV3(scheme)
+		leave()
 	}
 
 	return v3Schemes
@@ -1637,6 +2011,11 @@ func (c *Converter) convertSecuritySchemeToV3(scheme *swagger.SecurityScheme) *o
 
 	v3Scheme := &openapi.SecurityScheme{
 		Description: scheme.Description,
+		Extensions:  copyExtensions(nil, scheme.Extensions, "x-bearer-format", "x-http-scheme", "x-openid-connect-url", "x-deprecated"),
+	}
+
+	if deprecated, ok := scheme.Extensions["x-deprecated"].(bool); ok {
+		v3Scheme.Deprecated = deprecated
 	}
 
 	// Map Swagger 2.0 types to OpenAPI 3.x types
@@ -1655,12 +2034,22 @@ func (c *Converter) convertSecuritySchemeToV3(scheme *swagger.SecurityScheme) *o
 				v3Scheme.Scheme = "bearer"
 				v3Scheme.BearerFormat = bearerFormat
 			}
+			if httpScheme, ok := scheme.Extensions["x-http-scheme"].(string); ok {
+				v3Scheme.Type = "http"
+				v3Scheme.Scheme = httpScheme
+				v3Scheme.Name, v3Scheme.In = "", ""
+			}
 		}
 	case "oauth2":
 		v3Scheme.Type = "oauth2"
 		v3Scheme.Flows = c.convertOAuth2FlowsToV3(scheme)
+		if discoveryURL, ok := scheme.Extensions["x-openid-connect-url"].(string); ok {
+			v3Scheme.Type = "openIdConnect"
+			v3Scheme.OpenIDConnectURL = discoveryURL
+			v3Scheme.Flows = nil
+		}
 	default:
-		c.warnings = append(c.warnings, fmt.Sprintf("unknown security scheme type %q", scheme.Type))
+		c.warn(SeverityWarning, CodeSecurityScheme, fmt.Sprintf("unknown security scheme type %q", scheme.Type))
 	}
 
 	return v3Scheme
@@ -1697,7 +2086,7 @@ func (c *Converter) convertOAuth2FlowsToV3(scheme *swagger.SecurityScheme) *open
 			Scopes:           scheme.Scopes,
 		}
 	default:
-		c.warnings = append(c.warnings, fmt.Sprintf("unknown OAuth2 flow %q", scheme.Flow))
+		c.warn(SeverityWarning, CodeOAuthFlow, fmt.Sprintf("unknown OAuth2 flow %q", scheme.Flow))
 	}
 
 	return flows
@@ -1705,7 +2094,8 @@ func (c *Converter) convertOAuth2FlowsToV3(scheme *swagger.SecurityScheme) *open
 
 // convertSecurityToV3 converts security requirements.
 func (c *Converter) convertSecurityToV3(security []swagger.SecurityRequirement) []openapi.SecurityRequirement {
-	if len(security) == 0 {
+	// An empty, non-nil list is an explicit opt-out and is preserved
+	if security == nil {
 		return nil
 	}
 
@@ -1729,6 +2119,7 @@ func (c *Converter) convertTagsToV3(tags []swagger.Tag) []openapi.Tag {
 			Name:         tag.Name,
 			Description:  tag.Description,
 			ExternalDocs: c.convertExternalDocsToV3(tag.ExternalDocs),
+			Extensions:   copyExtensions(nil, tag.Extensions),
 		}
 	}
 
@@ -1746,3 +2137,28 @@ func (c *Converter) convertExternalDocsToV3(docs *swagger.ExternalDocs) *openapi
 		URL:         docs.URL,
 	}
 }
+
+// copyFloat returns a copy of an optional number, so that the source and
+// converted documents do not share it.
+func copyFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	value := *v
+	return &value
+}
+
+// copyExtensions copies the x-* extensions of src into dst, skipping the
+// given keys that the converter maps to native fields.
+func copyExtensions(dst, src map[string]interface{}, skip ...string) map[string]interface{} {
+	for key, value := range src {
+		if !strings.HasPrefix(key, "x-") || slices.Contains(skip, key) {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]interface{}, len(src))
+		}
+		dst[key] = value
+	}
+	return dst
+}
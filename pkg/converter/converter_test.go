@@ -411,7 +411,7 @@ func TestGetWarnings(t *testing.T) {
 		t.Error("Initial warnings should be empty")
 	}
 
-	conv.warnings = append(conv.warnings, "test warning")
+	conv.warn(SeverityWarning, CodeServers, "test warning")
 
 	warnings := conv.GetWarnings()
 	if len(warnings) != 1 {
@@ -425,7 +425,8 @@ func TestGetWarnings(t *testing.T) {
 
 func TestClearWarnings(t *testing.T) {
 	conv := New()
-	conv.warnings = append(conv.warnings, "warning 1", "warning 2")
+	conv.warn(SeverityWarning, CodeServers, "warning 1")
+	conv.warn(SeverityWarning, CodeServers, "warning 2")
 
 	if len(conv.GetWarnings()) != 2 {
 		t.Error("Should have 2 warnings before clear")
@@ -967,8 +968,8 @@ func TestConvertParameter(t *testing.T) {
 				Schema: &openapi.Schema{
 					Type:    "integer",
 					Format:  "int32",
-					Minimum: 1,
-					Maximum: 100,
+					Minimum: &[]float64{1}[0],
+					Maximum: &[]float64{100}[0],
 					Default: 10,
 				},
 			},
@@ -1062,8 +1063,8 @@ func TestConvertSchemaToParameter(t *testing.T) {
 			schema: &openapi.Schema{
 				Type:    "number",
 				Format:  "double",
-				Minimum: 1.0,
-				Maximum: 100.0,
+				Minimum: &[]float64{1.0}[0],
+				Maximum: &[]float64{100.0}[0],
 			},
 			verify: func(t *testing.T, p *swagger.Parameter) {
 				if p.Type != "number" {
@@ -1689,8 +1690,8 @@ func TestConvertSchemaComplex(t *testing.T) {
 			schema: &openapi.Schema{
 				Type:          "number",
 				MultipleOf:    0.5,
-				Minimum:       float64(1.0),
-				Maximum:       float64(100.0),
+				Minimum:       &[]float64{1.0}[0],
+				Maximum:       &[]float64{100.0}[0],
 				MinLength:     int(5),
 				MaxLength:     int(50),
 				MinItems:      int(1),
@@ -1710,6 +1711,26 @@ func TestConvertSchemaComplex(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "schema with const and numeric exclusive bounds",
+			schema: &openapi.Schema{
+				Type:             "integer",
+				Const:            float64(5),
+				ExclusiveMinimum: float64(0),
+				ExclusiveMaximum: float64(10),
+			},
+			verify: func(t *testing.T, s *swagger.Schema) {
+				if len(s.Enum) != 1 || s.Enum[0] != float64(5) {
+					t.Errorf("Enum = %v, want [5]", s.Enum)
+				}
+				if s.Minimum == nil || *s.Minimum != 0 || !s.ExclusiveMinimum {
+					t.Errorf("Minimum = %v exclusive %v, want exclusive 0", s.Minimum, s.ExclusiveMinimum)
+				}
+				if s.Maximum == nil || *s.Maximum != 10 || !s.ExclusiveMaximum {
+					t.Errorf("Maximum = %v exclusive %v, want exclusive 10", s.Maximum, s.ExclusiveMaximum)
+				}
+			},
+		},
 		{
 			name: "schema with properties",
 			schema: &openapi.Schema{
@@ -1846,9 +1867,9 @@ func TestConvertSchemaComplex(t *testing.T) {
 			name: "schema with exclusive maximum/minimum (bool)",
 			schema: &openapi.Schema{
 				Type:             "number",
-				Maximum:          100.0,
+				Maximum:          &[]float64{100.0}[0],
 				ExclusiveMaximum: true,
-				Minimum:          0.0,
+				Minimum:          &[]float64{0.0}[0],
 				ExclusiveMinimum: true,
 			},
 			verify: func(t *testing.T, s *swagger.Schema) {
@@ -2719,10 +2740,10 @@ func TestConvertItemsToSchema(t *testing.T) {
 				MaxLength: &[]int{50}[0],
 			},
 			verify: func(t *testing.T, s *openapi.Schema) {
-				if s.Minimum != 0 {
+				if s.Minimum == nil || *s.Minimum != 0 {
 					t.Errorf("Minimum = %v, want 0", s.Minimum)
 				}
-				if s.Maximum != 100 {
+				if s.Maximum == nil || *s.Maximum != 100 {
 					t.Errorf("Maximum = %v, want 100", s.Maximum)
 				}
 			},
@@ -2792,7 +2813,7 @@ func TestConvertParameterPropertiesToSchema(t *testing.T) {
 				MaxLength: &[]int{50}[0],
 			},
 			verify: func(t *testing.T, s *openapi.Schema) {
-				if s.Minimum != 1 {
+				if s.Minimum == nil || *s.Minimum != 1 {
 					t.Errorf("Minimum = %v, want 1", s.Minimum)
 				}
 			},
@@ -2881,6 +2902,86 @@ func TestConvertOperation(t *testing.T) {
 	}
 }
 
+// TestConvertReferences tests component references in both directions
+func TestConvertReferences(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "Test", Version: "1.0"},
+		Paths: openapi.Paths{
+			"/pets": {
+				Post: &openapi.Operation{
+					Parameters:  []openapi.Parameter{{Ref: "#/components/parameters/Limit"}},
+					RequestBody: &openapi.RequestBody{Ref: "#/components/requestBodies/Pet"},
+					Responses: openapi.Responses{
+						"201": {
+							Description: "Created",
+							Headers: map[string]*openapi.Header{
+								"X-Rate":  {Ref: "#/components/headers/Rate"},
+								"X-Other": {Ref: "#/components/headers/Missing"},
+							},
+						},
+						"default": {Ref: "#/components/responses/Error"},
+					},
+				},
+			},
+		},
+		Components: &openapi.Components{
+			Parameters: map[string]*openapi.Parameter{"Limit": {Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer"}}},
+			RequestBodies: map[string]*openapi.RequestBody{"Pet": {Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/Pet"}},
+			}}},
+			Responses: map[string]*openapi.Response{"Error": {Description: "Error"}},
+			Headers:   map[string]*openapi.Header{"Rate": {Schema: &openapi.Schema{Type: "integer"}}},
+			Schemas:   map[string]*openapi.Schema{"Pet": {Type: "object"}},
+		},
+	}
+
+	conv := New()
+	v2, err := conv.ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	post := v2.Paths["/pets"].Post
+	if len(post.Parameters) != 2 || post.Parameters[0].Ref != "#/parameters/Limit" {
+		t.Fatalf("parameters = %+v, want the Limit reference and a body parameter", post.Parameters)
+	}
+	if body := post.Parameters[1]; body.In != "body" || body.Schema == nil || body.Schema.Ref != "#/definitions/Pet" {
+		t.Errorf("body parameter = %+v, want the inlined Pet request body", body)
+	}
+	if got := post.Responses["default"].Ref; got != "#/responses/Error" {
+		t.Errorf("default response ref = %q, want #/responses/Error", got)
+	}
+	headers := post.Responses["201"].Headers
+	if headers["X-Rate"] == nil || headers["X-Rate"].Type != "integer" {
+		t.Errorf("X-Rate header = %+v, want the inlined Rate header", headers["X-Rate"])
+	}
+	if _, ok := headers["X-Other"]; ok {
+		t.Error("unresolvable header reference should be dropped")
+	}
+	found := false
+	for _, d := range conv.GetDiagnostics() {
+		found = found || d.Pointer == "/paths/~1pets/post/responses/201/headers/X-Other" && d.Code == CodeReference
+	}
+	if !found {
+		t.Errorf("diagnostics = %v, want a reference warning for X-Other", conv.GetWarnings())
+	}
+
+	v3, err := New().ConvertToV3(v2)
+	if err != nil {
+		t.Fatalf("ConvertToV3() error = %v", err)
+	}
+	back := v3.Paths["/pets"].Post
+	if got := back.Parameters[0].Ref; got != "#/components/parameters/Limit" {
+		t.Errorf("parameter ref = %q, want #/components/parameters/Limit", got)
+	}
+	if got := back.Responses["default"].Ref; got != "#/components/responses/Error" {
+		t.Errorf("default response ref = %q, want #/components/responses/Error", got)
+	}
+}
+
 // TestConvertParameters tests parameters array conversion V3 to V2
 func TestConvertParameters(t *testing.T) {
 	conv := New()
@@ -4187,3 +4288,286 @@ func BenchmarkRoundtripV3ToV2ToV3(b *testing.B) {
 		}
 	}
 }
+
+func TestConvertExtensionsRoundTrip(t *testing.T) {
+	t.Parallel()
+	ext := map[string]interface{}{"x-internal": true}
+
+	original := &swagger.Swagger{
+		Swagger:    "2.0",
+		Info:       swagger.Info{Title: "API", Version: "1.0.0", Extensions: ext},
+		Tags:       []swagger.Tag{{Name: "users", Extensions: ext}},
+		Extensions: ext,
+		Paths: map[string]*swagger.PathItem{
+			"/users": {
+				Get: &swagger.Operation{
+					Parameters: []*swagger.Parameter{{Name: "id", In: "query", Type: "string", Extensions: ext}},
+					Responses:  map[string]*swagger.Response{"200": {Description: "OK", Extensions: ext}},
+					Extensions: ext,
+				},
+			},
+		},
+	}
+
+	spec3, err := New().ConvertToV3(original)
+	if err != nil {
+		t.Fatalf("V2->V3 conversion error: %v", err)
+	}
+
+	get := spec3.Paths["/users"].Get
+	for name, got := range map[string]map[string]interface{}{
+		"root":      spec3.Extensions,
+		"info":      spec3.Info.Extensions,
+		"tag":       spec3.Tags[0].Extensions,
+		"operation": get.Extensions,
+		"parameter": get.Parameters[0].Extensions,
+		"response":  get.Responses["200"].Extensions,
+	} {
+		if got["x-internal"] != true {
+			t.Errorf("V3 %s extensions = %v, want x-internal", name, got)
+		}
+	}
+
+	spec2, err := New().ConvertToV2(spec3)
+	if err != nil {
+		t.Fatalf("V3->V2 conversion error: %v", err)
+	}
+
+	get2 := spec2.Paths["/users"].Get
+	for name, got := range map[string]map[string]interface{}{
+		"root":      spec2.Extensions,
+		"info":      spec2.Info.Extensions,
+		"tag":       spec2.Tags[0].Extensions,
+		"operation": get2.Extensions,
+		"parameter": get2.Parameters[0].Extensions,
+		"response":  get2.Responses["200"].Extensions,
+	} {
+		if got["x-internal"] != true {
+			t.Errorf("V2 %s extensions = %v, want x-internal", name, got)
+		}
+	}
+}
+
+func TestConvertResponseRangesToV2(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Paths: openapi.Paths{
+			"/items": {
+				Get: &openapi.Operation{
+					Responses: openapi.Responses{
+						"200":     {Description: "OK"},
+						"5XX":     {Description: "Server error"},
+						"default": {Description: "Error"},
+					},
+				},
+			},
+		},
+	}
+
+	conv := New()
+	spec2, err := conv.ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	responses := spec2.Paths["/items"].Get.Responses
+	if len(responses) != 2 || responses["200"] == nil || responses["default"] == nil {
+		t.Errorf("responses = %v, want 200 and default", responses)
+	}
+
+	found := false
+	for _, warning := range conv.GetWarnings() {
+		if strings.Contains(warning, "5XX") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning about 5XX, got %v", conv.GetWarnings())
+	}
+}
+
+func TestConvertEmptySecurity(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Paths: openapi.Paths{
+			"/health": {
+				Get: &openapi.Operation{
+					Security:  []openapi.SecurityRequirement{},
+					Responses: openapi.Responses{"200": {Description: "OK"}},
+				},
+			},
+		},
+	}
+
+	spec2, err := New().ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+	if security := spec2.Paths["/health"].Get.Security; security == nil || len(security) != 0 {
+		t.Errorf("V2 security = %#v, want explicit empty list", security)
+	}
+
+	spec3, err := New().ConvertToV3(spec2)
+	if err != nil {
+		t.Fatalf("ConvertToV3() error = %v", err)
+	}
+	if security := spec3.Paths["/health"].Get.Security; security == nil || len(security) != 0 {
+		t.Errorf("V3 security = %#v, want explicit empty list", security)
+	}
+}
+
+func TestConvertServerVariablesToV2(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Servers: []openapi.Server{{
+			URL: "https://{region}.example.com/{version}/{undefined}",
+			Variables: map[string]*openapi.ServerVariable{
+				"region":  {Default: "us-east", Enum: []string{"us-east", "eu-west"}},
+				"version": {Default: "v1"},
+			},
+		}},
+		Paths: openapi.Paths{
+			"/files": {
+				Servers: []openapi.Server{{URL: "https://files.example.com"}},
+				Get:     &openapi.Operation{Responses: openapi.Responses{"200": {Description: "OK"}}},
+			},
+		},
+	}
+
+	conv := New()
+	spec2, err := conv.ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	if spec2.Host != "us-east.example.com" || spec2.BasePath != "/v1/" {
+		t.Errorf("host = %q, basePath = %q, want defaults substituted", spec2.Host, spec2.BasePath)
+	}
+
+	warnings := strings.Join(conv.GetWarnings(), "\n")
+	for _, want := range []string{
+		`{region}`,
+		`"us-east"`,
+		`other values dropped: eu-west`,
+		`{version}`,
+		`{undefined}`,
+		`path-level servers`,
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings do not mention %s:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestConvertSecuritySchemesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Paths: openapi.Paths{
+			"/items": {
+				Get: &openapi.Operation{
+					Security: []openapi.SecurityRequirement{
+						{"ClientCert": {}},
+						{"Digest": {}, "OIDC": {"openid"}},
+					},
+					Responses: openapi.Responses{"200": {Description: "OK"}},
+				},
+			},
+			"/certs": {
+				Get: &openapi.Operation{
+					Security:  []openapi.SecurityRequirement{{"ClientCert": {}}},
+					Responses: openapi.Responses{"200": {Description: "OK"}},
+				},
+			},
+		},
+		Components: &openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"Digest":     {Type: "http", Scheme: "digest"},
+				"OIDC":       {Type: "openIdConnect", OpenIDConnectURL: "https://id.example.com/.well-known/openid-configuration"},
+				"ClientCert": {Type: "mutualTLS"},
+			},
+		},
+	}
+
+	conv := New()
+	spec2, err := conv.ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	if _, ok := spec2.SecurityDefinitions["ClientCert"]; ok {
+		t.Error("mutualTLS scheme should be removed")
+	}
+	if digest := spec2.SecurityDefinitions["Digest"]; digest == nil || digest.Type != "apiKey" || digest.Extensions["x-http-scheme"] != "digest" {
+		t.Errorf("Digest = %+v, want Authorization apiKey with x-http-scheme", digest)
+	}
+	if security := spec2.Paths["/items"].Get.Security; len(security) != 1 || len(security[0]) != 2 {
+		t.Errorf("/items security = %v, want only the Digest && OIDC requirement", security)
+	}
+	if security := spec2.Paths["/certs"].Get.Security; security != nil {
+		t.Errorf("/certs security = %#v, want nil", security)
+	}
+
+	warnings := strings.Join(conv.GetWarnings(), "\n")
+	for _, want := range []string{`"digest"`, "x-openid-connect-url", "mutualTLS", `using "ClientCert"`} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings do not mention %s:\n%s", want, warnings)
+		}
+	}
+
+	spec3, err := New().ConvertToV3(spec2)
+	if err != nil {
+		t.Fatalf("ConvertToV3() error = %v", err)
+	}
+	schemes := spec3.Components.SecuritySchemes
+	if digest := schemes["Digest"]; digest.Type != "http" || digest.Scheme != "digest" || digest.Name != "" {
+		t.Errorf("V3 Digest = %+v, want http digest", digest)
+	}
+	if oidc := schemes["OIDC"]; oidc.Type != "openIdConnect" || oidc.OpenIDConnectURL != spec.Components.SecuritySchemes["OIDC"].OpenIDConnectURL || len(oidc.Extensions) != 0 {
+		t.Errorf("V3 OIDC = %+v, want openIdConnect with its discovery URL", oidc)
+	}
+}
+
+func TestConvertResponseLinksToV2(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Paths: openapi.Paths{
+			"/users": {
+				Post: &openapi.Operation{
+					Responses: openapi.Responses{
+						"201": {
+							Description: "Created",
+							Links:       map[string]*openapi.Link{"GetUser": {OperationID: "getUser"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conv := New()
+	spec2, err := conv.ConvertToV2(spec)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+	if spec2.Paths["/users"].Post.Responses["201"] == nil {
+		t.Fatal("response 201 was not converted")
+	}
+	if warnings := strings.Join(conv.GetWarnings(), "\n"); !strings.Contains(warnings, "links") || !strings.Contains(warnings, "GetUser") {
+		t.Errorf("expected a warning about the GetUser link, got %v", conv.GetWarnings())
+	}
+}
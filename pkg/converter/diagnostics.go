@@ -0,0 +1,178 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Severity classifies a conversion diagnostic.
+type Severity string
+
+const (
+	// SeverityInfo marks a change that keeps the meaning of the document,
+	// e.g. a field moved to an x- extension.
+	SeverityInfo Severity = "info"
+
+	// SeverityWarning marks a lossy change: something was removed or only
+	// approximated in the target version.
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic codes, one per kind of construct.
+const (
+	CodeWebhooks            = "webhooks"
+	CodeJSONSchemaDialect   = "json-schema-dialect"
+	CodeInfo                = "info"
+	CodeServers             = "servers"
+	CodeServerVariable      = "server-variable"
+	CodeQueryMethod         = "query-method"
+	CodeCallbacks           = "callbacks"
+	CodeLinks               = "links"
+	CodePathItems           = "path-items"
+	CodeStreaming           = "streaming"
+	CodeRequestBody         = "request-body"
+	CodeMediaTypes          = "media-types"
+	CodeStatusRange         = "status-range"
+	CodeSchemaKeyword       = "schema-keyword"
+	CodeSecurityScheme      = "security-scheme"
+	CodeOAuthFlow           = "oauth2-flow"
+	CodeSecurityRequirement = "security-requirement"
+	CodeExtension           = "extension"
+	CodeReference           = "reference"
+)
+
+// Diagnostic describes a change made while converting a document.
+type Diagnostic struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Pointer  string   `json:"pointer,omitempty"` // JSON pointer of the source node, e.g. /paths/~1users/get
+	Message  string   `json:"message"`
+}
+
+// String formats the diagnostic as "pointer: message".
+func (d Diagnostic) String() string {
+	if d.Pointer == "" {
+		return d.Message
+	}
+	return d.Pointer + ": " + d.Message
+}
+
+// LossyConversionError is returned in strict mode when a conversion
+// produced warnings.
+type LossyConversionError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *LossyConversionError) Error() string {
+	messages := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		messages[i] = d.String()
+	}
+	return fmt.Sprintf("lossy conversion (%d warnings): %s", len(e.Diagnostics), strings.Join(messages, "; "))
+}
+
+// Report is the JSON conversion report written for CI.
+type Report struct {
+	Source      string       `json:"source"`
+	Target      string       `json:"target"`
+	Warnings    int          `json:"warnings"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Report returns the diagnostics of a conversion from source to target
+// version, ordered by pointer.
+func (c *Converter) Report(source, target string) Report {
+	diagnostics := slices.Clone(c.diagnostics)
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return strings.Compare(a.Pointer, b.Pointer)
+	})
+
+	report := Report{Source: source, Target: target, Diagnostics: diagnostics}
+	for _, d := range diagnostics {
+		if d.Severity == SeverityWarning {
+			report.Warnings++
+		}
+	}
+	return report
+}
+
+// WriteReport writes the conversion report as indented JSON to path.
+func (c *Converter) WriteReport(path, source, target string) error {
+	data, err := json.MarshalIndent(c.Report(source, target), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// SetStrict makes conversions fail with a *LossyConversionError when they
+// produce a warning.
+func (c *Converter) SetStrict(strict bool) {
+	c.strict = strict
+}
+
+// GetDiagnostics returns all conversion diagnostics.
+func (c *Converter) GetDiagnostics() []Diagnostic {
+	return c.diagnostics
+}
+
+// warn records a diagnostic for the node being converted.
+func (c *Converter) warn(severity Severity, code, message string) {
+	c.warnAt(c.pointer(), severity, code, message)
+}
+
+// warnAt records a diagnostic for the node at pointer.
+func (c *Converter) warnAt(pointer string, severity Severity, code, message string) {
+	c.diagnostics = append(c.diagnostics, Diagnostic{
+		Code:     code,
+		Severity: severity,
+		Pointer:  pointer,
+		Message:  message,
+	})
+}
+
+// enter appends tokens to the location of the node being converted and
+// returns a function that restores it: defer c.enter("paths", path)().
+func (c *Converter) enter(tokens ...string) func() {
+	n := len(c.location)
+	c.location = append(c.location, tokens...)
+	return func() { c.location = c.location[:n] }
+}
+
+// pointer returns the JSON pointer of the node being converted.
+func (c *Converter) pointer() string {
+	if len(c.location) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, token := range c.location {
+		b.WriteString("/")
+		b.WriteString(pointerToken(token))
+	}
+	return b.String()
+}
+
+// begin starts a conversion and returns a function that reports the
+// warnings it produced in strict mode.
+func (c *Converter) begin() func() error {
+	c.location = nil
+	mark := len(c.diagnostics)
+	return func() error {
+		if !c.strict {
+			return nil
+		}
+		var lossy []Diagnostic
+		for _, d := range c.diagnostics[mark:] {
+			if d.Severity == SeverityWarning {
+				lossy = append(lossy, d)
+			}
+		}
+		if len(lossy) == 0 {
+			return nil
+		}
+		return &LossyConversionError{Diagnostics: lossy}
+	}
+}
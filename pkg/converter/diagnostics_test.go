@@ -0,0 +1,227 @@
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func diagnosticsSpec() *openapi.OpenAPI {
+	return &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Servers: []openapi.Server{{
+			URL:       "https://{region}.example.com/v1",
+			Variables: map[string]*openapi.ServerVariable{"region": {Default: "eu"}},
+		}},
+		Paths: openapi.Paths{
+			"/users/{id}": {
+				Get: &openapi.Operation{
+					OperationID: "getUser",
+					Responses: openapi.Responses{
+						"200": {
+							Description: "OK",
+							Links:       map[string]*openapi.Link{"self": {OperationID: "getUser"}},
+							Content: map[string]*openapi.MediaType{
+								"application/json": {Schema: &openapi.Schema{
+									Type:       "object",
+									Properties: map[string]*openapi.Schema{"secret": {Type: "string", WriteOnly: true}},
+								}},
+							},
+						},
+						"5XX": {Description: "Error"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertToV2Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	conv := New()
+	if _, err := conv.ConvertToV2(diagnosticsSpec()); err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	want := map[string]Diagnostic{
+		CodeServerVariable: {Severity: SeverityInfo, Pointer: "/servers/0"},
+		CodeLinks:          {Severity: SeverityWarning, Pointer: "/paths/~1users~1{id}/get/responses/200/links"},
+		CodeStatusRange:    {Severity: SeverityWarning, Pointer: "/paths/~1users~1{id}/get/responses/5XX"},
+		CodeSchemaKeyword: {
+			Severity: SeverityWarning,
+			Pointer:  "/paths/~1users~1{id}/get/responses/200/content/application~1json/schema/properties/secret/writeOnly",
+		},
+	}
+
+	got := make(map[string]Diagnostic)
+	for _, d := range conv.GetDiagnostics() {
+		got[d.Code] = d
+	}
+	if len(got) != len(want) {
+		t.Errorf("diagnostics = %v, want codes %v", conv.GetDiagnostics(), want)
+	}
+	for code, w := range want {
+		d, ok := got[code]
+		if !ok {
+			t.Errorf("missing %s diagnostic", code)
+			continue
+		}
+		if d.Severity != w.Severity || d.Pointer != w.Pointer {
+			t.Errorf("%s diagnostic = %s %q, want %s %q", code, d.Severity, d.Pointer, w.Severity, w.Pointer)
+		}
+	}
+
+	for i, warning := range conv.GetWarnings() {
+		if warning != conv.GetDiagnostics()[i].String() {
+			t.Errorf("GetWarnings()[%d] = %q, want %q", i, warning, conv.GetDiagnostics()[i].String())
+		}
+	}
+}
+
+func TestConvertToV2ConstDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	spec := diagnosticsSpec()
+	spec.Components = &openapi.Components{Schemas: map[string]*openapi.Schema{
+		"Kind":  {Type: "string", Const: "user"},
+		"Mixed": {Type: "string", Const: "a", Enum: []interface{}{"a", "b"}},
+	}}
+
+	conv := New()
+	if _, err := conv.ConvertToV2(spec); err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	want := map[string]Severity{
+		"/components/schemas/Kind/const":  SeverityInfo,
+		"/components/schemas/Mixed/const": SeverityWarning,
+	}
+	for _, d := range conv.GetDiagnostics() {
+		if severity, ok := want[d.Pointer]; ok {
+			if d.Severity != severity {
+				t.Errorf("%s diagnostic = %s, want %s", d.Pointer, d.Severity, severity)
+			}
+			delete(want, d.Pointer)
+		}
+	}
+	for pointer := range want {
+		t.Errorf("missing diagnostic for %s", pointer)
+	}
+}
+
+func TestStrictConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		convert  func(*Converter) error
+		wantLoss int
+	}{
+		{
+			name: "lossy to 2.0",
+			convert: func(c *Converter) error {
+				_, err := c.ConvertToV2(diagnosticsSpec())
+				return err
+			},
+			wantLoss: 3,
+		},
+		{
+			name: "info only to 2.0",
+			convert: func(c *Converter) error {
+				spec := diagnosticsSpec()
+				spec.Paths = nil
+				_, err := c.ConvertToV2(spec)
+				return err
+			},
+		},
+		{
+			name: "lossy down-level",
+			convert: func(c *Converter) error {
+				return c.DownLevel(downLevelSpec(), "3.0.3")
+			},
+			wantLoss: 15,
+		},
+		{
+			name: "info only to 3.x",
+			convert: func(c *Converter) error {
+				_, err := c.ConvertToV3(&swagger.Swagger{
+					Swagger:  "2.0",
+					Info:     swagger.Info{Title: "API", Version: "1.0.0"},
+					Consumes: []string{"application/json"},
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conv := New()
+			conv.SetStrict(true)
+			err := tt.convert(conv)
+
+			var lossy *LossyConversionError
+			if tt.wantLoss == 0 {
+				if err != nil {
+					t.Fatalf("error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.As(err, &lossy) {
+				t.Fatalf("error = %v, want *LossyConversionError", err)
+			}
+			if len(lossy.Diagnostics) != tt.wantLoss {
+				t.Errorf("lossy diagnostics = %d, want %d: %v", len(lossy.Diagnostics), tt.wantLoss, lossy.Diagnostics)
+			}
+			for _, d := range lossy.Diagnostics {
+				if d.Severity != SeverityWarning {
+					t.Errorf("lossy diagnostic %v has severity %s", d, d.Severity)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	t.Parallel()
+
+	conv := New()
+	if _, err := conv.ConvertToV2(diagnosticsSpec()); err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := conv.WriteReport(path, "3.1.0", "2.0.0"); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid report: %v", err)
+	}
+
+	if report.Source != "3.1.0" || report.Target != "2.0.0" {
+		t.Errorf("report versions = %s -> %s", report.Source, report.Target)
+	}
+	if report.Warnings != 3 || len(report.Diagnostics) != 4 {
+		t.Errorf("report has %d warnings in %d diagnostics, want 3 in 4", report.Warnings, len(report.Diagnostics))
+	}
+	for i := 1; i < len(report.Diagnostics); i++ {
+		if report.Diagnostics[i-1].Pointer > report.Diagnostics[i].Pointer {
+			t.Errorf("diagnostics not ordered by pointer: %v", report.Diagnostics)
+		}
+	}
+}
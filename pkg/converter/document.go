@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Convert converts a specification to the target version: "2.0" or
+// "2.0.0" for Swagger 2.0, any 3.x version for OpenAPI 3. OpenAPI 3.x
+// documents are up- or down-leveled in place.
+func (c *Converter) Convert(spec oas.Specification, version string) (oas.Specification, error) {
+	toV2 := strings.HasPrefix(version, "2.")
+	if !toV2 && !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported target version %q", version)
+	}
+
+	switch s := spec.(type) {
+	case *swagger.Swagger:
+		if toV2 {
+			return s, nil
+		}
+		v3, err := c.ConvertToV3(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.DownLevel(v3, version); err != nil {
+			return nil, err
+		}
+		return v3, nil
+	case *openapi.OpenAPI:
+		if toV2 {
+			return c.ConvertToV2(s)
+		}
+		relevel := c.DownLevel
+		if upgrades(s.OpenAPI, version) {
+			relevel = c.UpLevel
+		}
+		if err := relevel(s, version); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("unsupported specification type %T", spec)
+}
+
+// upgrades reports whether version is a newer 3.x minor version than the
+// source version.
+func upgrades(source, version string) bool {
+	from, ok := openAPIMinor(source)
+	to, valid := openAPIMinor(version)
+	return ok && valid && to > from
+}
+
+// Marshal encodes a specification as indented JSON or, for the "yaml" and
+// "yml" formats, as YAML.
+func Marshal(spec oas.Specification, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		doc, err := oas.YAMLNode(spec)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "json", "":
+		data, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	return nil, fmt.Errorf("unsupported output format %q", format)
+}
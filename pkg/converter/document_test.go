@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+const swaggerYAML = `swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+host: api.example.com
+basePath: /v1
+paths:
+  /pets:
+    get:
+      produces: [application/json]
+      responses:
+        200:
+          description: OK
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+`
+
+func TestConvertAndMarshal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "swagger.yaml")
+	if err := os.WriteFile(path, []byte(swaggerYAML), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	spec, err := oas.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if response := spec.(*swagger.Swagger).Paths["/pets"].Get.Responses["200"]; response == nil || response.Schema == nil {
+		t.Fatal("YAML status code keys were not loaded")
+	}
+
+	v3, err := New().Convert(spec, "3.0.3")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	oas3, ok := v3.(*openapi.OpenAPI)
+	if !ok || oas3.OpenAPI != "3.0.3" {
+		t.Fatalf("Convert() = %T %v, want OpenAPI 3.0.3", v3, v3.GetVersion())
+	}
+	if len(oas3.Servers) != 1 || oas3.Servers[0].URL != "https://api.example.com/v1" {
+		t.Errorf("servers = %+v, want converted host and basePath", oas3.Servers)
+	}
+
+	data, err := Marshal(v3, "yaml")
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{"openapi: 3.0.3", "$ref: '#/components/schemas/Pet'"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("YAML output does not contain %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), `type: ""`) {
+		t.Errorf("YAML output contains an empty type:\n%s", data)
+	}
+
+	reloaded, err := oas.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() of converted document error = %v", err)
+	}
+	v2, err := New().Convert(reloaded, "2.0.0")
+	if err != nil {
+		t.Fatalf("Convert() back to 2.0 error = %v", err)
+	}
+	if v2.GetVersion() != "2.0" {
+		t.Errorf("GetVersion() = %q, want 2.0", v2.GetVersion())
+	}
+
+	if _, err := New().Convert(spec, "1.2"); err == nil {
+		t.Error("Convert() to 1.2 should fail")
+	}
+	if _, err := Marshal(spec, "xml"); err == nil {
+		t.Error("Marshal() as xml should fail")
+	}
+}
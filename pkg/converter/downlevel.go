@@ -0,0 +1,325 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// DownLevel rewrites an OpenAPI 3.x document in place for an older 3.x
+// target version (3.0.x or 3.1.x) and sets its openapi field. Features the
+// target cannot express are rewritten when an equivalent exists (type
+// arrays become nullable, const a single-value enum, ...) and removed with
+// a warning otherwise.
+func (c *Converter) DownLevel(spec *openapi.OpenAPI, version string) error {
+	if spec == nil {
+		return fmt.Errorf("input specification is nil")
+	}
+
+	minor, ok := openAPIMinor(version)
+	if !ok {
+		return fmt.Errorf("unsupported target version %q", version)
+	}
+
+	finish := c.begin()
+	d := &downLeveler{c: c, minor: minor}
+	d.document(spec)
+	oas.WalkV3(spec, oas.V3Visitor{
+		PathItem:  d.pathItem,
+		MediaType: d.mediaType,
+		Schema:    d.schema,
+	})
+	if err := finish(); err != nil {
+		return err
+	}
+	spec.OpenAPI = version
+	return nil
+}
+
+// openAPIMinor returns the minor version of a 3.x version string.
+func openAPIMinor(version string) (int, bool) {
+	rest, ok := strings.CutPrefix(version, "3.")
+	if !ok {
+		return 0, false
+	}
+	minor, _, _ := strings.Cut(rest, ".")
+	n, err := strconv.Atoi(minor)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// downLeveler carries the target minor version through the document walk.
+type downLeveler struct {
+	c     *Converter
+	minor int
+}
+
+// warn records a lossy change at pointer.
+func (d *downLeveler) warn(pointer, code, format string, args ...interface{}) {
+	d.c.warnAt(pointer, SeverityWarning, code, fmt.Sprintf(format, args...))
+}
+
+// note records a change at pointer that keeps the document's meaning.
+func (d *downLeveler) note(pointer, code, format string, args ...interface{}) {
+	d.c.warnAt(pointer, SeverityInfo, code, fmt.Sprintf(format, args...))
+}
+
+// pointerToken escapes a JSON pointer token.
+func pointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// sortedKeys returns the keys of a map in order, so warnings are stable.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// document rewrites the document-level fields and removes the sections the
+// target cannot express before the walk descends into the rest.
+func (d *downLeveler) document(spec *openapi.OpenAPI) {
+	if d.minor < 1 {
+		if spec.JSONSchemaDialect != "" {
+			d.warn("/jsonSchemaDialect", CodeJSONSchemaDialect, "jsonSchemaDialect requires OpenAPI 3.1 and was removed")
+			spec.JSONSchemaDialect = ""
+		}
+		if spec.Info.Summary != "" {
+			d.warn("/info/summary", CodeInfo, "info.summary requires OpenAPI 3.1 and was removed")
+			spec.Info.Summary = ""
+		}
+		if spec.Info.License != nil && spec.Info.License.Identifier != "" {
+			d.warn("/info/license/identifier", CodeInfo, "license identifier requires OpenAPI 3.1 and was removed")
+			spec.Info.License.Identifier = ""
+		}
+		if len(spec.Webhooks) > 0 {
+			d.warn("/webhooks", CodeWebhooks, "webhooks require OpenAPI 3.1 and were removed: %s", strings.Join(sortedKeys(spec.Webhooks), ", "))
+			spec.Webhooks = nil
+		}
+	}
+
+	components := spec.Components
+	if components == nil {
+		return
+	}
+
+	if d.minor < 1 && len(components.PathItems) > 0 {
+		d.warn("/components/pathItems", CodePathItems, "components.pathItems require OpenAPI 3.1 and were removed: %s", strings.Join(sortedKeys(components.PathItems), ", "))
+		components.PathItems = nil
+	}
+
+	for _, name := range sortedKeys(components.SecuritySchemes) {
+		pointer := "/components/securitySchemes/" + pointerToken(name)
+		if d.securityScheme(pointer, components.SecuritySchemes[name]) {
+			delete(components.SecuritySchemes, name)
+		}
+	}
+}
+
+// securityScheme rewrites a security scheme and reports whether it has to
+// be removed.
+func (d *downLeveler) securityScheme(pointer string, scheme *openapi.SecurityScheme) bool {
+	if scheme == nil {
+		return false
+	}
+
+	if d.minor < 1 && scheme.Type == "mutualTLS" {
+		d.warn(pointer, CodeSecurityScheme, "mutualTLS security schemes require OpenAPI 3.1 and were removed")
+		return true
+	}
+
+	if d.minor < 2 {
+		if scheme.Deprecated {
+			scheme.Extensions = copyExtensions(scheme.Extensions, map[string]interface{}{"x-deprecated": true})
+			scheme.Deprecated = false
+			d.note(pointer+"/deprecated", CodeSecurityScheme, "deprecated requires OpenAPI 3.2 and was moved to x-deprecated")
+		}
+		if scheme.OAuth2MetadataURL != "" {
+			d.warn(pointer+"/oauth2MetadataUrl", CodeSecurityScheme, "oauth2MetadataUrl requires OpenAPI 3.2 and was removed")
+			scheme.OAuth2MetadataURL = ""
+		}
+		if scheme.Flows != nil && scheme.Flows.DeviceAuthorization != nil {
+			d.warn(pointer+"/flows/deviceAuthorization", CodeOAuthFlow, "the deviceAuthorization flow requires OpenAPI 3.2 and was removed")
+			scheme.Flows.DeviceAuthorization = nil
+		}
+	}
+
+	return false
+}
+
+// pathItem removes the QUERY operation before the walk reaches it.
+func (d *downLeveler) pathItem(loc oas.Location, item *openapi.PathItem) {
+	if d.minor < 2 && item.Query != nil {
+		d.warn(loc.Pointer+"/query", CodeQueryMethod, "the QUERY method requires OpenAPI 3.2 and the operation was removed")
+		item.Query = nil
+	}
+}
+
+// mediaType rewrites a sequential media type before the walk reaches its schemas.
+func (d *downLeveler) mediaType(loc oas.Location, mediaType *openapi.MediaType) {
+	if d.minor >= 2 || (mediaType.ItemSchema == nil && mediaType.ItemEncoding == nil) {
+		return
+	}
+
+	// A stream of items is described as an array of them
+	if mediaType.Schema == nil && mediaType.ItemSchema != nil {
+		mediaType.Schema = &openapi.Schema{Type: "array", Items: mediaType.ItemSchema}
+		d.warn(loc.Pointer+"/itemSchema", CodeStreaming, "itemSchema requires OpenAPI 3.2 and was rewritten as an array schema")
+	} else {
+		d.warn(loc.Pointer+"/itemSchema", CodeStreaming, "itemSchema and itemEncoding require OpenAPI 3.2 and were removed")
+	}
+	mediaType.ItemSchema, mediaType.ItemEncoding = nil, nil
+}
+
+// schema rewrites a single schema; the walk then descends into the schemas
+// nested in the result.
+func (d *downLeveler) schema(loc oas.Location, schema *openapi.Schema) {
+	if d.minor < 2 && schema.XML != nil && schema.XML.NodeType != "" {
+		if schema.XML.NodeType == "attribute" {
+			schema.XML.Attribute = true
+		} else {
+			d.warn(loc.Pointer+"/xml/nodeType", CodeSchemaKeyword, "xml nodeType %q requires OpenAPI 3.2 and was removed", schema.XML.NodeType)
+		}
+		schema.XML.NodeType = ""
+	}
+
+	if d.minor < 1 {
+		d.schemaTo30(loc.Pointer, schema)
+	}
+}
+
+// schemaTo30 rewrites the JSON Schema 2020-12 keywords of a single schema
+// into their OpenAPI 3.0 equivalents.
+func (d *downLeveler) schemaTo30(pointer string, schema *openapi.Schema) {
+	// type: [string, "null"] → type: string, nullable: true
+	if types := schemaTypes(schema.Type); types != nil {
+		var nonNull []string
+		for _, t := range types {
+			if t == "null" {
+				schema.Nullable = true
+			} else {
+				nonNull = append(nonNull, t)
+			}
+		}
+		switch len(nonNull) {
+		case 0:
+			schema.Type = nil
+			d.warn(pointer+"/type", CodeSchemaKeyword, "a schema that only allows null cannot be expressed in OpenAPI 3.0 and now accepts any value")
+		case 1:
+			schema.Type = nonNull[0]
+		default:
+			// Several types: one alternative per type
+			schema.Type = nil
+			for _, t := range nonNull {
+				schema.AnyOf = append(schema.AnyOf, openapi.Schema{Type: t})
+			}
+		}
+	}
+
+	// const → single-value enum
+	if schema.Const != nil {
+		if len(schema.Enum) == 0 {
+			schema.Enum = []interface{}{schema.Const}
+		}
+		schema.Const = nil
+	}
+
+	// examples → example, keeping the first one
+	if len(schema.Examples) > 0 {
+		if schema.Example == nil {
+			schema.Example = schema.Examples[0]
+		}
+		if len(schema.Examples) > 1 {
+			d.warn(pointer+"/examples", CodeSchemaKeyword, "examples requires OpenAPI 3.1; only the first one was kept as example")
+		}
+		schema.Examples = nil
+	}
+
+	// Numeric exclusive bounds → boolean flags on minimum/maximum
+	if limit, ok := toFloat(schema.ExclusiveMinimum); ok {
+		schema.Minimum, schema.ExclusiveMinimum = &limit, true
+	}
+	if limit, ok := toFloat(schema.ExclusiveMaximum); ok {
+		schema.Maximum, schema.ExclusiveMaximum = &limit, true
+	}
+
+	// Tuples cannot be expressed: items accept any of the prefix schemas
+	if len(schema.PrefixItems) > 0 {
+		if schema.Items == nil {
+			items := &openapi.Schema{}
+			for _, prefix := range schema.PrefixItems {
+				if prefix != nil {
+					items.AnyOf = append(items.AnyOf, *prefix)
+				}
+			}
+			schema.Items = items
+		}
+		d.warn(pointer+"/prefixItems", CodeSchemaKeyword, "prefixItems requires OpenAPI 3.1 and was removed; item positions are no longer enforced")
+		schema.PrefixItems = nil
+	}
+
+	// The model keeps the JSON Schema 2020-12 keywords it does not declare
+	// ($defs, if/then/else, unevaluatedProperties, ...) with the extensions;
+	// 3.0 schemas only allow x- extensions next to their own keywords
+	for _, keyword := range sortedKeys(schema.Extensions) {
+		if !strings.HasPrefix(keyword, "x-") {
+			d.warn(pointer+"/"+pointerToken(keyword), CodeSchemaKeyword, "%s requires OpenAPI 3.1 and was removed", keyword)
+			delete(schema.Extensions, keyword)
+		}
+	}
+	if len(schema.Extensions) == 0 {
+		schema.Extensions = nil
+	}
+
+	// Siblings of $ref are ignored in 3.0, so keep them next to an allOf
+	if ref := schema.Ref; ref != "" {
+		siblings := *schema
+		siblings.Ref = ""
+		if !reflect.DeepEqual(siblings, openapi.Schema{}) {
+			*schema = siblings
+			schema.AllOf = append([]openapi.Schema{{Ref: ref}}, schema.AllOf...)
+		}
+	}
+}
+
+// schemaTypes returns the types of a schema whose type is a list.
+func schemaTypes(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// toFloat converts a numeric JSON value, ignoring booleans.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
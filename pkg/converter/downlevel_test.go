@@ -0,0 +1,221 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func downLevelSpec() *openapi.OpenAPI {
+	return &openapi.OpenAPI{
+		OpenAPI:           "3.2.0",
+		JSONSchemaDialect: "https://spec.openapis.org/oas/3.1/dialect/base",
+		Info: openapi.Info{
+			Title: "API", Version: "1.0.0", Summary: "Short",
+			License: &openapi.License{Name: "MIT", Identifier: "MIT"},
+		},
+		Paths: openapi.Paths{
+			"/events": {
+				Get: &openapi.Operation{
+					Responses: openapi.Responses{
+						"200": {
+							Description: "Stream",
+							Content: map[string]*openapi.MediaType{
+								"application/jsonl": {ItemSchema: &openapi.Schema{Ref: "#/components/schemas/Event"}},
+							},
+						},
+					},
+				},
+				Post: &openapi.Operation{
+					RequestBody: &openapi.RequestBody{
+						Content: map[string]*openapi.MediaType{
+							"multipart/form-data": {Encoding: map[string]*openapi.Encoding{
+								"file": {Headers: map[string]*openapi.Header{"X-Trace": {Schema: &openapi.Schema{Type: []string{"string", "null"}}}}},
+							}},
+						},
+					},
+					Responses: openapi.Responses{"202": {Description: "Accepted"}},
+				},
+				Query: &openapi.Operation{Responses: openapi.Responses{"200": {Description: "OK"}}},
+			},
+		},
+		Webhooks: map[string]*openapi.PathItem{"created": {Post: &openapi.Operation{}}},
+		Components: &openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"Event": {
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"name":   {Type: []interface{}{"string", "null"}},
+						"kind":   {Type: "string", Const: "event"},
+						"count":  {Type: "integer", ExclusiveMinimum: float64(0)},
+						"debt":   {Type: "number", ExclusiveMaximum: float64(0)},
+						"id":     {Type: []string{"string", "integer"}},
+						"parent": {Ref: "#/components/schemas/Event", Description: "Parent event"},
+						"pair":   {Type: "array", PrefixItems: []*openapi.Schema{{Type: "string"}, {Type: "integer"}}},
+						"attr":   {Type: "string", XML: &openapi.XML{NodeType: "attribute"}},
+						"sample": {Type: "string", Examples: []interface{}{"a", "b"}},
+						"void":   {Type: []string{"null"}},
+					},
+					Extensions: map[string]interface{}{
+						"$defs":                 map[string]interface{}{"Id": map[string]interface{}{"type": "string"}},
+						"if":                    map[string]interface{}{"required": []interface{}{"kind"}},
+						"unevaluatedProperties": false,
+						"x-keep":                true,
+					},
+				},
+			},
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"Cert":   {Type: "mutualTLS"},
+				"OAuth2": {Type: "oauth2", Deprecated: true, OAuth2MetadataURL: "https://id.example.com/meta", Flows: &openapi.OAuthFlows{DeviceAuthorization: &openapi.OAuthFlow{}}},
+			},
+		},
+	}
+}
+
+func TestDownLevelTo30(t *testing.T) {
+	t.Parallel()
+
+	spec := downLevelSpec()
+	conv := New()
+	if err := conv.DownLevel(spec, "3.0.3"); err != nil {
+		t.Fatalf("DownLevel() error = %v", err)
+	}
+
+	if spec.OpenAPI != "3.0.3" || spec.JSONSchemaDialect != "" || spec.Webhooks != nil {
+		t.Errorf("root = %q, dialect %q, webhooks %v; want 3.0.3 without 3.1 fields", spec.OpenAPI, spec.JSONSchemaDialect, spec.Webhooks)
+	}
+	if spec.Info.Summary != "" || spec.Info.License.Identifier != "" {
+		t.Errorf("info = %+v, want summary and license identifier removed", spec.Info)
+	}
+
+	encoding := spec.Paths["/events"].Post.RequestBody.Content["multipart/form-data"].Encoding["file"]
+	if trace := encoding.Headers["X-Trace"].Schema; trace.Type != "string" || !trace.Nullable {
+		t.Errorf("encoding header schema = %+v, want nullable string", trace)
+	}
+	if spec.Paths["/events"].Query != nil {
+		t.Error("query operation should be removed")
+	}
+
+	media := spec.Paths["/events"].Get.Responses["200"].Content["application/jsonl"]
+	if media.ItemSchema != nil || media.Schema == nil || media.Schema.Type != "array" || media.Schema.Items.Ref != "#/components/schemas/Event" {
+		t.Errorf("media type = %+v, want an array of the item schema", media)
+	}
+
+	props := spec.Components.Schemas["Event"].Properties
+	if name := props["name"]; name.Type != "string" || !name.Nullable {
+		t.Errorf("name = %+v, want nullable string", name)
+	}
+	if kind := props["kind"]; kind.Const != nil || !reflect.DeepEqual(kind.Enum, []interface{}{"event"}) {
+		t.Errorf("kind = %+v, want single-value enum", kind)
+	}
+	if count := props["count"]; count.Minimum == nil || *count.Minimum != 0 || count.ExclusiveMinimum != true {
+		t.Errorf("count = %+v, want minimum 0 with exclusiveMinimum true", count)
+	}
+	if debt := props["debt"]; debt.Maximum == nil || *debt.Maximum != 0 || debt.ExclusiveMaximum != true {
+		t.Errorf("debt = %+v, want maximum 0 with exclusiveMaximum true", debt)
+	}
+	// A zero bound must survive encoding next to its boolean flag
+	if got := mustJSON(t, props["count"]); !strings.Contains(got, `"minimum":0`) {
+		t.Errorf("count = %s, want minimum 0", got)
+	}
+	if got := mustJSON(t, props["debt"]); !strings.Contains(got, `"maximum":0`) {
+		t.Errorf("debt = %s, want maximum 0", got)
+	}
+	if id := props["id"]; id.Type != nil || len(id.AnyOf) != 2 || id.AnyOf[1].Type != "integer" {
+		t.Errorf("id = %+v, want anyOf string and integer", id)
+	}
+	if parent := props["parent"]; parent.Ref != "" || len(parent.AllOf) != 1 || parent.AllOf[0].Ref != "#/components/schemas/Event" || parent.Description != "Parent event" {
+		t.Errorf("parent = %+v, want $ref wrapped in allOf next to its description", parent)
+	}
+	if pair := props["pair"]; pair.PrefixItems != nil || pair.Items == nil || len(pair.Items.AnyOf) != 2 {
+		t.Errorf("pair = %+v, want items anyOf the prefix items", pair)
+	}
+	if attr := props["attr"].XML; attr.NodeType != "" || !attr.Attribute {
+		t.Errorf("attr xml = %+v, want attribute flag", attr)
+	}
+	if sample := props["sample"]; sample.Examples != nil || sample.Example != "a" {
+		t.Errorf("sample = %+v, want the first example kept as example", sample)
+	}
+	if void := props["void"]; void.Type != nil || !void.Nullable {
+		t.Errorf("void = %+v, want nullable without a type", void)
+	}
+	if event := spec.Components.Schemas["Event"]; !reflect.DeepEqual(event.Extensions, map[string]interface{}{"x-keep": true}) {
+		t.Errorf("Event extensions = %v, want only x-keep", event.Extensions)
+	}
+
+	schemes := spec.Components.SecuritySchemes
+	if _, ok := schemes["Cert"]; ok {
+		t.Error("mutualTLS scheme should be removed")
+	}
+	if oauth := schemes["OAuth2"]; oauth.Deprecated || oauth.Extensions["x-deprecated"] != true || oauth.OAuth2MetadataURL != "" || oauth.Flows.DeviceAuthorization != nil {
+		t.Errorf("OAuth2 = %+v, want 3.2 fields removed", oauth)
+	}
+
+	warnings := strings.Join(conv.GetWarnings(), "\n")
+	for _, want := range []string{
+		"/jsonSchemaDialect:",
+		"/webhooks: webhooks require OpenAPI 3.1 and were removed: created",
+		"/paths/~1events/query:",
+		"/paths/~1events/get/responses/200/content/application~1jsonl/itemSchema:",
+		"/components/schemas/Event/properties/pair/prefixItems:",
+		"/components/schemas/Event/properties/sample/examples:",
+		"/components/schemas/Event/properties/void/type:",
+		"/components/schemas/Event/$defs:",
+		"/components/schemas/Event/if:",
+		"/components/schemas/Event/unevaluatedProperties:",
+		"/components/securitySchemes/Cert:",
+		"/components/securitySchemes/OAuth2/flows/deviceAuthorization:",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings do not contain %q:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestDownLevelTo31(t *testing.T) {
+	t.Parallel()
+
+	spec := downLevelSpec()
+	conv := New()
+	if err := conv.DownLevel(spec, "3.1.0"); err != nil {
+		t.Fatalf("DownLevel() error = %v", err)
+	}
+
+	if spec.Paths["/events"].Query != nil {
+		t.Error("query operation should be removed")
+	}
+	if spec.Webhooks == nil || spec.JSONSchemaDialect == "" || spec.Info.Summary == "" {
+		t.Error("3.1 features should be kept")
+	}
+	if name := spec.Components.Schemas["Event"].Properties["name"]; name.Nullable {
+		t.Errorf("name = %+v, type arrays are valid in 3.1", name)
+	}
+	if _, ok := spec.Components.SecuritySchemes["Cert"]; !ok {
+		t.Error("mutualTLS is valid in 3.1")
+	}
+}
+
+func TestDownLevelErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    *openapi.OpenAPI
+		version string
+	}{
+		{name: "nil spec", version: "3.0.0"},
+		{name: "swagger version", spec: &openapi.OpenAPI{}, version: "2.0"},
+		{name: "invalid minor", spec: &openapi.OpenAPI{}, version: "3.x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := New().DownLevel(tt.spec, tt.version); err == nil {
+				t.Errorf("DownLevel(%q) should fail", tt.version)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Extensions that carry OpenAPI 3.x constructs through a Swagger 2.0
+// document in preserve mode. Each holds the 3.x JSON of the field it is
+// named after.
+const (
+	preservedWebhooks          = "x-oas3-webhooks"
+	preservedJSONSchemaDialect = "x-oas3-jsonSchemaDialect"
+	preservedServers           = "x-oas3-servers"
+	preservedLinks             = "x-oas3-links"
+	preservedCallbacks         = "x-oas3-callbacks"
+	preservedOneOf             = "x-oas3-oneOf"
+	preservedAnyOf             = "x-oas3-anyOf"
+	preservedNot               = "x-oas3-not"
+	preservedConst             = "x-oas3-const"
+	preservedPrefixItems       = "x-oas3-prefixItems"
+	preservedExamples          = "x-oas3-examples"
+	preservedWriteOnly         = "x-oas3-writeOnly"
+	preservedNodeType          = "x-oas3-nodeType"
+	preservedKeywords          = "x-oas3-keywords" // JSON Schema keywords the schema model keeps with its extensions
+)
+
+// SetPreserve makes ConvertToV2 keep constructs Swagger 2.0 cannot express
+// in x-oas3-* extensions, which ConvertToV3 restores.
+func (c *Converter) SetPreserve(preserve bool) {
+	c.preserve = preserve
+}
+
+// drop reports a construct with no Swagger 2.0 equivalent. In preserve mode
+// value is stored under key in ext and the diagnostic is informational.
+func (c *Converter) drop(ext *map[string]interface{}, key string, value interface{}, code, message string) {
+	if !c.preserve {
+		c.warn(SeverityWarning, code, message)
+		return
+	}
+
+	data, err := json.Marshal(value)
+	var stashed interface{}
+	if err == nil {
+		err = json.Unmarshal(data, &stashed)
+	}
+	if err != nil {
+		c.warn(SeverityWarning, code, fmt.Sprintf("%s; it could not be preserved: %v", message, err))
+		return
+	}
+
+	if *ext == nil {
+		*ext = make(map[string]interface{})
+	}
+	(*ext)[key] = stashed
+	c.warn(SeverityInfo, code, fmt.Sprintf("%s; preserved in %s", message, key))
+}
+
+// restore decodes the construct preserved under key into target and removes
+// the extension from ext. It reports whether a construct was restored.
+func (c *Converter) restore(ext *map[string]interface{}, key string, target interface{}) bool {
+	value, ok := (*ext)[key]
+	if !ok {
+		return false
+	}
+	delete(*ext, key)
+	if len(*ext) == 0 {
+		*ext = nil
+	}
+
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
+		leave := c.enter(key)
+		c.warn(SeverityWarning, CodeExtension, fmt.Sprintf("invalid %s extension was ignored: %v", key, err))
+		leave()
+		return false
+	}
+	return true
+}
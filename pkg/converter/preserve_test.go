@@ -0,0 +1,167 @@
+package converter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func preserveSpec() *openapi.OpenAPI {
+	return &openapi.OpenAPI{
+		OpenAPI:           "3.1.0",
+		JSONSchemaDialect: "https://spec.openapis.org/oas/3.1/dialect/base",
+		Info:              openapi.Info{Title: "Pets", Version: "1.0.0"},
+		Servers: []openapi.Server{
+			{
+				URL:         "https://{region}.example.com/v1",
+				Description: "Regional",
+				Variables:   map[string]*openapi.ServerVariable{"region": {Default: "eu", Enum: []string{"eu", "us"}}},
+			},
+			{URL: "https://staging.example.com/v1"},
+		},
+		Paths: openapi.Paths{
+			"/pets": {
+				Servers: []openapi.Server{{URL: "https://pets.example.com"}},
+				Post: &openapi.Operation{
+					OperationID: "createPet",
+					Servers:     []openapi.Server{{URL: "https://write.example.com"}},
+					Callbacks: map[string]*openapi.Callback{
+						"onAdopted": {
+							"{$request.body#/callbackUrl}": {
+								Post: &openapi.Operation{Responses: openapi.Responses{"200": {Description: "OK"}}},
+							},
+						},
+					},
+					Responses: openapi.Responses{
+						"201": {
+							Description: "Created",
+							Links: map[string]*openapi.Link{
+								"GetPet": {OperationID: "getPet", Parameters: map[string]interface{}{"id": "$response.body#/id"}},
+							},
+							Content: map[string]*openapi.MediaType{
+								"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/Pet"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Webhooks: map[string]*openapi.PathItem{
+			"petAdopted": {Post: &openapi.Operation{Responses: openapi.Responses{"200": {Description: "OK"}}}},
+		},
+		Components: &openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"Pet": {
+					OneOf: []openapi.Schema{{Ref: "#/components/schemas/Cat"}, {Ref: "#/components/schemas/Dog"}},
+				},
+				"Cat": {Type: "object", Properties: map[string]*openapi.Schema{"lives": {Type: "integer"}}},
+				"Dog": {
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"tag":  {AnyOf: []openapi.Schema{{Type: "string"}, {Type: "integer"}}},
+						"name": {Type: "string", Not: &openapi.Schema{Enum: []interface{}{""}}},
+					},
+				},
+				"Owner": {
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"kind": {Type: "string", Const: "person"},
+						"pair": {Type: "array", PrefixItems: []*openapi.Schema{{Type: "string"}, {Type: "integer"}}},
+						"nick": {Type: []string{"string", "null"}, Examples: []interface{}{"Al", "Bo"}},
+						"pin":  {Type: "string", WriteOnly: true},
+						"pets": {Type: "integer", ExclusiveMinimum: float64(0)},
+						"lang": {Type: "string", XML: &openapi.XML{NodeType: "attribute"}},
+					},
+					Extensions: map[string]interface{}{
+						"$defs": map[string]interface{}{"Id": map[string]interface{}{"type": "string"}},
+					},
+				},
+			},
+			Links: map[string]*openapi.Link{"Self": {OperationID: "createPet"}},
+		},
+	}
+}
+
+func mustJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestPreserveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := preserveSpec()
+
+	conv := New()
+	conv.SetPreserve(true)
+	conv.SetStrict(true)
+	v2, err := conv.ConvertToV2(original)
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+	for _, d := range conv.GetDiagnostics() {
+		if d.Severity != SeverityInfo {
+			t.Errorf("preserved construct reported as %s: %v", d.Severity, d)
+		}
+	}
+
+	back := New()
+	final, err := back.ConvertToV3(v2)
+	if err != nil {
+		t.Fatalf("ConvertToV3() error = %v", err)
+	}
+	if warnings := back.GetWarnings(); len(warnings) != 0 {
+		t.Errorf("ConvertToV3() warnings = %v", warnings)
+	}
+
+	if got, want := mustJSON(t, final), mustJSON(t, original); got != want {
+		t.Errorf("round trip changed the document:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestPreserveDisabled(t *testing.T) {
+	t.Parallel()
+
+	conv := New()
+	v2, err := conv.ConvertToV2(preserveSpec())
+	if err != nil {
+		t.Fatalf("ConvertToV2() error = %v", err)
+	}
+	if doc := mustJSON(t, v2); strings.Contains(doc, "x-oas3-") {
+		t.Errorf("x-oas3-* extensions written without preserve mode: %s", doc)
+	}
+
+	final, err := New().ConvertToV3(v2)
+	if err != nil {
+		t.Fatalf("ConvertToV3() error = %v", err)
+	}
+	if len(final.Webhooks) != 0 || final.JSONSchemaDialect != "" || len(final.Servers) != 1 {
+		t.Errorf("constructs restored without preserve mode: webhooks=%v dialect=%q servers=%v",
+			final.Webhooks, final.JSONSchemaDialect, final.Servers)
+	}
+}
+
+func TestRestoreInvalidExtension(t *testing.T) {
+	t.Parallel()
+
+	ext := map[string]interface{}{preservedServers: "not a list", "x-other": true}
+	var servers []openapi.Server
+
+	conv := New()
+	if conv.restore(&ext, preservedServers, &servers) {
+		t.Error("restore() = true for an invalid extension")
+	}
+	if _, ok := ext[preservedServers]; ok || len(ext) != 1 {
+		t.Errorf("extensions after restore = %v, want only x-other", ext)
+	}
+	diagnostics := conv.GetDiagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].Code != CodeExtension || diagnostics[0].Severity != SeverityWarning {
+		t.Errorf("diagnostics = %v, want one %s warning", diagnostics, CodeExtension)
+	}
+}
@@ -0,0 +1,93 @@
+package converter
+
+import (
+	"fmt"
+	"slices"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// UpLevel rewrites an OpenAPI 3.x document in place for a newer 3.x target
+// version and sets its openapi field. Coming from 3.0, the schema keywords
+// 3.1 replaced are rewritten into their JSON Schema 2020-12 equivalents:
+// nullable becomes a "null" type, boolean exclusiveMinimum/exclusiveMaximum
+// become numeric bounds and example becomes examples.
+func (c *Converter) UpLevel(spec *openapi.OpenAPI, version string) error {
+	if spec == nil {
+		return fmt.Errorf("input specification is nil")
+	}
+
+	minor, ok := openAPIMinor(version)
+	if !ok {
+		return fmt.Errorf("unsupported target version %q", version)
+	}
+	source, ok := openAPIMinor(spec.OpenAPI)
+	if !ok {
+		return fmt.Errorf("unsupported source version %q", spec.OpenAPI)
+	}
+	if minor < source {
+		return fmt.Errorf("cannot up-level OpenAPI %s to %s", spec.OpenAPI, version)
+	}
+
+	finish := c.begin()
+	if source < 1 && minor >= 1 {
+		oas.WalkV3(spec, oas.V3Visitor{
+			Schema: func(loc oas.Location, schema *openapi.Schema) {
+				c.schemaTo31(loc.Pointer, schema)
+			},
+		})
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+	spec.OpenAPI = version
+	return nil
+}
+
+// schemaTo31 rewrites the OpenAPI 3.0 keywords of a single schema into
+// their JSON Schema 2020-12 equivalents.
+func (c *Converter) schemaTo31(pointer string, schema *openapi.Schema) {
+	// type: string, nullable: true → type: [string, "null"]
+	if schema.Nullable {
+		schema.Nullable = false
+		switch t := schema.Type.(type) {
+		case string:
+			if t != "" {
+				schema.Type = []string{t, "null"}
+			}
+		case []string:
+			if !slices.Contains(t, "null") {
+				schema.Type = append(t, "null")
+			}
+		}
+		if schema.Type == nil {
+			// 3.0 ignores nullable without a type
+			c.warnAt(pointer+"/nullable", SeverityInfo, CodeSchemaKeyword, "nullable without a type has no effect and was removed")
+		} else if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, nil) {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+
+	// Boolean exclusive bounds → numeric exclusiveMinimum/exclusiveMaximum
+	if exclusive, ok := schema.ExclusiveMinimum.(bool); ok {
+		schema.ExclusiveMinimum = nil
+		if exclusive && schema.Minimum != nil {
+			schema.ExclusiveMinimum, schema.Minimum = *schema.Minimum, nil
+		}
+	}
+	if exclusive, ok := schema.ExclusiveMaximum.(bool); ok {
+		schema.ExclusiveMaximum = nil
+		if exclusive && schema.Maximum != nil {
+			schema.ExclusiveMaximum, schema.Maximum = *schema.Maximum, nil
+		}
+	}
+
+	// example → examples
+	if schema.Example != nil {
+		if len(schema.Examples) == 0 {
+			schema.Examples = []interface{}{schema.Example}
+		}
+		schema.Example = nil
+	}
+}
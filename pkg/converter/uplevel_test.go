@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func upLevelSpec() *openapi.OpenAPI {
+	return &openapi.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    openapi.Info{Title: "API", Version: "1.0.0"},
+		Paths: openapi.Paths{
+			"/pets": {
+				Get: &openapi.Operation{
+					Parameters: []openapi.Parameter{
+						{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer", Maximum: &[]float64{100}[0], ExclusiveMaximum: true}},
+					},
+					Responses: openapi.Responses{"200": {Description: "OK"}},
+				},
+			},
+		},
+		Components: &openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"Pet": {
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"name":   {Type: "string", Nullable: true, Example: "Rex"},
+						"kind":   {Type: "string", Nullable: true, Enum: []interface{}{"cat", "dog"}},
+						"age":    {Type: "integer", Minimum: &[]float64{0}[0], ExclusiveMinimum: true},
+						"weight": {Type: "number", Minimum: &[]float64{1}[0], ExclusiveMinimum: false},
+						"owner":  {Ref: "#/components/schemas/Owner", Nullable: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUpLevelTo31(t *testing.T) {
+	t.Parallel()
+
+	spec := upLevelSpec()
+	conv := New()
+	if err := conv.UpLevel(spec, "3.1.0"); err != nil {
+		t.Fatalf("UpLevel() error = %v", err)
+	}
+	if spec.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", spec.OpenAPI)
+	}
+
+	props := spec.Components.Schemas["Pet"].Properties
+	if name := props["name"]; name.Nullable || !reflect.DeepEqual(name.Type, []string{"string", "null"}) {
+		t.Errorf("name = %+v, want type [string, null]", name)
+	}
+	if name := props["name"]; name.Example != nil || !reflect.DeepEqual(name.Examples, []interface{}{"Rex"}) {
+		t.Errorf("name example = %v, examples = %v, want examples [Rex]", name.Example, name.Examples)
+	}
+	if kind := props["kind"]; !reflect.DeepEqual(kind.Enum, []interface{}{"cat", "dog", nil}) {
+		t.Errorf("kind enum = %v, want null allowed", kind.Enum)
+	}
+	if age := props["age"]; age.ExclusiveMinimum != float64(0) || age.Minimum != nil {
+		t.Errorf("age = %+v, want exclusiveMinimum 0", age)
+	}
+	if weight := props["weight"]; weight.ExclusiveMinimum != nil || weight.Minimum == nil || *weight.Minimum != 1 {
+		t.Errorf("weight = %+v, want minimum 1 without exclusiveMinimum", weight)
+	}
+	if owner := props["owner"]; owner.Nullable || owner.Type != nil {
+		t.Errorf("owner = %+v, want nullable dropped", owner)
+	}
+	limit := spec.Paths["/pets"].Get.Parameters[0].Schema
+	if limit.ExclusiveMaximum != float64(100) || limit.Maximum != nil {
+		t.Errorf("limit = %+v, want exclusiveMaximum 100", limit)
+	}
+
+	warnings := strings.Join(conv.GetWarnings(), "\n")
+	if !strings.Contains(warnings, "/components/schemas/Pet/properties/owner/nullable:") {
+		t.Errorf("warnings do not mention the untyped nullable:\n%s", warnings)
+	}
+}
+
+func TestConvertUpgrades(t *testing.T) {
+	t.Parallel()
+
+	result, err := New().Convert(upLevelSpec(), "3.2.0")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	spec := result.(*openapi.OpenAPI)
+	if spec.OpenAPI != "3.2.0" {
+		t.Errorf("openapi = %q, want 3.2.0", spec.OpenAPI)
+	}
+	if name := spec.Components.Schemas["Pet"].Properties["name"]; name.Nullable {
+		t.Errorf("name = %+v, want nullable rewritten", name)
+	}
+
+	// Schemas are left alone between 3.1 and 3.2
+	spec = upLevelSpec()
+	spec.OpenAPI = "3.1.0"
+	if err := New().UpLevel(spec, "3.2.0"); err != nil {
+		t.Fatalf("UpLevel() error = %v", err)
+	}
+	if name := spec.Components.Schemas["Pet"].Properties["name"]; !name.Nullable {
+		t.Errorf("name = %+v, want 3.1 schemas unchanged", name)
+	}
+
+	if err := New().UpLevel(upLevelSpec(), "3.x"); err == nil {
+		t.Error("UpLevel() to 3.x should fail")
+	}
+	spec = upLevelSpec()
+	spec.OpenAPI = "3.2.0"
+	if err := New().UpLevel(spec, "3.0.3"); err == nil {
+		t.Error("UpLevel() to an older version should fail")
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
 	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
 )
 
@@ -51,12 +53,9 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Check if any operation has x-visibility annotation
-	hasVisibility := g.hasVisibilityAnnotations()
-
-	if hasVisibility {
-		// Generate separate specs for public and private
-		return g.generateSeparateSpecs()
+	// Generate one spec per audience when operations or fields are restricted
+	if audiences := oas.V2Audiences(g.spec); len(audiences) > 0 {
+		return g.generateAudienceSpecs(audiences)
 	}
 
 	// Generate normal spec
@@ -83,202 +82,51 @@ func (g *Generator) Generate() error {
 	return nil
 }
 
-// hasVisibilityAnnotations checks if any operation has x-visibility extension.
-func (g *Generator) hasVisibilityAnnotations() bool {
-	for _, pathItem := range g.spec.Paths {
-		operations := []*swagger.Operation{
-			pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete,
-			pathItem.Patch, pathItem.Options, pathItem.Head,
+// generateAudienceSpecs generates one specification per audience, suffixed
+// with the audience name. Operations and properties without an audience
+// appear in every specification.
+func (g *Generator) generateAudienceSpecs(audiences []string) error {
+	for _, audience := range audiences {
+		spec, err := oas.FilterV2(g.spec, audience)
+		if err != nil {
+			return fmt.Errorf("failed to filter %s specification: %w", audience, err)
 		}
-		for _, op := range operations {
-			if op != nil && op.Extensions != nil {
-				if _, ok := op.Extensions["x-visibility"]; ok {
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
-
-// generateSeparateSpecs generates separate public and private specifications.
-func (g *Generator) generateSeparateSpecs() error {
-	publicSpec := g.filterSpecByVisibility("public")
-	privateSpec := g.filterSpecByVisibility("private")
-
-	for _, format := range g.outputType {
-		format = strings.ToLower(strings.TrimSpace(format))
-		switch format {
-		case "json":
-			if err := g.generateJSONWithSuffix(publicSpec, "_public"); err != nil {
-				return err
-			}
-			if err := g.generateJSONWithSuffix(privateSpec, "_private"); err != nil {
-				return err
-			}
-		case "yaml", "yml":
-			if err := g.generateYAMLWithSuffix(publicSpec, "_public"); err != nil {
-				return err
-			}
-			if err := g.generateYAMLWithSuffix(privateSpec, "_private"); err != nil {
-				return err
-			}
-		case "go":
-			if err := g.generateGoWithSuffix(publicSpec, "_public"); err != nil {
-				return err
-			}
-			if err := g.generateGoWithSuffix(privateSpec, "_private"); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("unsupported output format: %s", format)
-		}
-	}
-
-	return nil
-}
-
-// filterSpecByVisibility creates a new spec containing only operations with the specified visibility.
-func (g *Generator) filterSpecByVisibility(visibility string) *swagger.Swagger {
-	filteredSpec := &swagger.Swagger{
-		Swagger:             g.spec.Swagger,
-		Info:                g.spec.Info,
-		Host:                g.spec.Host,
-		BasePath:            g.spec.BasePath,
-		Schemes:             g.spec.Schemes,
-		Consumes:            g.spec.Consumes,
-		Produces:            g.spec.Produces,
-		Paths:               make(swagger.Paths),
-		Definitions:         make(map[string]*swagger.Schema),
-		Parameters:          g.spec.Parameters,
-		Responses:           g.spec.Responses,
-		SecurityDefinitions: g.spec.SecurityDefinitions,
-		Security:            g.spec.Security,
-		Tags:                g.spec.Tags,
-		ExternalDocs:        g.spec.ExternalDocs,
-	}
-
-	usedSchemas := make(map[string]bool)
-
-	// Filter paths based on visibility
-	for path, pathItem := range g.spec.Paths {
-		filteredPathItem := &swagger.PathItem{}
-		hasOperations := false
-
-		for method, op := range map[string]*swagger.Operation{
-			"get":     pathItem.Get,
-			"post":    pathItem.Post,
-			"put":     pathItem.Put,
-			"delete":  pathItem.Delete,
-			"patch":   pathItem.Patch,
-			"options": pathItem.Options,
-			"head":    pathItem.Head,
-		} {
-			if op == nil {
-				continue
-			}
 
-			// Check visibility - if not set or empty, include in both
-			opVisibility := ""
-			if op.Extensions != nil {
-				if vis, ok := op.Extensions["x-visibility"].(string); ok {
-					opVisibility = vis
+		suffix := "_" + audience
+		for _, format := range g.outputType {
+			format = strings.ToLower(strings.TrimSpace(format))
+			switch format {
+			case "json":
+				if err := g.generateJSONWithSuffix(spec, suffix); err != nil {
+					return err
 				}
-			}
-
-			// Include operation if:
-			// 1. No x-visibility set (empty) - include in both specs
-			// 2. x-visibility matches the current visibility filter
-			if opVisibility == "" || opVisibility == visibility {
-				switch method {
-				case "get":
-					filteredPathItem.Get = op
-				case "post":
-					filteredPathItem.Post = op
-				case "put":
-					filteredPathItem.Put = op
-				case "delete":
-					filteredPathItem.Delete = op
-				case "patch":
-					filteredPathItem.Patch = op
-				case "options":
-					filteredPathItem.Options = op
-				case "head":
-					filteredPathItem.Head = op
+			case "yaml", "yml":
+				if err := g.generateYAMLWithSuffix(spec, suffix); err != nil {
+					return err
 				}
-				hasOperations = true
-
-				// Collect schemas used by this operation
-				g.collectSchemasFromOperation(op, usedSchemas)
+			case "go":
+				if err := g.generateGoWithSuffix(spec, suffix); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported output format: %s", format)
 			}
 		}
-
-		if hasOperations {
-			filteredSpec.Paths[path] = filteredPathItem
-		}
-	}
-
-	// Copy only used schemas
-	for schemaName := range usedSchemas {
-		if schema, ok := g.spec.Definitions[schemaName]; ok {
-			filteredSpec.Definitions[schemaName] = schema
-		}
 	}
 
-	return filteredSpec
+	return nil
 }
 
-// collectSchemasFromOperation collects all schemas referenced by an operation.
-func (g *Generator) collectSchemasFromOperation(op *swagger.Operation, usedSchemas map[string]bool) {
-	// Check parameters
-	for _, param := range op.Parameters {
-		if param.Schema != nil {
-			g.collectSchemaRefs(param.Schema, usedSchemas)
-		}
-	}
-
-	// Check responses
-	for _, resp := range op.Responses {
-		if resp.Schema != nil {
-			g.collectSchemaRefs(resp.Schema, usedSchemas)
-		}
-	}
-}
-
-// collectSchemaRefs recursively collects all schema references.
-func (g *Generator) collectSchemaRefs(schema *swagger.Schema, usedSchemas map[string]bool) {
-	if schema == nil {
-		return
-	}
-
-	// Check $ref
-	if schema.Ref != "" {
-		// Extract schema name from #/definitions/SchemaName
-		schemaName := strings.TrimPrefix(schema.Ref, "#/definitions/")
-		if schemaName != "" && !usedSchemas[schemaName] {
-			usedSchemas[schemaName] = true
-			// Recursively collect schemas referenced by this schema
-			if refSchema, ok := g.spec.Definitions[schemaName]; ok {
-				g.collectSchemaRefs(refSchema, usedSchemas)
-			}
-		}
-		return
-	}
-
-	// Check properties
-	for _, prop := range schema.Properties {
-		g.collectSchemaRefs(prop, usedSchemas)
-	}
-
-	// Check items (for arrays)
-	if schema.Items != nil {
-		g.collectSchemaRefs(schema.Items, usedSchemas)
-	}
-
-	// Check allOf
-	for _, s := range schema.AllOf {
-		g.collectSchemaRefs(s, usedSchemas)
+// identifierSuffix turns a file suffix such as "_mobile-app" into the
+// exported identifier suffix "MobileApp".
+func identifierSuffix(suffix string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(suffix, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
 	}
+	return b.String()
 }
 
 // generateJSONWithSuffix generates JSON with a filename suffix.
@@ -300,7 +148,12 @@ func (g *Generator) generateJSONWithSuffix(spec *swagger.Swagger, suffix string)
 // generateYAMLWithSuffix generates YAML with a filename suffix.
 func (g *Generator) generateYAMLWithSuffix(spec *swagger.Swagger, suffix string) error {
 	filePath := filepath.Join(g.outputDir, "swagger"+suffix+".yaml")
-	yamlData, err := yaml.Marshal(spec)
+	doc, err := oas.YAMLNode(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	yamlData, err := yaml.Marshal(doc)
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
@@ -344,12 +197,7 @@ func (g *Generator) generateGoWithSuffix(spec *swagger.Swagger, suffix string) e
 	}
 
 	// Determine variable name based on suffix
-	varName := "SwaggerDoc"
-	if suffix == "_public" {
-		varName = "SwaggerDocPublic"
-	} else if suffix == "_private" {
-		varName = "SwaggerDocPrivate"
-	}
+	varName := "SwaggerDoc" + identifierSuffix(suffix)
 
 	// Write SwaggerDoc
 	if _, err := fmt.Fprintf(file, "// %s is the Swagger 2.0 specification in JSON format\n", varName); err != nil {
@@ -360,12 +208,7 @@ func (g *Generator) generateGoWithSuffix(spec *swagger.Swagger, suffix string) e
 	}
 
 	// Write ReadDoc function
-	funcName := "ReadDoc"
-	if suffix == "_public" {
-		funcName = "ReadDocPublic"
-	} else if suffix == "_private" {
-		funcName = "ReadDocPrivate"
-	}
+	funcName := "ReadDoc" + identifierSuffix(suffix)
 
 	if _, err := fmt.Fprintf(file, "// %s returns the Swagger specification\n", funcName); err != nil {
 		return err
@@ -403,7 +246,12 @@ func (g *Generator) generateJSON() error {
 // generateYAML generates swagger.yaml file.
 func (g *Generator) generateYAML() error {
 	filePath := filepath.Join(g.outputDir, "swagger.yaml")
-	yamlData, err := yaml.Marshal(g.spec)
+	doc, err := oas.YAMLNode(g.spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	yamlData, err := yaml.Marshal(doc)
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
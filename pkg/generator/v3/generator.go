@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
 	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
 )
 
@@ -79,12 +81,9 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Check if we need to generate separate public/private specs
-	hasVisibility := g.hasVisibilityAnnotations()
-
-	if hasVisibility {
-		// Generate separate specs for public and private
-		if err := g.generateSeparateSpecs(); err != nil {
+	// Generate one spec per audience when operations or fields are restricted
+	if audiences := oas.V3Audiences(g.spec); len(audiences) > 0 {
+		if err := g.generateAudienceSpecs(audiences); err != nil {
 			return err
 		}
 	} else {
@@ -154,229 +153,63 @@ func (g *Generator) generateYAML() error {
 	encoder := yaml.NewEncoder(file)
 	encoder.SetIndent(2)
 
-	if err := encoder.Encode(g.spec); err != nil {
+	doc, err := oas.YAMLNode(g.spec)
+	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Generated: %s\n", filePath)
-	return nil
-}
-
-// hasVisibilityAnnotations checks if any operation has x-visibility extension.
-func (g *Generator) hasVisibilityAnnotations() bool {
-	for _, pathItem := range g.spec.Paths {
-		for _, op := range []*openapi.Operation{
-			pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete,
-			pathItem.Patch, pathItem.Options, pathItem.Head, pathItem.Trace,
-		} {
-			if op != nil && op.Extensions != nil {
-				if _, ok := op.Extensions["x-visibility"]; ok {
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
-
-// generateSeparateSpecs generates separate public and private OpenAPI specs.
-func (g *Generator) generateSeparateSpecs() error {
-	// Create public spec
-	publicSpec := g.filterSpecByVisibility("public")
-	// Create private spec
-	privateSpec := g.filterSpecByVisibility("private")
-
-	// Generate files for each visibility level
-	for _, outputType := range g.outputType {
-		switch outputType {
-		case "json":
-			if err := g.generateJSONWithSuffix(publicSpec, "_public"); err != nil {
-				return fmt.Errorf("failed to generate public JSON: %w", err)
-			}
-			if err := g.generateJSONWithSuffix(privateSpec, "_private"); err != nil {
-				return fmt.Errorf("failed to generate private JSON: %w", err)
-			}
-		case "yaml", "yml":
-			if err := g.generateYAMLWithSuffix(publicSpec, "_public"); err != nil {
-				return fmt.Errorf("failed to generate public YAML: %w", err)
-			}
-			if err := g.generateYAMLWithSuffix(privateSpec, "_private"); err != nil {
-				return fmt.Errorf("failed to generate private YAML: %w", err)
-			}
-		case "go":
-			if err := g.generateGoWithSuffix(publicSpec, "_public"); err != nil {
-				return fmt.Errorf("failed to generate public Go file: %w", err)
-			}
-			if err := g.generateGoWithSuffix(privateSpec, "_private"); err != nil {
-				return fmt.Errorf("failed to generate private Go file: %w", err)
-			}
-		default:
-			return fmt.Errorf("unsupported output type: %s", outputType)
-		}
+	if err := encoder.Encode(doc); err != nil {
+		return err
 	}
 
+	fmt.Printf("Generated: %s\n", filePath)
 	return nil
 }
 
-// filterSpecByVisibility creates a new spec containing only operations with the specified visibility.
-func (g *Generator) filterSpecByVisibility(visibility string) *openapi.OpenAPI {
-	filteredSpec := &openapi.OpenAPI{
-		OpenAPI:           g.spec.OpenAPI,
-		JSONSchemaDialect: g.spec.JSONSchemaDialect,
-		Info:              g.spec.Info,
-		Servers:           g.spec.Servers,
-		Paths:             make(map[string]*openapi.PathItem),
-		Components:        &openapi.Components{Schemas: make(map[string]*openapi.Schema)},
-		Security:          g.spec.Security,
-		Tags:              g.spec.Tags,
-		ExternalDocs:      g.spec.ExternalDocs,
-	}
-
-	usedSchemas := make(map[string]bool)
-
-	// Filter paths based on visibility
-	for path, pathItem := range g.spec.Paths {
-		filteredPathItem := &openapi.PathItem{}
-		hasOperations := false
-
-		for method, op := range map[string]*openapi.Operation{
-			"get":     pathItem.Get,
-			"post":    pathItem.Post,
-			"put":     pathItem.Put,
-			"delete":  pathItem.Delete,
-			"patch":   pathItem.Patch,
-			"options": pathItem.Options,
-			"head":    pathItem.Head,
-			"trace":   pathItem.Trace,
-		} {
-			if op == nil {
-				continue
-			}
+// generateAudienceSpecs generates one OpenAPI spec per audience, suffixed
+// with the audience name. Operations and properties without an audience
+// appear in every spec.
+func (g *Generator) generateAudienceSpecs(audiences []string) error {
+	for _, audience := range audiences {
+		spec, err := oas.FilterV3(g.spec, audience)
+		if err != nil {
+			return fmt.Errorf("failed to filter %s spec: %w", audience, err)
+		}
 
-			// Check visibility - if not set or empty, include in both
-			opVisibility := ""
-			if op.Extensions != nil {
-				if vis, ok := op.Extensions["x-visibility"].(string); ok {
-					opVisibility = vis
+		suffix := "_" + audience
+		for _, outputType := range g.outputType {
+			switch outputType {
+			case "json":
+				if err := g.generateJSONWithSuffix(spec, suffix); err != nil {
+					return fmt.Errorf("failed to generate %s JSON: %w", audience, err)
 				}
-			}
-
-			// Include operation if:
-			// 1. No x-visibility set (empty) - include in both specs
-			// 2. x-visibility matches the current visibility filter
-			if opVisibility == "" || opVisibility == visibility {
-				hasOperations = true
-				switch method {
-				case "get":
-					filteredPathItem.Get = op
-				case "post":
-					filteredPathItem.Post = op
-				case "put":
-					filteredPathItem.Put = op
-				case "delete":
-					filteredPathItem.Delete = op
-				case "patch":
-					filteredPathItem.Patch = op
-				case "options":
-					filteredPathItem.Options = op
-				case "head":
-					filteredPathItem.Head = op
-				case "trace":
-					filteredPathItem.Trace = op
+			case "yaml", "yml":
+				if err := g.generateYAMLWithSuffix(spec, suffix); err != nil {
+					return fmt.Errorf("failed to generate %s YAML: %w", audience, err)
 				}
-
-				// Collect schemas used in this operation
-				g.collectSchemasFromOperation(op, usedSchemas)
-			}
-		}
-
-		if hasOperations {
-			filteredSpec.Paths[path] = filteredPathItem
-		}
-	}
-
-	// Copy only used schemas
-	for schemaName := range usedSchemas {
-		if schema, ok := g.spec.Components.Schemas[schemaName]; ok {
-			filteredSpec.Components.Schemas[schemaName] = schema
-		}
-	}
-
-	return filteredSpec
-}
-
-// collectSchemasFromOperation collects all schema names referenced in an operation.
-func (g *Generator) collectSchemasFromOperation(op *openapi.Operation, usedSchemas map[string]bool) {
-	// Collect from request body
-	if op.RequestBody != nil && op.RequestBody.Content != nil {
-		for _, mediaType := range op.RequestBody.Content {
-			if mediaType.Schema != nil {
-				g.collectSchemaRefs(mediaType.Schema, usedSchemas)
-			}
-		}
-	}
-
-	// Collect from responses
-	for _, response := range op.Responses {
-		if response.Content != nil {
-			for _, mediaType := range response.Content {
-				if mediaType.Schema != nil {
-					g.collectSchemaRefs(mediaType.Schema, usedSchemas)
+			case "go":
+				if err := g.generateGoWithSuffix(spec, suffix); err != nil {
+					return fmt.Errorf("failed to generate %s Go file: %w", audience, err)
 				}
+			default:
+				return fmt.Errorf("unsupported output type: %s", outputType)
 			}
 		}
 	}
 
-	// Collect from parameters
-	for _, param := range op.Parameters {
-		if param.Schema != nil {
-			g.collectSchemaRefs(param.Schema, usedSchemas)
-		}
-	}
+	return nil
 }
 
-// collectSchemaRefs recursively collects schema references.
-func (g *Generator) collectSchemaRefs(schema *openapi.Schema, usedSchemas map[string]bool) {
-	if schema == nil {
-		return
-	}
-
-	// Check for $ref
-	if schema.Ref != "" {
-		// Extract schema name from #/components/schemas/SchemaName
-		parts := strings.Split(schema.Ref, "/")
-		if len(parts) > 0 {
-			schemaName := parts[len(parts)-1]
-			if !usedSchemas[schemaName] {
-				usedSchemas[schemaName] = true
-				// Recursively collect schemas from referenced schema
-				if refSchema, ok := g.spec.Components.Schemas[schemaName]; ok {
-					g.collectSchemaRefs(refSchema, usedSchemas)
-				}
-			}
-		}
-	}
-
-	// Check properties
-	for _, propSchema := range schema.Properties {
-		g.collectSchemaRefs(propSchema, usedSchemas)
-	}
-
-	// Check items (for arrays)
-	if schema.Items != nil {
-		g.collectSchemaRefs(schema.Items, usedSchemas)
-	}
-
-	// Check allOf, anyOf, oneOf
-	for _, s := range schema.AllOf {
-		g.collectSchemaRefs(&s, usedSchemas)
-	}
-	for _, s := range schema.AnyOf {
-		g.collectSchemaRefs(&s, usedSchemas)
-	}
-	for _, s := range schema.OneOf {
-		g.collectSchemaRefs(&s, usedSchemas)
+// identifierSuffix turns a file suffix such as "_mobile-app" into the
+// exported identifier suffix "MobileApp".
+func identifierSuffix(suffix string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(suffix, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
 	}
+	return b.String()
 }
 
 // generateJSONWithSuffix generates JSON with a filename suffix.
@@ -413,7 +246,12 @@ func (g *Generator) generateYAMLWithSuffix(spec *openapi.OpenAPI, suffix string)
 	encoder := yaml.NewEncoder(file)
 	encoder.SetIndent(2)
 
-	if err := encoder.Encode(spec); err != nil {
+	doc, err := oas.YAMLNode(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := encoder.Encode(doc); err != nil {
 		return err
 	}
 
@@ -438,12 +276,7 @@ func (g *Generator) generateGoWithSuffix(spec *openapi.OpenAPI, suffix string) e
 	defer file.Close()
 
 	// Write package declaration
-	varName := "SwaggerDoc"
-	if suffix == "_public" {
-		varName = "SwaggerDocPublic"
-	} else if suffix == "_private" {
-		varName = "SwaggerDocPrivate"
-	}
+	varName := "SwaggerDoc" + identifierSuffix(suffix)
 
 	if _, err := fmt.Fprintf(file, "// Package %s Code generated by nexs-swag. DO NOT EDIT\n", g.instanceName); err != nil {
 		return err
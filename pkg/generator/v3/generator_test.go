@@ -843,3 +843,84 @@ func TestGenerateGoReadDocFunction(t *testing.T) {
 		t.Error("ReadDoc function should return SwaggerDoc")
 	}
 }
+
+func TestGenerateAudienceSpecs(t *testing.T) {
+	t.Parallel()
+
+	body := func(ref string) *v3.RequestBody {
+		return &v3.RequestBody{Content: map[string]*v3.MediaType{"application/json": {Schema: &v3.Schema{Ref: ref}}}}
+	}
+	spec := &v3.OpenAPI{
+		OpenAPI: "3.2.0",
+		Info:    v3.Info{Title: "Audiences", Version: "1.0.0"},
+		Paths: v3.Paths{
+			"/search": {
+				Query:  &v3.Operation{RequestBody: body("#/components/schemas/Filter")},
+				Delete: &v3.Operation{Extensions: map[string]interface{}{"x-audience": []string{"internal"}}},
+			},
+		},
+		Webhooks: map[string]*v3.PathItem{
+			"audit": {Post: &v3.Operation{
+				Extensions:  map[string]interface{}{"x-audience": []string{"partner-api", "internal"}},
+				RequestBody: body("#/components/schemas/Event"),
+			}},
+		},
+		Components: &v3.Components{Schemas: map[string]*v3.Schema{
+			"Filter": {Properties: map[string]*v3.Schema{
+				"q":     {Type: "string"},
+				"trace": {Type: "boolean", Extensions: map[string]interface{}{"x-audience": []string{"internal"}}},
+			}},
+			"Event": {Type: "object"},
+		}},
+	}
+
+	tmpDir := t.TempDir()
+	gen := New(spec, tmpDir, []string{"json", "go"})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "openapi.json")); !os.IsNotExist(err) {
+		t.Errorf("openapi.json written alongside audience specs: %v", err)
+	}
+
+	read := func(name string) *v3.OpenAPI {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", name, err)
+		}
+		doc := &v3.OpenAPI{}
+		if err := json.Unmarshal(data, doc); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", name, err)
+		}
+		return doc
+	}
+
+	internal := read("openapi_internal.json")
+	if internal.Paths["/search"].Delete == nil || internal.Webhooks["audit"] == nil {
+		t.Errorf("internal spec is missing restricted operations")
+	}
+	if internal.Components.Schemas["Filter"].Properties["trace"] == nil {
+		t.Errorf("internal spec is missing the internal property")
+	}
+
+	partner := read("openapi_partner-api.json")
+	if partner.Paths["/search"].Query == nil || partner.Paths["/search"].Delete != nil {
+		t.Errorf("partner /search = %+v, want only the query operation", partner.Paths["/search"])
+	}
+	if _, ok := partner.Components.Schemas["Filter"].Properties["trace"]; ok {
+		t.Errorf("partner spec kept the internal property")
+	}
+	if partner.Webhooks["audit"] == nil || partner.Components.Schemas["Event"] == nil {
+		t.Errorf("partner spec lost the audit webhook or its schema")
+	}
+
+	goFile, err := os.ReadFile(filepath.Join(tmpDir, "docs_partner-api.go"))
+	if err != nil {
+		t.Fatalf("ReadFile(docs_partner-api.go) error = %v", err)
+	}
+	if !strings.Contains(string(goFile), "var SwaggerDocPartnerApi = ") {
+		t.Errorf("docs_partner-api.go does not declare SwaggerDocPartnerApi")
+	}
+}
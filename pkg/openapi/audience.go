@@ -0,0 +1,418 @@
+package openapi
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+
+	v2 "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	v3 "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Extensions that restrict operations and schema properties to audiences.
+const (
+	AudienceExtension   = "x-audience"   // List of audiences, e.g. ["partner", "internal"]
+	VisibilityExtension = "x-visibility" // Single audience, public or private
+)
+
+// Audiences returns the audiences an object is restricted to by its
+// x-audience (a list or comma-separated string) and x-visibility
+// extensions. An empty result means every audience sees the object.
+func Audiences(extensions map[string]interface{}) []string {
+	var audiences []string
+	switch v := extensions[AudienceExtension].(type) {
+	case []string:
+		audiences = append(audiences, v...)
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				audiences = append(audiences, name)
+			}
+		}
+	case string:
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				audiences = append(audiences, name)
+			}
+		}
+	}
+	if visibility, ok := extensions[VisibilityExtension].(string); ok && visibility != "" {
+		audiences = append(audiences, visibility)
+	}
+	return audiences
+}
+
+// visibleTo reports whether an object belongs in the spec of audience.
+func visibleTo(extensions map[string]interface{}, audience string) bool {
+	audiences := Audiences(extensions)
+	return len(audiences) == 0 || slices.Contains(audiences, audience)
+}
+
+// audienceSet collects audience names. Public and private come as a pair,
+// so restricting anything to one of them yields both specs.
+type audienceSet map[string]bool
+
+func (s audienceSet) add(extensions map[string]interface{}) {
+	for _, audience := range Audiences(extensions) {
+		s[audience] = true
+	}
+}
+
+func (s audienceSet) sorted() []string {
+	if s["public"] || s["private"] {
+		s["public"], s["private"] = true, true
+	}
+	audiences := make([]string, 0, len(s))
+	for audience := range s {
+		audiences = append(audiences, audience)
+	}
+	sort.Strings(audiences)
+	return audiences
+}
+
+// V3Audiences returns the audiences named by the operations and schemas
+// of spec, sorted. It is empty when nothing is restricted.
+func V3Audiences(spec *v3.OpenAPI) []string {
+	set := make(audienceSet)
+	WalkV3(spec, V3Visitor{
+		Operation: func(_ Location, op *v3.Operation) { set.add(op.Extensions) },
+		Schema:    func(_ Location, schema *v3.Schema) { set.add(schema.Extensions) },
+	})
+	return set.sorted()
+}
+
+// V2Audiences returns the audiences named by the operations and schemas
+// of spec, sorted. It is empty when nothing is restricted.
+func V2Audiences(spec *v2.Swagger) []string {
+	set := make(audienceSet)
+	WalkV2(spec, V2Visitor{
+		Operation: func(_ Location, op *v2.Operation) { set.add(op.Extensions) },
+		Schema:    func(_ Location, schema *v2.Schema) { set.add(schema.Extensions) },
+	})
+	return set.sorted()
+}
+
+// FilterV3 returns a copy of spec for one audience. Operations and schema
+// properties restricted to other audiences are removed, path items left
+// without operations are dropped, and the result is pruned with PruneV3.
+// The x-audience and x-visibility extensions are removed from the copy so
+// it does not name the other audiences.
+func FilterV3(spec *v3.OpenAPI, audience string) (*v3.OpenAPI, error) {
+	filtered := &v3.OpenAPI{}
+	if err := clone(spec, filtered); err != nil {
+		return nil, err
+	}
+
+	emptied := make(map[*v3.PathItem]bool)
+	WalkV3(filtered, V3Visitor{
+		PathItem: func(_ Location, item *v3.PathItem) {
+			ops := item.Operations()
+			removed := 0
+			for method, op := range ops {
+				if !visibleTo(op.Extensions, audience) {
+					item.SetOperation(method, nil)
+					removed++
+				}
+			}
+			if removed > 0 && removed == len(ops) {
+				emptied[item] = true
+			}
+		},
+		Operation: func(_ Location, op *v3.Operation) { stripAudiences(&op.Extensions) },
+		Schema: func(_ Location, schema *v3.Schema) {
+			schema.Required = removeProperties(schema.Properties, schema.Required, func(prop *v3.Schema) bool {
+				return visibleTo(prop.Extensions, audience)
+			})
+			stripAudiences(&schema.Extensions)
+		},
+	})
+
+	deleteEmptied(filtered.Paths, emptied)
+	deleteEmptied(filtered.Webhooks, emptied)
+	if filtered.Components != nil {
+		deleteEmptied(filtered.Components.PathItems, emptied)
+	}
+
+	PruneV3(filtered)
+	return filtered, nil
+}
+
+// FilterV2 returns a copy of spec for one audience. See FilterV3.
+func FilterV2(spec *v2.Swagger, audience string) (*v2.Swagger, error) {
+	filtered := &v2.Swagger{}
+	if err := clone(spec, filtered); err != nil {
+		return nil, err
+	}
+
+	emptied := make(map[*v2.PathItem]bool)
+	WalkV2(filtered, V2Visitor{
+		PathItem: func(_ Location, item *v2.PathItem) {
+			ops := item.Operations()
+			removed := 0
+			for method, op := range ops {
+				if !visibleTo(op.Extensions, audience) {
+					item.SetOperation(method, nil)
+					removed++
+				}
+			}
+			if removed > 0 && removed == len(ops) {
+				emptied[item] = true
+			}
+		},
+		Operation: func(_ Location, op *v2.Operation) { stripAudiences(&op.Extensions) },
+		Schema: func(_ Location, schema *v2.Schema) {
+			schema.Required = removeProperties(schema.Properties, schema.Required, func(prop *v2.Schema) bool {
+				return visibleTo(prop.Extensions, audience)
+			})
+			stripAudiences(&schema.Extensions)
+		},
+	})
+
+	deleteEmptied(filtered.Paths, emptied)
+
+	PruneV2(filtered)
+	return filtered, nil
+}
+
+// stripAudiences removes the audience extensions once the object they
+// restrict has been kept. The walk visits a path item before its operations
+// and a schema before its properties, so they are read before being removed.
+func stripAudiences(extensions *map[string]interface{}) {
+	delete(*extensions, AudienceExtension)
+	delete(*extensions, VisibilityExtension)
+	if len(*extensions) == 0 {
+		*extensions = nil
+	}
+}
+
+// clone deep-copies a document through its JSON encoding.
+func clone(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// removeProperties deletes the properties keep rejects and returns required
+// without their names.
+func removeProperties[S any](properties map[string]*S, required []string, keep func(*S) bool) []string {
+	for name, prop := range properties {
+		if prop == nil || keep(prop) {
+			continue
+		}
+		delete(properties, name)
+		required = slices.DeleteFunc(required, func(r string) bool { return r == name })
+	}
+	if len(required) == 0 {
+		return nil
+	}
+	return required
+}
+
+// deleteEmptied removes the path items whose operations were all filtered out.
+func deleteEmptied[M ~map[string]*P, P any](items M, emptied map[*P]bool) {
+	for name, item := range items {
+		if emptied[item] {
+			delete(items, name)
+		}
+	}
+}
+
+// pruner records the components reachable from a document's operations.
+type pruner struct {
+	prefix  string          // Prefix of local component references
+	used    map[string]bool // "section/name" of reachable components
+	queue   []string        // Reachable components not walked yet
+	schemes map[string]bool // Security schemes named by security requirements
+	tags    map[string]bool // Tags named by operations
+}
+
+func newPruner(prefix string) *pruner {
+	return &pruner{
+		prefix:  prefix,
+		used:    make(map[string]bool),
+		schemes: make(map[string]bool),
+		tags:    make(map[string]bool),
+	}
+}
+
+// ref marks the component a local reference points to.
+func (p *pruner) ref(ref string) {
+	rest, ok := strings.CutPrefix(ref, p.prefix)
+	if !ok {
+		return
+	}
+	section, name, ok := strings.Cut(rest, "/")
+	if !ok {
+		return
+	}
+	key := section + "/" + strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+	if !p.used[key] {
+		p.used[key] = true
+		p.queue = append(p.queue, key)
+	}
+}
+
+// next returns the next reachable component to walk.
+func (p *pruner) next() (string, string, bool) {
+	if len(p.queue) == 0 {
+		return "", "", false
+	}
+	key := p.queue[0]
+	p.queue = p.queue[1:]
+	section, name, _ := strings.Cut(key, "/")
+	return section, name, true
+}
+
+// keep deletes the entries of a component section that are not reachable.
+func keep[V any](p *pruner, section string, components map[string]V) {
+	for name := range components {
+		if !p.used[section+"/"+name] {
+			delete(components, name)
+		}
+	}
+}
+
+// security marks the schemes named by security requirements.
+func security[R ~map[string][]string](p *pruner, requirements []R) {
+	for _, requirement := range requirements {
+		for name := range requirement {
+			p.schemes[name] = true
+		}
+	}
+}
+
+// PruneV3 removes the components of spec that its paths, webhooks and
+// security requirements do not reach, following references between
+// components, and the tags no operation uses. Examples, links and
+// callbacks are kept: the model does not record references to them.
+func PruneV3(spec *v3.OpenAPI) {
+	p := newPruner("#/components/")
+	security(p, spec.Security)
+	w := v3Walker{V3Visitor{
+		PathItem:    func(_ Location, item *v3.PathItem) { p.ref(item.Ref) },
+		Parameter:   func(_ Location, param *v3.Parameter) { p.ref(param.Ref) },
+		RequestBody: func(_ Location, body *v3.RequestBody) { p.ref(body.Ref) },
+		Response:    func(_ Location, resp *v3.Response) { p.ref(resp.Ref) },
+		Header:      func(_ Location, header *v3.Header) { p.ref(header.Ref) },
+		Operation: func(_ Location, op *v3.Operation) {
+			for _, tag := range op.Tags {
+				p.tags[tag] = true
+			}
+			security(p, op.Security)
+		},
+		Schema: func(_ Location, schema *v3.Schema) {
+			p.ref(schema.Ref)
+			if schema.Discriminator != nil {
+				for _, ref := range schema.Discriminator.Mapping {
+					p.ref(ref)
+				}
+			}
+		},
+	}}
+
+	components := spec.Components
+	spec.Components = nil
+	WalkV3(spec, w.visitor)
+	spec.Components = components
+
+	if components != nil {
+		root := Location{}
+		for _, name := range sortedKeys(components.Callbacks) {
+			w.callback(root.child("components", "callbacks", name), components.Callbacks[name])
+		}
+		for section, name, ok := p.next(); ok; section, name, ok = p.next() {
+			loc := root.child("components", section, name)
+			switch section {
+			case "schemas":
+				w.schema(loc, components.Schemas[name])
+			case "responses":
+				w.response(loc, components.Responses[name])
+			case "parameters":
+				w.parameter(loc, components.Parameters[name])
+			case "requestBodies":
+				w.requestBody(loc, components.RequestBodies[name])
+			case "headers":
+				w.header(loc, components.Headers[name])
+			case "pathItems":
+				w.pathItem(loc, components.PathItems[name])
+			}
+		}
+
+		keep(p, "schemas", components.Schemas)
+		keep(p, "responses", components.Responses)
+		keep(p, "parameters", components.Parameters)
+		keep(p, "requestBodies", components.RequestBodies)
+		keep(p, "headers", components.Headers)
+		keep(p, "pathItems", components.PathItems)
+		for name := range components.SecuritySchemes {
+			if !p.schemes[name] {
+				delete(components.SecuritySchemes, name)
+			}
+		}
+		if len(components.Schemas)+len(components.Responses)+len(components.Parameters)+
+			len(components.Examples)+len(components.RequestBodies)+len(components.Headers)+
+			len(components.SecuritySchemes)+len(components.Links)+len(components.Callbacks)+
+			len(components.PathItems) == 0 {
+			spec.Components = nil
+		}
+	}
+
+	spec.Tags = slices.DeleteFunc(spec.Tags, func(tag v3.Tag) bool { return !p.tags[tag.Name] })
+	if len(spec.Tags) == 0 {
+		spec.Tags = nil
+	}
+}
+
+// PruneV2 removes the definitions, parameters, responses, security
+// definitions and tags of spec that its paths do not reach. See PruneV3.
+func PruneV2(spec *v2.Swagger) {
+	p := newPruner("#/")
+	security(p, spec.Security)
+	w := v2Walker{V2Visitor{
+		PathItem:  func(_ Location, item *v2.PathItem) { p.ref(item.Ref) },
+		Parameter: func(_ Location, param *v2.Parameter) { p.ref(param.Ref) },
+		Response:  func(_ Location, resp *v2.Response) { p.ref(resp.Ref) },
+		Schema:    func(_ Location, schema *v2.Schema) { p.ref(schema.Ref) },
+		Operation: func(_ Location, op *v2.Operation) {
+			for _, tag := range op.Tags {
+				p.tags[tag] = true
+			}
+			security(p, op.Security)
+		},
+	}}
+
+	definitions, parameters, responses := spec.Definitions, spec.Parameters, spec.Responses
+	spec.Definitions, spec.Parameters, spec.Responses = nil, nil, nil
+	WalkV2(spec, w.visitor)
+	spec.Definitions, spec.Parameters, spec.Responses = definitions, parameters, responses
+
+	root := Location{}
+	for section, name, ok := p.next(); ok; section, name, ok = p.next() {
+		loc := root.child(section, name)
+		switch section {
+		case "definitions":
+			w.schema(loc, definitions[name])
+		case "parameters":
+			w.parameter(loc, parameters[name])
+		case "responses":
+			w.response(loc, responses[name])
+		}
+	}
+
+	keep(p, "definitions", spec.Definitions)
+	keep(p, "parameters", spec.Parameters)
+	keep(p, "responses", spec.Responses)
+	for name := range spec.SecurityDefinitions {
+		if !p.schemes[name] {
+			delete(spec.SecurityDefinitions, name)
+		}
+	}
+
+	spec.Tags = slices.DeleteFunc(spec.Tags, func(tag v2.Tag) bool { return !p.tags[tag.Name] })
+	if len(spec.Tags) == 0 {
+		spec.Tags = nil
+	}
+}
@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestAudiences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		extensions map[string]interface{}
+		want       []string
+	}{
+		{name: "none", extensions: nil, want: nil},
+		{name: "list", extensions: map[string]interface{}{"x-audience": []string{"partner", "internal"}}, want: []string{"partner", "internal"}},
+		{name: "decoded list", extensions: map[string]interface{}{"x-audience": []interface{}{"mobile", 1}}, want: []string{"mobile"}},
+		{name: "string", extensions: map[string]interface{}{"x-audience": "partner, mobile,"}, want: []string{"partner", "mobile"}},
+		{name: "visibility", extensions: map[string]interface{}{"x-visibility": "private"}, want: []string{"private"}},
+	}
+
+	for _, tt := range tests {
+		if got := Audiences(tt.extensions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Audiences() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestV3Audiences(t *testing.T) {
+	t.Parallel()
+
+	spec := &openapi.OpenAPI{
+		Paths: openapi.Paths{
+			"/a": {Get: &openapi.Operation{Extensions: map[string]interface{}{"x-visibility": "public"}}},
+		},
+		Components: &openapi.Components{Schemas: map[string]*openapi.Schema{
+			"User": {Properties: map[string]*openapi.Schema{"ssn": {Extensions: map[string]interface{}{"x-audience": []string{"internal"}}}}},
+		}},
+	}
+	if got, want := V3Audiences(spec), []string{"internal", "private", "public"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("V3Audiences() = %v, want %v", got, want)
+	}
+	if got := V3Audiences(&openapi.OpenAPI{}); len(got) != 0 {
+		t.Errorf("V3Audiences() of an unrestricted spec = %v", got)
+	}
+}
+
+func TestFilterV3(t *testing.T) {
+	t.Parallel()
+
+	internal := map[string]interface{}{"x-audience": []string{"internal"}}
+	spec := &openapi.OpenAPI{
+		OpenAPI:  "3.1.0",
+		Security: []openapi.SecurityRequirement{{"apiKey": {}}},
+		Tags:     []openapi.Tag{{Name: "users"}, {Name: "admin"}, {Name: "unused"}},
+		Paths: openapi.Paths{
+			"/users": {
+				Get: &openapi.Operation{
+					Tags:       []string{"users"},
+					Parameters: []openapi.Parameter{{Ref: "#/components/parameters/Page"}},
+					Responses: openapi.Responses{
+						"200":     {Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/User"}}}},
+						"default": {Ref: "#/components/responses/Error"},
+					},
+				},
+			},
+			"/admin": {
+				Post: &openapi.Operation{
+					Tags:       []string{"admin"},
+					Security:   []openapi.SecurityRequirement{{"oauth": {"admin"}}},
+					Extensions: internal,
+					Responses:  openapi.Responses{"200": {Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/Audit"}}}}},
+				},
+			},
+		},
+		Components: &openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"User": {
+					Required: []string{"name", "ssn"},
+					Properties: map[string]*openapi.Schema{
+						"name":  {Type: "string"},
+						"ssn":   {Type: "string", Extensions: internal},
+						"notes": {Ref: "#/components/schemas/Notes", Extensions: internal},
+						"pet":   {Ref: "#/components/schemas/Pet"},
+					},
+				},
+				"Pet": {
+					OneOf:         []openapi.Schema{{Ref: "#/components/schemas/Cat"}},
+					Discriminator: &openapi.Discriminator{PropertyName: "kind", Mapping: map[string]string{"dog": "#/components/schemas/Dog"}},
+				},
+				"Cat":     {Type: "object"},
+				"Dog":     {Type: "object"},
+				"Notes":   {Type: "string"},
+				"Audit":   {Type: "object"},
+				"Problem": {Type: "object"},
+				"Orphan":  {Type: "object"},
+			},
+			Parameters: map[string]*openapi.Parameter{
+				"Page":   {Name: "page", In: "query"},
+				"Unused": {Name: "unused", In: "query"},
+			},
+			Responses: map[string]*openapi.Response{
+				"Error": {Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/Problem"}}}},
+			},
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"apiKey": {Type: "apiKey"},
+				"oauth":  {Type: "oauth2"},
+			},
+		},
+	}
+
+	public, err := FilterV3(spec, "public")
+	if err != nil {
+		t.Fatalf("FilterV3() error = %v", err)
+	}
+
+	if _, ok := public.Paths["/admin"]; ok {
+		t.Error("/admin kept in the public spec")
+	}
+	user := public.Components.Schemas["User"]
+	if _, ok := user.Properties["ssn"]; ok || !reflect.DeepEqual(user.Required, []string{"name"}) {
+		t.Errorf("User = properties %v, required %v; want ssn removed", sortedKeys(user.Properties), user.Required)
+	}
+
+	checks := map[string]struct{ got, want []string }{
+		"schemas":         {sortedKeys(public.Components.Schemas), []string{"Cat", "Dog", "Pet", "Problem", "User"}},
+		"parameters":      {sortedKeys(public.Components.Parameters), []string{"Page"}},
+		"responses":       {sortedKeys(public.Components.Responses), []string{"Error"}},
+		"securitySchemes": {sortedKeys(public.Components.SecuritySchemes), []string{"apiKey"}},
+	}
+	for name, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s = %v, want %v", name, check.got, check.want)
+		}
+	}
+	if len(public.Tags) != 1 || public.Tags[0].Name != "users" {
+		t.Errorf("tags = %v, want only users", public.Tags)
+	}
+
+	internalSpec, err := FilterV3(spec, "internal")
+	if err != nil {
+		t.Fatalf("FilterV3() error = %v", err)
+	}
+	if internalSpec.Components.Schemas["Notes"] == nil || internalSpec.Components.SecuritySchemes["oauth"] == nil || len(internalSpec.Tags) != 2 {
+		t.Errorf("internal spec lost internal components: schemas=%v schemes=%v tags=%v",
+			sortedKeys(internalSpec.Components.Schemas), sortedKeys(internalSpec.Components.SecuritySchemes), internalSpec.Tags)
+	}
+
+	if data, _ := json.Marshal(internalSpec); strings.Contains(string(data), AudienceExtension) {
+		t.Errorf("internal spec still names audiences: %s", data)
+	}
+
+	if len(spec.Components.Schemas) != 8 || spec.Components.Schemas["User"].Properties["ssn"] == nil || spec.Paths["/admin"] == nil {
+		t.Error("FilterV3 modified the original spec")
+	}
+	if spec.Paths["/admin"].Post.Extensions[AudienceExtension] == nil {
+		t.Error("FilterV3 removed the audience extensions of the original spec")
+	}
+}
+
+func TestFilterV2(t *testing.T) {
+	t.Parallel()
+
+	private := map[string]interface{}{"x-visibility": "private"}
+	spec := &swagger.Swagger{
+		Swagger: "2.0",
+		Paths: swagger.Paths{
+			"/users": {
+				Get: &swagger.Operation{
+					Parameters: []*swagger.Parameter{{Ref: "#/parameters/Page"}},
+					Responses:  swagger.Responses{"200": {Schema: &swagger.Schema{Ref: "#/definitions/User"}}},
+				},
+				Delete: &swagger.Operation{
+					Extensions: private,
+					Security:   []swagger.SecurityRequirement{{"basic": {}}},
+					Responses:  swagger.Responses{"204": {Ref: "#/responses/Deleted"}},
+				},
+			},
+		},
+		Definitions: map[string]*swagger.Schema{
+			"User": {AllOf: []*swagger.Schema{{Ref: "#/definitions/Base"}}, Properties: map[string]*swagger.Schema{
+				"password": {Type: "string", Extensions: private},
+			}},
+			"Base":   {Type: "object"},
+			"Orphan": {Type: "object"},
+		},
+		Parameters:          map[string]*swagger.Parameter{"Page": {Name: "page", In: "query", Type: "integer"}},
+		Responses:           map[string]*swagger.Response{"Deleted": {Description: "Deleted"}},
+		SecurityDefinitions: map[string]*swagger.SecurityScheme{"basic": {Type: "basic"}},
+	}
+
+	public, err := FilterV2(spec, "public")
+	if err != nil {
+		t.Fatalf("FilterV2() error = %v", err)
+	}
+	if public.Paths["/users"].Delete != nil || public.Paths["/users"].Get == nil {
+		t.Errorf("/users = %+v, want only GET", public.Paths["/users"])
+	}
+	if got := sortedKeys(public.Definitions); !reflect.DeepEqual(got, []string{"Base", "User"}) {
+		t.Errorf("definitions = %v, want Base and User", got)
+	}
+	if _, ok := public.Definitions["User"].Properties["password"]; ok {
+		t.Error("private property kept in the public spec")
+	}
+	if len(public.Responses) != 0 || len(public.SecurityDefinitions) != 0 || public.Parameters["Page"] == nil {
+		t.Errorf("responses=%v securityDefinitions=%v parameters=%v", public.Responses, public.SecurityDefinitions, public.Parameters)
+	}
+
+	privateSpec, err := FilterV2(spec, "private")
+	if err != nil {
+		t.Fatalf("FilterV2() error = %v", err)
+	}
+	if privateSpec.Responses["Deleted"] == nil || privateSpec.SecurityDefinitions["basic"] == nil {
+		t.Errorf("private spec lost the DELETE components")
+	}
+	if extensions := privateSpec.Paths["/users"].Delete.Extensions; extensions != nil {
+		t.Errorf("DELETE extensions = %v, want x-visibility removed", extensions)
+	}
+}
@@ -0,0 +1,126 @@
+// Package jsonext holds the JSON helpers shared by the specification models:
+// YAML to JSON conversion and encoding of objects with extension fields.
+package jsonext
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToJSON returns JSON documents unchanged and re-encodes YAML as JSON.
+func ToJSON(data []byte) ([]byte, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return data, nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return json.Marshal(StringKeys(doc))
+}
+
+// StringKeys converts the map[interface{}]interface{} values that YAML
+// produces for non-string keys (e.g. status codes) into JSON objects.
+func StringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = StringKeys(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = StringKeys(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = StringKeys(item)
+		}
+	}
+	return value
+}
+
+// Marshal encodes v and adds extensions as top-level fields.
+func Marshal(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(extensions) == 0 {
+		return data, nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range extensions {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+// MarshalRef encodes a Reference Object. Readers ignore the fields next to
+// $ref, and required ones would be written empty, so none are kept.
+func MarshalRef(ref string) ([]byte, error) {
+	return json.Marshal(map[string]string{"$ref": ref})
+}
+
+// Unmarshal decodes data into v, a pointer to a struct, and collects the
+// fields v does not declare, x-* extensions and unknown fields alike, into
+// extensions.
+func Unmarshal(data []byte, v interface{}, extensions *map[string]interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	for key, raw := range fields {
+		if known[key] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		if *extensions == nil {
+			*extensions = make(map[string]interface{})
+		}
+		(*extensions)[key] = value
+	}
+	return nil
+}
+
+// fieldCache maps struct types to the JSON names of their fields.
+var fieldCache sync.Map
+
+// jsonFields returns the JSON names of the fields of a struct type.
+func jsonFields(t reflect.Type) map[string]bool {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	fieldCache.Store(t, names)
+	return names
+}
@@ -0,0 +1,627 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jsonPath is a compiled JSONPath query (RFC 9535) over a decoded JSON
+// tree. Names, wildcards, indexes, slices, unions, descendant segments and
+// filters with comparisons, existence tests and logical operators are
+// supported; filter functions are not.
+type jsonPath struct {
+	segments []pathSegment
+}
+
+// pathSegment is one step of a query: its selectors apply to the current
+// nodes, or to them and all their descendants.
+type pathSegment struct {
+	descendant bool
+	selectors  []pathSelector
+}
+
+// pathSelector selects children of a node.
+type pathSelector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	slice  [3]*int // start, end, step
+	filter filterExpr
+}
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectWildcard
+	selectIndex
+	selectSlice
+	selectFilter
+)
+
+// pathMatch is a node selected by a query with its location, a list of
+// object keys (string) and array indexes (int) from the root.
+type pathMatch struct {
+	path  []interface{}
+	value interface{}
+}
+
+// compileJSONPath parses a query, which must start at the root ($).
+func compileJSONPath(query string) (*jsonPath, error) {
+	p := &pathParser{input: query}
+	p.skipSpace()
+	if !p.consume("$") {
+		return nil, fmt.Errorf("invalid JSONPath %q: must start with $", query)
+	}
+	segments, err := p.segments()
+	if err == nil {
+		p.skipSpace()
+		if p.pos < len(p.input) {
+			err = p.errorf("unexpected %q", p.input[p.pos:])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %w", query, err)
+	}
+	return &jsonPath{segments: segments}, nil
+}
+
+// selectFrom returns the nodes of root the query selects.
+func (q *jsonPath) selectFrom(root interface{}) []pathMatch {
+	return evaluate(q.segments, root, []pathMatch{{value: root}})
+}
+
+// evaluate applies segments to the nodes, resolving $ in filters to root.
+func evaluate(segments []pathSegment, root interface{}, nodes []pathMatch) []pathMatch {
+	for _, segment := range segments {
+		var next []pathMatch
+		for _, node := range nodes {
+			targets := []pathMatch{node}
+			if segment.descendant {
+				targets = descendants(node)
+			}
+			for _, target := range targets {
+				for _, selector := range segment.selectors {
+					next = append(next, selector.apply(target, root)...)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// descendants returns node and everything below it, in document order.
+func descendants(node pathMatch) []pathMatch {
+	out := []pathMatch{node}
+	for _, child := range children(node) {
+		out = append(out, descendants(child)...)
+	}
+	return out
+}
+
+// children returns the members of an object, in key order, or the
+// elements of an array.
+func children(node pathMatch) []pathMatch {
+	var out []pathMatch
+	switch v := node.value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			out = append(out, node.child(key, v[key]))
+		}
+	case []interface{}:
+		for i, item := range v {
+			out = append(out, node.child(i, item))
+		}
+	}
+	return out
+}
+
+func (m pathMatch) child(token, value interface{}) pathMatch {
+	path := make([]interface{}, len(m.path), len(m.path)+1)
+	copy(path, m.path)
+	return pathMatch{path: append(path, token), value: value}
+}
+
+// pointer returns the JSON pointer of the match.
+func (m pathMatch) pointer() string {
+	var b strings.Builder
+	for _, token := range m.path {
+		b.WriteString("/" + escapePointerToken(fmt.Sprint(token)))
+	}
+	return b.String()
+}
+
+func (s pathSelector) apply(node pathMatch, root interface{}) []pathMatch {
+	switch s.kind {
+	case selectName:
+		if object, ok := node.value.(map[string]interface{}); ok {
+			if value, ok := object[s.name]; ok {
+				return []pathMatch{node.child(s.name, value)}
+			}
+		}
+	case selectWildcard:
+		return children(node)
+	case selectIndex:
+		if array, ok := node.value.([]interface{}); ok {
+			i := s.index
+			if i < 0 {
+				i += len(array)
+			}
+			if i >= 0 && i < len(array) {
+				return []pathMatch{node.child(i, array[i])}
+			}
+		}
+	case selectSlice:
+		if array, ok := node.value.([]interface{}); ok {
+			var out []pathMatch
+			for _, i := range sliceIndexes(s.slice, len(array)) {
+				out = append(out, node.child(i, array[i]))
+			}
+			return out
+		}
+	case selectFilter:
+		var out []pathMatch
+		for _, child := range children(node) {
+			if s.filter.test(child.value, root) {
+				out = append(out, child)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// sliceIndexes returns the indexes an array slice selects, following the
+// normalization rules of RFC 9535.
+func sliceIndexes(slice [3]*int, length int) []int {
+	step := 1
+	if slice[2] != nil {
+		step = *slice[2]
+	}
+	if step == 0 {
+		return nil
+	}
+	normalize := func(bound *int, fallback int) int {
+		if bound == nil {
+			return fallback
+		}
+		if *bound < 0 {
+			return *bound + length
+		}
+		return *bound
+	}
+	clamp := func(i, low, high int) int { return min(max(i, low), high) }
+
+	var out []int
+	if step > 0 {
+		start := clamp(normalize(slice[0], 0), 0, length)
+		end := clamp(normalize(slice[1], length), 0, length)
+		for i := start; i < end; i += step {
+			out = append(out, i)
+		}
+		return out
+	}
+	start := clamp(normalize(slice[0], length-1), -1, length-1)
+	end := clamp(normalize(slice[1], -length-1), -1, length-1)
+	for i := start; i > end; i += step {
+		out = append(out, i)
+	}
+	return out
+}
+
+// filterExpr is a filter expression evaluated against a candidate node.
+type filterExpr interface {
+	test(current, root interface{}) bool
+}
+
+type orExpr struct{ left, right filterExpr }
+
+func (e orExpr) test(current, root interface{}) bool {
+	return e.left.test(current, root) || e.right.test(current, root)
+}
+
+type andExpr struct{ left, right filterExpr }
+
+func (e andExpr) test(current, root interface{}) bool {
+	return e.left.test(current, root) && e.right.test(current, root)
+}
+
+type notExpr struct{ expr filterExpr }
+
+func (e notExpr) test(current, root interface{}) bool {
+	return !e.expr.test(current, root)
+}
+
+// existsExpr is true when its query selects at least one node.
+type existsExpr struct{ query filterQuery }
+
+func (e existsExpr) test(current, root interface{}) bool {
+	return len(e.query.nodes(current, root)) > 0
+}
+
+type compareExpr struct {
+	left, right filterOperand
+	op          string
+}
+
+func (e compareExpr) test(current, root interface{}) bool {
+	left, leftOK := e.left.value(current, root)
+	right, rightOK := e.right.value(current, root)
+	switch e.op {
+	case "==":
+		return equalValues(left, leftOK, right, rightOK)
+	case "!=":
+		return !equalValues(left, leftOK, right, rightOK)
+	case "<":
+		return lessThan(left, leftOK, right, rightOK)
+	case ">":
+		return lessThan(right, rightOK, left, leftOK)
+	case "<=":
+		return lessThan(left, leftOK, right, rightOK) || equalValues(left, leftOK, right, rightOK)
+	case ">=":
+		return lessThan(right, rightOK, left, leftOK) || equalValues(left, leftOK, right, rightOK)
+	}
+	return false
+}
+
+// equalValues compares two operands; a query that selects nothing only
+// equals another one that selects nothing.
+func equalValues(left interface{}, leftOK bool, right interface{}, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return leftOK == rightOK
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// lessThan orders two numbers or two strings.
+func lessThan(left interface{}, leftOK bool, right interface{}, rightOK bool) bool {
+	if !leftOK || !rightOK {
+		return false
+	}
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		return ok && l < r
+	case string:
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
+
+// filterOperand is one side of a comparison.
+type filterOperand interface {
+	value(current, root interface{}) (interface{}, bool)
+}
+
+type literal struct{ v interface{} }
+
+func (l literal) value(interface{}, interface{}) (interface{}, bool) {
+	return l.v, true
+}
+
+// filterQuery is a query relative to the candidate (@) or the root ($).
+// As an operand it must select a single node.
+type filterQuery struct {
+	relative bool
+	segments []pathSegment
+}
+
+func (q filterQuery) nodes(current, root interface{}) []pathMatch {
+	start := root
+	if q.relative {
+		start = current
+	}
+	return evaluate(q.segments, root, []pathMatch{{value: start}})
+}
+
+func (q filterQuery) value(current, root interface{}) (interface{}, bool) {
+	nodes := q.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].value, true
+}
+
+// pathParser is a recursive descent parser for JSONPath queries.
+type pathParser struct {
+	input string
+	pos   int
+}
+
+func (p *pathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *pathParser) peek(s string) bool {
+	return strings.HasPrefix(p.input[p.pos:], s)
+}
+
+func (p *pathParser) consume(s string) bool {
+	if p.peek(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) skipSpace() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// segments parses the segments following $ or @.
+func (p *pathParser) segments() ([]pathSegment, error) {
+	var segments []pathSegment
+	for {
+		switch {
+		case p.consume(".."):
+			segment, err := p.dotSegment(true)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+		case p.consume("."):
+			segment, err := p.dotSegment(false)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+		case p.peek("["):
+			selectors, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, pathSegment{selectors: selectors})
+		default:
+			return segments, nil
+		}
+	}
+}
+
+// dotSegment parses the name, wildcard or bracket following "." or "..".
+func (p *pathParser) dotSegment(descendant bool) (pathSegment, error) {
+	if descendant && p.peek("[") {
+		selectors, err := p.bracket()
+		return pathSegment{descendant: true, selectors: selectors}, err
+	}
+	if p.consume("*") {
+		return pathSegment{descendant: descendant, selectors: []pathSelector{{kind: selectWildcard}}}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if r != '_' && !unicode.IsLetter(r) && r < 0x80 && (p.pos == start || !unicode.IsDigit(r)) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return pathSegment{}, p.errorf("expected a member name")
+	}
+	name := p.input[start:p.pos]
+	return pathSegment{descendant: descendant, selectors: []pathSelector{{kind: selectName, name: name}}}, nil
+}
+
+// bracket parses a comma-separated list of selectors in brackets.
+func (p *pathParser) bracket() ([]pathSelector, error) {
+	p.consume("[")
+	var selectors []pathSelector
+	for {
+		p.skipSpace()
+		selector, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+		p.skipSpace()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *pathParser) selector() (pathSelector, error) {
+	switch {
+	case p.peek("'") || p.peek(`"`):
+		name, err := p.stringLiteral()
+		return pathSelector{kind: selectName, name: name}, err
+	case p.consume("*"):
+		return pathSelector{kind: selectWildcard}, nil
+	case p.consume("?"):
+		filter, err := p.or()
+		return pathSelector{kind: selectFilter, filter: filter}, err
+	}
+
+	var bounds [3]*int
+	part := 0
+	for {
+		p.skipSpace()
+		if n, ok := p.integer(); ok {
+			bounds[part] = &n
+		}
+		p.skipSpace()
+		if part == 2 || !p.consume(":") {
+			break
+		}
+		part++
+	}
+	switch {
+	case part == 0 && bounds[0] != nil:
+		return pathSelector{kind: selectIndex, index: *bounds[0]}, nil
+	case part > 0:
+		return pathSelector{kind: selectSlice, slice: bounds}, nil
+	}
+	return pathSelector{}, p.errorf("expected a selector")
+}
+
+// integer parses an optionally negative integer.
+func (p *pathParser) integer() (int, bool) {
+	start := p.pos
+	p.consume("-")
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, false
+	}
+	return n, true
+}
+
+// stringLiteral parses a single- or double-quoted string.
+func (p *pathParser) stringLiteral() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c != '\\':
+			b.WriteByte(c)
+		case p.pos >= len(p.input):
+			return "", p.errorf("unterminated string")
+		default:
+			escaped := p.input[p.pos]
+			p.pos++
+			switch escaped {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if p.pos+4 > len(p.input) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				b.WriteByte(escaped)
+			}
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// or parses a logical-or expression, the loosest-binding filter form.
+func (p *pathParser) or() (filterExpr, error) {
+	left, err := p.and()
+	for err == nil {
+		p.skipSpace()
+		if !p.consume("||") {
+			return left, nil
+		}
+		var right filterExpr
+		right, err = p.and()
+		left = orExpr{left, right}
+	}
+	return nil, err
+}
+
+func (p *pathParser) and() (filterExpr, error) {
+	left, err := p.unary()
+	for err == nil {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		var right filterExpr
+		right, err = p.unary()
+		left = andExpr{left, right}
+	}
+	return nil, err
+}
+
+// unary parses a negation, a parenthesized expression, an existence test
+// or a comparison.
+func (p *pathParser) unary() (filterExpr, error) {
+	p.skipSpace()
+	switch {
+	case p.consume("!"):
+		expr, err := p.unary()
+		return notExpr{expr}, err
+	case p.consume("("):
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			p.skipSpace()
+			right, err := p.operand()
+			return compareExpr{left: left, right: right, op: op}, err
+		}
+	}
+	query, ok := left.(filterQuery)
+	if !ok {
+		return nil, p.errorf("expected a comparison")
+	}
+	return existsExpr{query}, nil
+}
+
+// operand parses a query or a literal.
+func (p *pathParser) operand() (filterOperand, error) {
+	switch {
+	case p.peek("@") || p.peek("$"):
+		relative := p.input[p.pos] == '@'
+		p.pos++
+		segments, err := p.segments()
+		return filterQuery{relative: relative, segments: segments}, err
+	case p.peek("'") || p.peek(`"`):
+		s, err := p.stringLiteral()
+		return literal{s}, err
+	case p.consume("true"):
+		return literal{true}, nil
+	case p.consume("false"):
+		return literal{false}, nil
+	case p.consume("null"):
+		return literal{nil}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte("-+0123456789.eE", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("expected a query or literal")
+	}
+	return literal{n}, nil
+}
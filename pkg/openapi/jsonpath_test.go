@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const jsonPathDocument = `{
+	"paths": {
+		"/pets": {
+			"get": {"tags": ["pets"], "x-internal": true, "parameters": [{"name": "limit", "in": "query"}, {"name": "page", "in": "query"}]},
+			"post": {"tags": ["pets", "admin"], "parameters": [{"name": "body", "in": "body"}]}
+		},
+		"/users": {"get": {"tags": ["users"], "x-rate": 10}}
+	},
+	"list": [0, 1, 2, 3, 4, 5]
+}`
+
+func TestJSONPath(t *testing.T) {
+	t.Parallel()
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(jsonPathDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "$", want: []string{""}},
+		{query: "$.paths['/pets'].get", want: []string{"/paths/~1pets/get"}},
+		{query: `$.paths["/pets"]["get","post"].tags[0]`, want: []string{"/paths/~1pets/get/tags/0", "/paths/~1pets/post/tags/0"}},
+		{query: "$.paths.*.*", want: []string{"/paths/~1pets/get", "/paths/~1pets/post", "/paths/~1users/get"}},
+		{query: "$..parameters[-1].name", want: []string{"/paths/~1pets/get/parameters/1/name", "/paths/~1pets/post/parameters/0/name"}},
+		{query: "$.list[1:5:2]", want: []string{"/list/1", "/list/3"}},
+		{query: "$.list[::-2]", want: []string{"/list/5", "/list/3", "/list/1"}},
+		{query: "$.list[-2:]", want: []string{"/list/4", "/list/5"}},
+		{query: "$.paths.*[?@['x-internal'] == true]", want: []string{"/paths/~1pets/get"}},
+		{query: "$.paths.*[?(@['x-rate'] >= 10 || !@.parameters)]", want: []string{"/paths/~1users/get"}},
+		{query: "$.paths.*[?@.tags[?@ == 'admin']]", want: []string{"/paths/~1pets/post"}},
+		{query: "$..parameters[?@.in == 'query' && @.name != 'page']", want: []string{"/paths/~1pets/get/parameters/0"}},
+		{query: "$.list[?@ > $.list[3]]", want: []string{"/list/4", "/list/5"}},
+		{query: "$.missing.*", want: nil},
+	}
+
+	for _, tt := range tests {
+		query, err := compileJSONPath(tt.query)
+		if err != nil {
+			t.Errorf("compileJSONPath(%q) error = %v", tt.query, err)
+			continue
+		}
+		var got []string
+		for _, match := range query.selectFrom(doc) {
+			got = append(got, match.pointer())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s selected %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCompileJSONPathErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"", "paths", "$.", "$[", "$['a'", "$[?@.a ==]", "$.a b", "$[?length(@) > 1]"} {
+		if _, err := compileJSONPath(query); err == nil {
+			t.Errorf("compileJSONPath(%q) succeeded, want an error", query)
+		}
+	}
+}
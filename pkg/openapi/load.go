@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	v2 "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	v3 "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Load reads a Swagger 2.0 or OpenAPI 3.x document in JSON or YAML.
+func Load(path string) (Specification, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes a Swagger 2.0 or OpenAPI 3.x document in JSON or YAML and
+// returns a *v2.Swagger or *v3.OpenAPI depending on its version field.
+func Parse(data []byte) (Specification, error) {
+	var header struct {
+		Swagger string `json:"swagger" yaml:"swagger"`
+		OpenAPI string `json:"openapi" yaml:"openapi"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(data, &header); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(header.Swagger, "2."):
+		spec, err := v2.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return spec, nil
+	case strings.HasPrefix(header.OpenAPI, "3."):
+		spec, err := v3.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return spec, nil
+	case header.Swagger != "":
+		return nil, fmt.Errorf("unsupported swagger version %q", header.Swagger)
+	case header.OpenAPI != "":
+		return nil, fmt.Errorf("unsupported openapi version %q", header.OpenAPI)
+	}
+
+	return nil, fmt.Errorf("document has neither a swagger nor an openapi version field")
+}
@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		version string
+		wantErr string
+	}{
+		{name: "swagger yaml", data: "swagger: \"2.0\"\ninfo:\n  title: A\n  version: '1'\npaths:\n  /a:\n    get:\n      responses:\n        200:\n          description: OK\n", version: "2.0"},
+		{name: "swagger json", data: `{"swagger":"2.0","info":{"title":"A","version":"1"},"paths":{}}`, version: "2.0"},
+		{name: "openapi yaml", data: "openapi: 3.0.3\ninfo:\n  title: A\n  version: '1'\npaths: {}\n", version: "3.0.3"},
+		{name: "openapi json", data: `{"openapi":"3.2.0","info":{"title":"A","version":"1"},"paths":{}}`, version: "3.2.0"},
+		{name: "unsupported", data: `{"openapi":"4.0.0"}`, wantErr: "unsupported openapi version"},
+		{name: "no version", data: `{"info":{}}`, wantErr: "neither"},
+		{name: "invalid yaml", data: "a: [", wantErr: "invalid YAML"},
+		{name: "invalid body", data: `{"openapi":"3.1.0","paths":[]}`, wantErr: "invalid OpenAPI document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if spec.GetVersion() != tt.version {
+				t.Errorf("GetVersion() = %q, want %q", spec.GetVersion(), tt.version)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	v2Path := filepath.Join(dir, "swagger.json")
+	v3Path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(v2Path, []byte(`{"swagger":"2.0","info":{"title":"A","version":"1"},"paths":{},"x-owner":"team"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(v3Path, []byte("openapi: 3.1.0\ninfo:\n  title: A\n  version: '1'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := Load(v2Path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if doc, ok := spec.(*swagger.Swagger); !ok || doc.Extensions["x-owner"] != "team" {
+		t.Errorf("Load() = %#v, want *v2.Swagger with x-owner", spec)
+	}
+
+	spec, err = Load(v3Path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := spec.(*openapi.OpenAPI); !ok {
+		t.Errorf("Load() = %T, want *v3.OpenAPI", spec)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+}
@@ -0,0 +1,643 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	v2 "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	v3 "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// MergeSource is one specification passed to Merge.
+type MergeSource struct {
+	Name       string        // Identifies the source in reports and prefixes renamed components
+	Spec       Specification // Swagger 2.0 or OpenAPI 3.x document
+	PathPrefix string        // Prepended to every path, e.g. "/users"
+	TagPrefix  string        // Prepended to every tag name, e.g. "users-"
+}
+
+// MergeRename records a component renamed because another source already
+// defined a different component with the same name.
+type MergeRename struct {
+	Source  string // Source the component came from
+	Section string // Component section, e.g. "components/schemas"
+	From    string // Original name
+	To      string // Name in the merged document
+}
+
+// MergeConflict records an item dropped because an earlier source already
+// defined it.
+type MergeConflict struct {
+	Source   string // Source whose item was dropped
+	Existing string // Source whose item was kept
+	Pointer  string // JSON pointer of the item in the merged document
+}
+
+// MergeReport describes how Merge resolved collisions between sources.
+type MergeReport struct {
+	Renames   []MergeRename
+	Conflicts []MergeConflict
+}
+
+// Merge combines several specifications of the same major version into one.
+//
+// Paths and tags are prefixed per source. Components with the same name
+// and content are shared; otherwise the later one is renamed to
+// "<source>_<name>" and the references to it are rewritten. Operations on a
+// path and method another source already defines are dropped and reported
+// as conflicts. Schemes and tags are combined; root security, consumes and
+// produces that differ between sources are moved onto the operations, and
+// differing root servers onto the path items. Info and the version come
+// from the first source.
+func Merge(sources ...MergeSource) (Specification, *MergeReport, error) {
+	if len(sources) == 0 {
+		return nil, nil, fmt.Errorf("no specifications to merge")
+	}
+
+	m := &merger{report: &MergeReport{}, owners: make(map[string]string)}
+	trees := make([]map[string]interface{}, len(sources))
+	for i, source := range sources {
+		if source.Spec == nil {
+			return nil, nil, fmt.Errorf("source %q has no specification", source.Name)
+		}
+		_, isV2 := source.Spec.(*v2.Swagger)
+		if i == 0 {
+			m.v2 = isV2
+		} else if isV2 != m.v2 {
+			return nil, nil, fmt.Errorf("source %q is %s but %q is %s; convert them to the same version first",
+				source.Name, source.Spec.GetVersion(), sources[0].Name, sources[0].Spec.GetVersion())
+		}
+
+		data, err := json.Marshal(source.Spec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("source %q: %w", source.Name, err)
+		}
+		var tree map[string]interface{}
+		if err := json.Unmarshal(data, &tree); err != nil {
+			return nil, nil, fmt.Errorf("source %q: %w", source.Name, err)
+		}
+		trees[i] = tree
+	}
+
+	// Components are merged first, since renaming a security scheme can
+	// make equal root security requirements differ
+	m.doc = map[string]interface{}{"paths": map[string]interface{}{}}
+	sharedBase := m.v2 && agree(trees, "basePath")
+	prefixes := make([]string, len(sources))
+	for i, source := range sources {
+		tree := trees[i]
+		prefixes[i] = normalizePrefix(source.PathPrefix)
+		if basePath, _ := tree["basePath"].(string); m.v2 && !sharedBase && basePath != "/" {
+			prefixes[i] += normalizePrefix(basePath)
+		}
+		if source.TagPrefix != "" {
+			m.prefixTags(tree, source.TagPrefix)
+		}
+
+		renames := m.renames(source.Name, tree)
+		m.rewrite(tree, renames, prefixes[i])
+		m.components(tree)
+	}
+
+	// Root fields operations can override stay at the root only when every
+	// source agrees on them
+	overridable := []string{"security"}
+	if m.v2 {
+		overridable = append(overridable, "consumes", "produces")
+	}
+	for key, value := range trees[0] {
+		switch key {
+		case "paths", "webhooks", "components", "definitions", "parameters", "responses",
+			"securityDefinitions", "tags", "servers", "schemes":
+		case "basePath":
+			if sharedBase {
+				m.doc[key] = value
+			}
+		default:
+			if !slices.Contains(overridable, key) || agree(trees, key) {
+				m.doc[key] = value
+			}
+		}
+	}
+
+	sharedServers := agree(trees, "servers")
+	for i, source := range sources {
+		tree := trees[i]
+		for _, key := range overridable {
+			if !agree(trees, key) {
+				m.pushDown(tree, key)
+			}
+		}
+		if !sharedServers {
+			m.pushDownServers(tree)
+		}
+		m.paths(source.Name, tree, "paths", prefixes[i])
+		m.paths(source.Name, tree, "webhooks", "")
+		m.root(tree)
+	}
+
+	spec, err := specification(m.doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spec, m.report, nil
+}
+
+// merger accumulates the merged document as a JSON tree.
+type merger struct {
+	v2     bool
+	doc    map[string]interface{}
+	report *MergeReport
+	owners map[string]string // JSON pointer -> source of merged operations
+}
+
+// sections returns the component sections of the merged document.
+func (m *merger) sections() []string {
+	if m.v2 {
+		return []string{"definitions", "parameters", "responses", "securityDefinitions"}
+	}
+	return []string{
+		"components/schemas", "components/responses", "components/parameters",
+		"components/examples", "components/requestBodies", "components/headers",
+		"components/securitySchemes", "components/links", "components/callbacks",
+		"components/pathItems",
+	}
+}
+
+// schemeSection returns the section security schemes live in.
+func (m *merger) schemeSection() string {
+	if m.v2 {
+		return "securityDefinitions"
+	}
+	return "components/securitySchemes"
+}
+
+// methods returns the operation keys of a path item.
+func (m *merger) methods() []string {
+	if m.v2 {
+		return v2.Methods
+	}
+	return v3.Methods
+}
+
+// operations returns the operation objects of the path items in tree.
+func (m *merger) operations(tree map[string]interface{}) []map[string]interface{} {
+	var ops []map[string]interface{}
+	for _, key := range []string{"paths", "webhooks"} {
+		items, _ := tree[key].(map[string]interface{})
+		for _, path := range sortedKeys(items) {
+			item, _ := items[path].(map[string]interface{})
+			for _, method := range m.methods() {
+				if op, ok := item[method].(map[string]interface{}); ok {
+					ops = append(ops, op)
+				}
+			}
+		}
+	}
+	return ops
+}
+
+// pushDown copies the root value of key onto the operations that do not
+// set their own, then removes it from the root.
+func (m *merger) pushDown(tree map[string]interface{}, key string) {
+	value, ok := tree[key]
+	if !ok {
+		return
+	}
+	for _, op := range m.operations(tree) {
+		if _, set := op[key]; !set {
+			op[key] = value
+		}
+	}
+	delete(tree, key)
+}
+
+// pushDownServers copies the root servers onto the path items that do not
+// set their own, then removes them from the root, so each path keeps
+// pointing at the hosts of its own source.
+func (m *merger) pushDownServers(tree map[string]interface{}) {
+	servers, ok := tree["servers"]
+	if !ok {
+		return
+	}
+	items, _ := tree["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(items) {
+		if item, ok := items[path].(map[string]interface{}); ok {
+			if _, set := item["servers"]; !set {
+				item["servers"] = servers
+			}
+		}
+	}
+	delete(tree, "servers")
+}
+
+// prefixTags prepends prefix to the root tags and the operation tags.
+func (m *merger) prefixTags(tree map[string]interface{}, prefix string) {
+	tags, _ := tree["tags"].([]interface{})
+	for _, tag := range tags {
+		if object, ok := tag.(map[string]interface{}); ok {
+			if name, ok := object["name"].(string); ok {
+				object["name"] = prefix + name
+			}
+		}
+	}
+	for _, op := range m.operations(tree) {
+		names, _ := op["tags"].([]interface{})
+		for i, name := range names {
+			if s, ok := name.(string); ok {
+				names[i] = prefix + s
+			}
+		}
+	}
+}
+
+// renames picks new names for the components of tree that collide with a
+// different component in the merged document. A component whose content
+// only differs through a renamed reference is renamed as well, so the
+// check repeats until no new collisions appear.
+func (m *merger) renames(source string, tree map[string]interface{}) map[string]map[string]string {
+	renames := make(map[string]map[string]string)
+	for changed := true; changed; {
+		changed = false
+		for _, section := range m.sections() {
+			components := container(tree, section)
+			existing := container(m.doc, section)
+			for _, name := range sortedKeys(components) {
+				if _, done := renames[section][name]; done || existing[name] == nil {
+					continue
+				}
+				value := m.rewritten(components[name], renames, "")
+				if reflect.DeepEqual(existing[name], value) {
+					continue
+				}
+				if renames[section] == nil {
+					renames[section] = make(map[string]string)
+				}
+				renames[section][name] = m.unique(section, source+"_"+name, components, renames[section])
+				changed = true
+			}
+		}
+	}
+
+	for _, section := range m.sections() {
+		for _, name := range sortedKeys(renames[section]) {
+			m.report.Renames = append(m.report.Renames, MergeRename{
+				Source: source, Section: section, From: name, To: renames[section][name],
+			})
+		}
+	}
+	return renames
+}
+
+// unique sanitizes name and suffixes it until no component in the merged
+// document or the source, and no other rename, uses it.
+func (m *merger) unique(section, name string, components map[string]interface{}, taken map[string]string) string {
+	name = invalidComponentName.ReplaceAllString(name, "_")
+	used := func(candidate string) bool {
+		if container(m.doc, section)[candidate] != nil || components[candidate] != nil {
+			return true
+		}
+		for _, other := range taken {
+			if other == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := name
+	for i := 2; used(candidate); i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	return candidate
+}
+
+// rewrite applies renames and the path prefix to the references in tree.
+func (m *merger) rewrite(tree map[string]interface{}, renames map[string]map[string]string, prefix string) {
+	for key, value := range m.rewritten(tree, renames, prefix).(map[string]interface{}) {
+		tree[key] = value
+	}
+	for _, section := range m.sections() {
+		components := container(tree, section)
+		for from, to := range renames[section] {
+			components[to] = components[from]
+			delete(components, from)
+		}
+	}
+}
+
+// rewritten returns a copy of node with references to renamed components,
+// security requirement names and discriminator mappings updated, and
+// references into the paths prefixed.
+func (m *merger) rewritten(node interface{}, renames map[string]map[string]string, prefix string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			switch key {
+			case "$ref", "operationRef":
+				if ref, ok := value.(string); ok {
+					value = m.reference(ref, renames, prefix)
+				}
+			case "security":
+				value = m.requirements(value, renames)
+			case "discriminator":
+				value = m.discriminator(value, renames)
+			default:
+				value = m.rewritten(value, renames, prefix)
+			}
+			out[key] = value
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = m.rewritten(item, renames, prefix)
+		}
+		return out
+	}
+	return node
+}
+
+// reference rewrites a local reference to a renamed component or a path.
+func (m *merger) reference(ref string, renames map[string]map[string]string, prefix string) string {
+	if prefix != "" && strings.HasPrefix(ref, "#/paths/") {
+		token, rest, _ := strings.Cut(strings.TrimPrefix(ref, "#/paths/"), "/")
+		path := prefix + strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		ref = "#/paths/" + escapePointerToken(path)
+		if rest != "" {
+			ref += "/" + rest
+		}
+		return ref
+	}
+
+	for section, names := range renames {
+		base := "#/" + section + "/"
+		if !strings.HasPrefix(ref, base) {
+			continue
+		}
+		name, rest, hasRest := strings.Cut(strings.TrimPrefix(ref, base), "/")
+		if to, ok := names[name]; ok {
+			ref = base + to
+			if hasRest {
+				ref += "/" + rest
+			}
+		}
+		return ref
+	}
+	return ref
+}
+
+// requirements renames the schemes of a list of security requirements.
+// Anything else, such as a schema property named "security", is walked
+// like any other node.
+func (m *merger) requirements(value interface{}, renames map[string]map[string]string) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return m.rewritten(value, renames, "")
+	}
+	schemes := renames[m.schemeSection()]
+	out := make([]interface{}, len(list))
+	for i, item := range list {
+		requirement, ok := item.(map[string]interface{})
+		if !ok {
+			out[i] = item
+			continue
+		}
+		renamed := make(map[string]interface{}, len(requirement))
+		for name, scopes := range requirement {
+			if to, ok := schemes[name]; ok {
+				name = to
+			}
+			renamed[name] = scopes
+		}
+		out[i] = renamed
+	}
+	return out
+}
+
+// discriminator rewrites the mapping of a discriminator object, whose
+// values are references or bare schema names.
+func (m *merger) discriminator(value interface{}, renames map[string]map[string]string) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return m.rewritten(value, renames, "")
+	}
+	out := m.rewritten(object, renames, "").(map[string]interface{})
+	mapping, _ := out["mapping"].(map[string]interface{})
+	schemas := renames["components/schemas"]
+	if m.v2 {
+		schemas = renames["definitions"]
+	}
+	for key, target := range mapping {
+		name, _ := target.(string)
+		if to, ok := schemas[name]; ok {
+			mapping[key] = to
+		} else if strings.HasPrefix(name, "#/") {
+			mapping[key] = m.reference(name, renames, "")
+		}
+	}
+	return out
+}
+
+// components adds the components of tree that the merged document lacks.
+// Equal components are shared; colliding ones were renamed beforehand.
+func (m *merger) components(tree map[string]interface{}) {
+	for _, section := range m.sections() {
+		components := container(tree, section)
+		if len(components) == 0 {
+			continue
+		}
+		target := container(m.doc, section)
+		if target == nil {
+			target = make(map[string]interface{})
+			setContainer(m.doc, section, target)
+		}
+		for _, name := range sortedKeys(components) {
+			if _, ok := target[name]; !ok {
+				target[name] = components[name]
+			}
+		}
+	}
+}
+
+// paths adds the path items under key, prefixing their paths. Operations
+// on a path and method the merged document already has are reported and
+// dropped. When two sources share a path, path-level parameters and servers
+// move onto the operations so they keep applying only to their own source.
+func (m *merger) paths(source string, tree map[string]interface{}, key, prefix string) {
+	items, _ := tree[key].(map[string]interface{})
+	if len(items) == 0 {
+		return
+	}
+	target, _ := m.doc[key].(map[string]interface{})
+	if target == nil {
+		target = make(map[string]interface{})
+		m.doc[key] = target
+	}
+
+	for _, path := range sortedKeys(items) {
+		item, _ := items[path].(map[string]interface{})
+		merged := prefix + path
+		if prefix != "" && path == "/" {
+			merged = prefix
+		}
+		pointer := "/" + key + "/" + escapePointerToken(merged)
+
+		existing, ok := target[merged].(map[string]interface{})
+		if !ok {
+			target[merged] = item
+			for _, method := range m.methods() {
+				if item[method] != nil {
+					m.owners[pointer+"/"+method] = source
+				}
+			}
+			continue
+		}
+
+		m.inlineParameters(existing)
+		m.inlineParameters(item)
+		m.inlineServers(existing)
+		m.inlineServers(item)
+		for _, method := range m.methods() {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if existing[method] != nil {
+				m.report.Conflicts = append(m.report.Conflicts, MergeConflict{
+					Source:   source,
+					Existing: m.owners[pointer+"/"+method],
+					Pointer:  pointer + "/" + method,
+				})
+				continue
+			}
+			existing[method] = op
+			m.owners[pointer+"/"+method] = source
+		}
+	}
+}
+
+// inlineParameters moves the path-level parameters of item onto its
+// operations.
+func (m *merger) inlineParameters(item map[string]interface{}) {
+	params, ok := item["parameters"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, method := range m.methods() {
+		if op, ok := item[method].(map[string]interface{}); ok {
+			op["parameters"] = mergeParameters(params, op["parameters"])
+		}
+	}
+	delete(item, "parameters")
+}
+
+// inlineServers moves the path-level servers of item onto its operations
+// that do not set their own.
+func (m *merger) inlineServers(item map[string]interface{}) {
+	servers, ok := item["servers"]
+	if !ok {
+		return
+	}
+	for _, method := range m.methods() {
+		if op, ok := item[method].(map[string]interface{}); ok {
+			if _, set := op["servers"]; !set {
+				op["servers"] = servers
+			}
+		}
+	}
+	delete(item, "servers")
+}
+
+// mergeParameters returns the operation parameters preceded by the path
+// parameters they do not override.
+func mergeParameters(pathParams []interface{}, opParams interface{}) []interface{} {
+	own, _ := opParams.([]interface{})
+	identity := func(param interface{}) string {
+		object, _ := param.(map[string]interface{})
+		if ref, ok := refOf(object); ok {
+			return ref
+		}
+		return fmt.Sprint(object["in"], ":", object["name"])
+	}
+
+	var out []interface{}
+	for _, param := range pathParams {
+		overridden := false
+		for _, other := range own {
+			if identity(other) == identity(param) {
+				overridden = true
+			}
+		}
+		if !overridden {
+			out = append(out, param)
+		}
+	}
+	return append(out, own...)
+}
+
+// root merges the tags, servers and schemes of tree into the document.
+// Servers only remain at the root when every source agrees on them.
+func (m *merger) root(tree map[string]interface{}) {
+	m.union(tree, "tags", func(item interface{}) string {
+		object, _ := item.(map[string]interface{})
+		name, _ := object["name"].(string)
+		return name
+	})
+	m.union(tree, "servers", func(item interface{}) string {
+		object, _ := item.(map[string]interface{})
+		url, _ := object["url"].(string)
+		return url
+	})
+	m.union(tree, "schemes", func(item interface{}) string {
+		scheme, _ := item.(string)
+		return scheme
+	})
+}
+
+// union appends the items of the array under key that the document does
+// not contain yet, comparing them by identity.
+func (m *merger) union(tree map[string]interface{}, key string, identity func(interface{}) string) {
+	items, _ := tree[key].([]interface{})
+	if len(items) == 0 {
+		return
+	}
+	merged, _ := m.doc[key].([]interface{})
+	for _, item := range items {
+		found := false
+		for _, other := range merged {
+			if identity(other) == identity(item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	m.doc[key] = merged
+}
+
+// agree reports whether every tree has the same value under key.
+func agree(trees []map[string]interface{}, key string) bool {
+	for _, tree := range trees[1:] {
+		if !reflect.DeepEqual(tree[key], trees[0][key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizePrefix returns prefix with a leading and no trailing slash.
+func normalizePrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
@@ -0,0 +1,245 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// mustParse parses a document or fails the test.
+func mustParse(t *testing.T, doc string) Specification {
+	t.Helper()
+	spec, err := Parse([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+const usersService = `openapi: 3.1.0
+info: {title: Users, version: "1"}
+servers: [{url: "https://users.internal"}]
+security: [{bearer: []}]
+tags: [{name: users}]
+paths:
+  /users/{id}:
+    parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+    get:
+      tags: [users]
+      responses:
+        "200": {description: OK, content: {application/json: {schema: {$ref: "#/components/schemas/User"}}}}
+        default: {$ref: "#/components/responses/Error"}
+  /health:
+    get:
+      responses: {"200": {description: OK}}
+components:
+  schemas:
+    User: {type: object, properties: {address: {$ref: "#/components/schemas/Address"}}}
+    Address: {type: object, properties: {street: {type: string}}}
+    Problem: {type: object}
+  responses:
+    Error: {description: Error, content: {application/json: {schema: {$ref: "#/components/schemas/Problem"}}}}
+  securitySchemes:
+    bearer: {type: http, scheme: bearer}
+`
+
+const ordersService = `openapi: 3.1.0
+info: {title: Orders, version: "2"}
+servers: [{url: "https://users.internal"}, {url: "https://orders.internal"}]
+security: [{bearer: []}]
+tags: [{name: orders}]
+paths:
+  /users/{id}:
+    delete:
+      tags: [orders]
+      responses: {"204": {description: Deleted}}
+  /health:
+    get:
+      responses: {"200": {description: OK}}
+  /orders:
+    get:
+      tags: [orders]
+      responses:
+        "200": {description: OK, content: {application/json: {schema: {$ref: "#/components/schemas/Order"}}}}
+        default: {$ref: "#/components/responses/Error"}
+components:
+  schemas:
+    Order: {type: object, properties: {buyer: {$ref: "#/components/schemas/User"}}}
+    User: {type: object, properties: {address: {$ref: "#/components/schemas/Address"}}}
+    Address: {type: object, properties: {zip: {type: string}}}
+    Problem: {type: object}
+  responses:
+    Error: {description: Error, content: {application/json: {schema: {$ref: "#/components/schemas/Problem"}}}}
+  securitySchemes:
+    bearer: {type: apiKey, in: header, name: X-Token}
+`
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	spec, report, err := Merge(
+		MergeSource{Name: "users", Spec: mustParse(t, usersService)},
+		MergeSource{Name: "orders", Spec: mustParse(t, ordersService), TagPrefix: "shop-"},
+	)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	doc := spec.(*openapi.OpenAPI)
+
+	if doc.Info.Title != "Users" {
+		t.Errorf("info.title = %q, want the first source's", doc.Info.Title)
+	}
+	if got := sortedKeys(doc.Components.Schemas); !reflect.DeepEqual(got, []string{"Address", "Order", "Problem", "User", "orders_Address", "orders_User"}) {
+		t.Errorf("schemas = %v", got)
+	}
+	if got := sortedKeys(doc.Components.Responses); !reflect.DeepEqual(got, []string{"Error"}) {
+		t.Errorf("responses = %v, want the shared Error", got)
+	}
+	if ref := doc.Components.Schemas["Order"].Properties["buyer"].Ref; ref != "#/components/schemas/orders_User" {
+		t.Errorf("Order.buyer $ref = %q", ref)
+	}
+	if ref := doc.Components.Schemas["orders_User"].Properties["address"].Ref; ref != "#/components/schemas/orders_Address" {
+		t.Errorf("orders_User.address $ref = %q", ref)
+	}
+	if doc.Components.SecuritySchemes["orders_bearer"] == nil {
+		t.Errorf("securitySchemes = %v, want orders_bearer", sortedKeys(doc.Components.SecuritySchemes))
+	}
+
+	// Security differs per source, so it moves onto the operations
+	if len(doc.Security) != 0 {
+		t.Errorf("root security = %v, want none", doc.Security)
+	}
+	orders := doc.Paths["/orders"].Get
+	if _, ok := orders.Security[0]["orders_bearer"]; !ok {
+		t.Errorf("GET /orders security = %v, want orders_bearer", orders.Security)
+	}
+	if !reflect.DeepEqual(orders.Tags, []string{"shop-orders"}) {
+		t.Errorf("GET /orders tags = %v", orders.Tags)
+	}
+
+	user := doc.Paths["/users/{id}"]
+	if user.Get == nil || user.Delete == nil || len(user.Parameters) != 0 {
+		t.Fatalf("/users/{id} = %+v, want GET and DELETE without path-level parameters", user)
+	}
+	if len(user.Get.Parameters) != 1 || len(user.Delete.Parameters) != 0 {
+		t.Errorf("path parameter applied to GET %d times and DELETE %d times, want 1 and 0", len(user.Get.Parameters), len(user.Delete.Parameters))
+	}
+
+	var tags []string
+	for _, tag := range doc.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if !reflect.DeepEqual(tags, []string{"users", "shop-orders"}) {
+		t.Errorf("tags = %v", tags)
+	}
+
+	// Servers differ per source, so they move onto the path items, and onto
+	// the operations of a path two sources share
+	if len(doc.Servers) != 0 {
+		t.Errorf("root servers = %v, want none", doc.Servers)
+	}
+	if servers := doc.Paths["/orders"].Servers; len(servers) != 2 || servers[1].URL != "https://orders.internal" {
+		t.Errorf("/orders servers = %v, want the orders servers", servers)
+	}
+	if len(user.Servers) != 0 || len(user.Get.Servers) != 1 || len(user.Delete.Servers) != 2 {
+		t.Errorf("/users/{id} servers: path %v, GET %v, DELETE %v; want each operation to keep its source's servers", user.Servers, user.Get.Servers, user.Delete.Servers)
+	}
+
+	wantConflicts := []MergeConflict{{Source: "orders", Existing: "users", Pointer: "/paths/~1health/get"}}
+	if !reflect.DeepEqual(report.Conflicts, wantConflicts) {
+		t.Errorf("conflicts = %+v, want %+v", report.Conflicts, wantConflicts)
+	}
+	if len(report.Renames) != 3 {
+		t.Errorf("renames = %+v, want Address, User and bearer", report.Renames)
+	}
+}
+
+func TestMergePathPrefix(t *testing.T) {
+	t.Parallel()
+
+	spec, report, err := Merge(
+		MergeSource{Name: "users", Spec: mustParse(t, usersService), PathPrefix: "users/"},
+		MergeSource{Name: "orders", Spec: mustParse(t, ordersService), PathPrefix: "/orders"},
+	)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	doc := spec.(*openapi.OpenAPI)
+
+	want := []string{"/orders/health", "/orders/orders", "/orders/users/{id}", "/users/health", "/users/users/{id}"}
+	if got := sortedKeys(doc.Paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+	if len(report.Conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none", report.Conflicts)
+	}
+}
+
+func TestMergeSharedServers(t *testing.T) {
+	t.Parallel()
+
+	const doc = `openapi: 3.1.0
+info: {title: %s, version: "1"}
+servers: [{url: "https://api.example.com"}]
+paths:
+  /%s:
+    get:
+      responses: {"200": {description: OK}}
+`
+	spec, _, err := Merge(
+		MergeSource{Name: "users", Spec: mustParse(t, fmt.Sprintf(doc, "Users", "users"))},
+		MergeSource{Name: "orders", Spec: mustParse(t, fmt.Sprintf(doc, "Orders", "orders"))},
+	)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	merged := spec.(*openapi.OpenAPI)
+
+	if len(merged.Servers) != 1 || merged.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("root servers = %v, want the shared server", merged.Servers)
+	}
+	for path, item := range merged.Paths {
+		if len(item.Servers) != 0 {
+			t.Errorf("%s servers = %v, want none when the sources agree", path, item.Servers)
+		}
+	}
+}
+
+func TestMergeSwagger(t *testing.T) {
+	t.Parallel()
+
+	a := mustParse(t, `{"swagger":"2.0","info":{"title":"A","version":"1"},"basePath":"/a","schemes":["https"],
+		"paths":{"/items":{"get":{"responses":{"200":{"description":"OK","schema":{"$ref":"#/definitions/Item"}}}}}},
+		"definitions":{"Item":{"type":"object","discriminator":"kind"}}}`)
+	b := mustParse(t, `{"swagger":"2.0","info":{"title":"B","version":"1"},"basePath":"/b","schemes":["http","https"],
+		"paths":{"/items":{"get":{"responses":{"200":{"description":"OK","schema":{"$ref":"#/definitions/Item"}}}}}},
+		"definitions":{"Item":{"type":"string"}}}`)
+
+	spec, report, err := Merge(MergeSource{Name: "a", Spec: a}, MergeSource{Name: "b", Spec: b})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	doc := spec.(*swagger.Swagger)
+
+	if doc.BasePath != "" {
+		t.Errorf("basePath = %q, want it folded into the paths", doc.BasePath)
+	}
+	if ref := doc.Paths["/b/items"].Get.Responses["200"].Schema.Ref; ref != "#/definitions/b_Item" {
+		t.Errorf("/b/items schema $ref = %q", ref)
+	}
+	if doc.Paths["/a/items"] == nil || !reflect.DeepEqual(doc.Schemes, []string{"https", "http"}) {
+		t.Errorf("paths = %v, schemes = %v", sortedKeys(doc.Paths), doc.Schemes)
+	}
+	if len(report.Renames) != 1 || report.Renames[0].Section != "definitions" {
+		t.Errorf("renames = %+v", report.Renames)
+	}
+
+	_, _, err = Merge(MergeSource{Name: "a", Spec: a}, MergeSource{Name: "users", Spec: mustParse(t, usersService)})
+	if err == nil || !strings.Contains(err.Error(), "same version") {
+		t.Errorf("Merge() of 2.0 and 3.1 error = %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Overlay is an OpenAPI Overlay 1.0 document: a list of actions that
+// update or remove the nodes of a specification selected by JSONPath.
+type Overlay struct {
+	Overlay    string                 `json:"overlay"           yaml:"overlay"`           // REQUIRED. Overlay Specification version (1.0.0)
+	Info       OverlayInfo            `json:"info"              yaml:"info"`              // REQUIRED. Metadata about the overlay
+	Extends    string                 `json:"extends,omitempty" yaml:"extends,omitempty"` // URL of the document the overlay is meant for
+	Actions    []OverlayAction        `json:"actions"           yaml:"actions"`           // REQUIRED. Actions, applied in order
+	Extensions map[string]interface{} `json:"-"                 yaml:"-"`                 // Custom extensions (x-*)
+}
+
+// OverlayInfo describes an overlay.
+type OverlayInfo struct {
+	Title   string `json:"title"   yaml:"title"`   // REQUIRED. Overlay title
+	Version string `json:"version" yaml:"version"` // REQUIRED. Overlay version
+}
+
+// OverlayAction updates or removes the nodes its target selects.
+type OverlayAction struct {
+	Target      string      `json:"target"                yaml:"target"`                // REQUIRED. JSONPath selecting objects or arrays
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"` // Description of the action
+	Update      interface{} `json:"update,omitempty"      yaml:"update,omitempty"`      // Object merged into each target, or value appended to it
+	Remove      bool        `json:"remove,omitempty"      yaml:"remove,omitempty"`      // Remove the targets from their parents
+}
+
+// LoadOverlay reads an overlay document in JSON or YAML.
+func LoadOverlay(path string) (*Overlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	overlay, err := ParseOverlay(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return overlay, nil
+}
+
+// ParseOverlay decodes an overlay document in JSON or YAML and checks its
+// version and actions.
+func ParseOverlay(data []byte) (*Overlay, error) {
+	tree, err := decodeTree(data)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlay Overlay
+	if err := json.Unmarshal(encoded, &overlay); err != nil {
+		return nil, fmt.Errorf("invalid overlay: %w", err)
+	}
+	if object, ok := tree.(map[string]interface{}); ok {
+		for key, value := range object {
+			if strings.HasPrefix(key, "x-") {
+				if overlay.Extensions == nil {
+					overlay.Extensions = make(map[string]interface{})
+				}
+				overlay.Extensions[key] = value
+			}
+		}
+	}
+
+	if !strings.HasPrefix(overlay.Overlay, "1.") {
+		if overlay.Overlay == "" {
+			return nil, fmt.Errorf("document has no overlay version field")
+		}
+		return nil, fmt.Errorf("unsupported overlay version %q", overlay.Overlay)
+	}
+	for i, action := range overlay.Actions {
+		if _, err := compileJSONPath(action.Target); err != nil {
+			return nil, fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return &overlay, nil
+}
+
+// Apply runs the actions of the overlay in order against a copy of spec
+// and returns the result, along with a warning for every action whose
+// target selected nothing.
+//
+// An update object is merged into each target object recursively, values
+// other than objects replacing what is there. When the target is an array
+// the update is appended to it, or its elements are when it is an array
+// too. A remove action deletes its targets. Edits the specification model
+// cannot hold are reported as warnings too.
+func (o *Overlay) Apply(spec Specification) (Specification, []string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	for i, action := range o.Actions {
+		query, err := compileJSONPath(action.Target)
+		if err != nil {
+			return nil, nil, fmt.Errorf("action %d: %w", i+1, err)
+		}
+		matches := query.selectFrom(doc)
+		if len(matches) == 0 {
+			warnings = append(warnings, fmt.Sprintf("action %d: target %s selected nothing", i+1, action.Target))
+			continue
+		}
+
+		switch {
+		case action.Remove:
+			doc, err = removeMatches(doc, matches)
+		case action.Update != nil:
+			for _, match := range matches {
+				if doc, err = updateMatch(doc, match, action.Update); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+
+	result, err := specification(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("overlay produced an invalid document: %w", err)
+	}
+
+	// The model drops what it cannot hold, such as empty values of optional
+	// fields, so report the edits that did not survive re-parsing
+	data, err = json.Marshal(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	var parsed interface{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, nil, err
+	}
+	for _, path := range differences(doc, parsed, nil) {
+		warnings = append(warnings, fmt.Sprintf("%s: overlay edit was not kept by the specification model", pathMatch{path: path}.pointer()))
+	}
+	return result, warnings, nil
+}
+
+// differences returns the paths of the outermost nodes where two decoded
+// JSON values differ.
+func differences(a, b interface{}, path []interface{}) [][]interface{} {
+	child := func(token interface{}) []interface{} {
+		return append(append([]interface{}(nil), path...), token)
+	}
+
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := sortedKeys(x)
+		for _, key := range sortedKeys(y) {
+			if _, ok := x[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		var diffs [][]interface{}
+		for _, key := range keys {
+			diffs = append(diffs, differences(x[key], y[key], child(key))...)
+		}
+		return diffs
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			break
+		}
+		var diffs [][]interface{}
+		for i := range x {
+			diffs = append(diffs, differences(x[i], y[i], child(i))...)
+		}
+		return diffs
+	default:
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+	}
+	return [][]interface{}{path}
+}
+
+// updateMatch merges or appends a copy of update into the matched node.
+func updateMatch(doc interface{}, match pathMatch, update interface{}) (interface{}, error) {
+	switch target := match.value.(type) {
+	case map[string]interface{}:
+		object, ok := update.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is an object but the update is not", match.pointer())
+		}
+		mergeObject(target, object)
+		return doc, nil
+	case []interface{}:
+		if items, ok := update.([]interface{}); ok {
+			return setAt(doc, match.path, append(target, cloneTree(items).([]interface{})...))
+		}
+		return setAt(doc, match.path, append(target, cloneTree(update)))
+	}
+	return nil, fmt.Errorf("%s is neither an object nor an array", match.pointer())
+}
+
+// mergeObject merges update into target, recursing into nested objects.
+func mergeObject(target, update map[string]interface{}) {
+	for key, value := range update {
+		nested, isObject := value.(map[string]interface{})
+		existing, hasObject := target[key].(map[string]interface{})
+		if isObject && hasObject {
+			mergeObject(existing, nested)
+			continue
+		}
+		target[key] = cloneTree(value)
+	}
+}
+
+// removeMatches removes the matched nodes from doc. Later array elements
+// and deeper nodes go first so the remaining paths stay valid.
+func removeMatches(doc interface{}, matches []pathMatch) (interface{}, error) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return comparePaths(matches[i].path, matches[j].path) > 0
+	})
+
+	var err error
+	for i, match := range matches {
+		if i > 0 && comparePaths(match.path, matches[i-1].path) == 0 {
+			continue // selected twice, e.g. by a union
+		}
+		n := len(match.path)
+		if n == 0 {
+			return nil, fmt.Errorf("cannot remove the document root")
+		}
+		parent, ok := valueAt(doc, match.path[:n-1])
+		if !ok {
+			continue // inside a node removed already
+		}
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			delete(v, match.path[n-1].(string))
+		case []interface{}:
+			index := match.path[n-1].(int)
+			if index >= len(v) {
+				continue
+			}
+			remaining := append(v[:index:index], v[index+1:]...)
+			if doc, err = setAt(doc, match.path[:n-1], remaining); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return doc, nil
+}
+
+// comparePaths orders paths by their tokens, array indexes numerically.
+func comparePaths(a, b []interface{}) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch x := a[i].(type) {
+		case int:
+			if y, ok := b[i].(int); ok && x != y {
+				return x - y
+			}
+		case string:
+			if y, ok := b[i].(string); ok && x != y {
+				return strings.Compare(x, y)
+			}
+		}
+	}
+	return len(a) - len(b)
+}
+
+// valueAt returns the node at path.
+func valueAt(doc interface{}, path []interface{}) (interface{}, bool) {
+	value := doc
+	for _, token := range path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			key, _ := token.(string)
+			item, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = item
+		case []interface{}:
+			i, ok := token.(int)
+			if !ok || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// setAt replaces the node at path and returns the document, which is the
+// new value when path is empty.
+func setAt(doc interface{}, path []interface{}, value interface{}) (interface{}, error) {
+	n := len(path)
+	if n == 0 {
+		return value, nil
+	}
+	parent, _ := valueAt(doc, path[:n-1])
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[path[n-1].(string)] = value
+	case []interface{}:
+		v[path[n-1].(int)] = value
+	default:
+		return nil, fmt.Errorf("cannot set %s", pathMatch{path: path}.pointer())
+	}
+	return doc, nil
+}
+
+// cloneTree deep-copies a decoded JSON value.
+func cloneTree(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = cloneTree(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = cloneTree(item)
+		}
+		return out
+	}
+	return value
+}
@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+const overlaySpec = `openapi: 3.1.0
+info: {title: Pets, version: "1"}
+tags: [{name: pets}, {name: internal}]
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: debug, in: query, schema: {type: boolean}}
+      responses: {"200": {description: OK}}
+  /admin:
+    post:
+      tags: [internal]
+      responses: {"204": {description: Done}}
+`
+
+func TestOverlayApply(t *testing.T) {
+	t.Parallel()
+
+	overlay, err := ParseOverlay([]byte(`overlay: 1.0.0
+info: {title: Marketing, version: "1"}
+x-owner: docs
+actions:
+  - target: $.info
+    update: {description: The best pet API, contact: {name: Pets team}}
+  - target: $.paths['/pets'].get
+    update: {summary: Browse pets, x-featured: true}
+  - target: $.paths.*[?@.tags[?@ == 'internal']]
+    remove: true
+  - target: $.tags[?@.name == 'internal']
+    remove: true
+  - target: $..parameters[?@.name == 'debug']
+    remove: true
+  - target: $.tags
+    update: [{name: featured}]
+  - target: $.paths['/nope']
+    update: {summary: unused}
+`))
+	if err != nil {
+		t.Fatalf("ParseOverlay() error = %v", err)
+	}
+	if overlay.Extensions["x-owner"] != "docs" || len(overlay.Actions) != 7 {
+		t.Errorf("overlay = %+v", overlay)
+	}
+
+	spec := mustParse(t, overlaySpec)
+	result, warnings, err := overlay.Apply(spec)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	doc := result.(*openapi.OpenAPI)
+
+	if doc.Info.Description != "The best pet API" || doc.Info.Contact == nil || doc.Info.Title != "Pets" {
+		t.Errorf("info = %+v, want the description and contact merged in", doc.Info)
+	}
+	get := doc.Paths["/pets"].Get
+	if get.Summary != "Browse pets" || get.Extensions["x-featured"] != true {
+		t.Errorf("GET /pets summary = %q, extensions = %v", get.Summary, get.Extensions)
+	}
+	if len(get.Parameters) != 1 || get.Parameters[0].Name != "limit" {
+		t.Errorf("GET /pets parameters = %+v, want only limit", get.Parameters)
+	}
+	if item := doc.Paths["/admin"]; item == nil || item.Post != nil {
+		t.Errorf("/admin = %+v, want the internal operation removed", item)
+	}
+	var tags []string
+	for _, tag := range doc.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if !reflect.DeepEqual(tags, []string{"pets", "featured"}) {
+		t.Errorf("tags = %v", tags)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "action 7") {
+		t.Errorf("warnings = %v, want one for action 7", warnings)
+	}
+
+	// The input specification is left untouched
+	if original := spec.(*openapi.OpenAPI); original.Paths["/pets"].Get.Summary != "List pets" || original.Paths["/admin"].Post == nil {
+		t.Error("Apply() modified its input")
+	}
+}
+
+func TestOverlayApplyNestedExtensions(t *testing.T) {
+	t.Parallel()
+
+	overlay, err := ParseOverlay([]byte(`overlay: 1.0.0
+info: {title: Extensions, version: "1"}
+actions:
+  - target: $.components
+    update: {x-internal: true}
+  - target: $.info
+    update: {contact: {name: Core, x-team: core}, license: {name: MIT, x-spdx: true}}
+  - target: $.info
+    update: {summary: ""}
+`))
+	if err != nil {
+		t.Fatalf("ParseOverlay() error = %v", err)
+	}
+
+	spec := mustParse(t, overlaySpec+"components: {schemas: {Pet: {type: object}}}\n")
+	result, warnings, err := overlay.Apply(spec)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	doc := result.(*openapi.OpenAPI)
+
+	if doc.Components.Extensions["x-internal"] != true {
+		t.Errorf("components extensions = %v, want x-internal", doc.Components.Extensions)
+	}
+	if contact := doc.Info.Contact; contact == nil || contact.Extensions["x-team"] != "core" {
+		t.Errorf("info.contact = %+v, want x-team kept", contact)
+	}
+	if license := doc.Info.License; license == nil || license.Extensions["x-spdx"] != true {
+		t.Errorf("info.license = %+v, want x-spdx kept", license)
+	}
+
+	// An empty summary is omitted by the model, so the edit is reported
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "/info/summary") {
+		t.Errorf("warnings = %v, want one for /info/summary", warnings)
+	}
+}
+
+func TestOverlayErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{name: "no version", overlay: `actions: []`, wantErr: "no overlay version"},
+		{name: "unsupported version", overlay: `{"overlay": "2.0.0", "actions": []}`, wantErr: "unsupported overlay version"},
+		{name: "bad target", overlay: "overlay: 1.0.0\nactions: [{target: paths, remove: true}]", wantErr: "action 1"},
+		{name: "scalar target", overlay: "overlay: 1.0.0\nactions: [{target: $.info.title, update: {a: b}}]", wantErr: "neither an object nor an array"},
+		{name: "remove root", overlay: "overlay: 1.0.0\nactions: [{target: $, remove: true}]", wantErr: "document root"},
+	}
+
+	for _, tt := range tests {
+		overlay, err := ParseOverlay([]byte(tt.overlay))
+		if err == nil {
+			_, _, err = overlay.Apply(mustParse(t, overlaySpec))
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"overlay.json": `{"overlay": "1.0.0", "info": {"title": "T", "version": "1"}, "actions": [{"target": "$.info", "update": {"title": "Renamed"}}]}`,
+	})
+	overlay, err := LoadOverlay(filepath.Join(dir, "overlay.json"))
+	if err != nil {
+		t.Fatalf("LoadOverlay() error = %v", err)
+	}
+	result, _, err := overlay.Apply(mustParse(t, overlaySpec))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if title := result.(*openapi.OpenAPI).Info.Title; title != "Renamed" {
+		t.Errorf("info.title = %q, want Renamed", title)
+	}
+
+	if _, err := LoadOverlay(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("LoadOverlay() of a missing file succeeded")
+	}
+}
@@ -0,0 +1,571 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fsvxavier/nexs-swag/pkg/openapi/internal/jsonext"
+)
+
+// Resolver loads documents from disk and follows the $ref values between
+// them. Documents are cached by absolute path for the resolver's lifetime.
+type Resolver struct {
+	documents map[string]interface{}
+}
+
+// NewResolver creates a Resolver with an empty document cache.
+func NewResolver() *Resolver {
+	return &Resolver{documents: make(map[string]interface{})}
+}
+
+// CycleError reports a chain of references that leads back to itself.
+type CycleError struct {
+	Chain []string
+}
+
+func (e *CycleError) Error() string {
+	return "circular $ref: " + strings.Join(e.Chain, " -> ")
+}
+
+// Bundle loads the document at path and moves everything it references in
+// other files into its components (definitions, parameters and responses
+// for Swagger 2.0), leaving only local references.
+func Bundle(path string) (Specification, error) {
+	return NewResolver().Bundle(path)
+}
+
+// Dereference loads the document at path and replaces every reference,
+// local or external, with the value it points to.
+func Dereference(path string) (Specification, error) {
+	return NewResolver().Dereference(path)
+}
+
+// Resolve returns the value ref points to and its canonical location, the
+// absolute file path and JSON pointer joined by "#". File references are
+// resolved relative to the directory of base.
+func (r *Resolver) Resolve(base, ref string) (interface{}, string, error) {
+	file, pointer, err := r.locate(base, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc, err := r.document(file)
+	if err != nil {
+		return nil, "", err
+	}
+	value, err := lookup(doc, pointer)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", ref, err)
+	}
+	return value, file + "#" + pointer, nil
+}
+
+// Bundle returns the document at path with all external references moved
+// into its components. See the package-level Bundle.
+func (r *Resolver) Bundle(path string) (Specification, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := r.document(root)
+	if err != nil {
+		return nil, err
+	}
+	top, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: document is not an object", path)
+	}
+
+	b := &bundler{
+		resolver: r,
+		root:     root,
+		v2:       top["swagger"] != nil,
+		refs:     make(map[string]string),
+		names:    make(map[string]bool),
+		inlining: make(map[string]bool),
+	}
+	for _, section := range b.sections() {
+		for name := range container(top, section) {
+			b.names[section+"/"+name] = true
+		}
+	}
+
+	out, err := b.walk(root, doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	bundled := out.(map[string]interface{})
+	for _, hoisted := range b.hoisted {
+		target := container(bundled, hoisted.section)
+		if target == nil {
+			target = make(map[string]interface{})
+			setContainer(bundled, hoisted.section, target)
+		}
+		target[hoisted.name] = hoisted.value
+	}
+	return specification(bundled)
+}
+
+// Dereference returns the document at path with every reference inlined.
+// See the package-level Dereference.
+func (r *Resolver) Dereference(path string) (Specification, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := r.document(root)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dereferencer{resolver: r, done: make(map[string]interface{})}
+	out, err := d.walk(root, doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	return specification(out)
+}
+
+// locate splits ref into the absolute path of its file and its JSON pointer.
+func (r *Resolver) locate(base, ref string) (string, string, error) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if strings.Contains(file, "://") {
+		return "", "", fmt.Errorf("remote reference %q is not supported", ref)
+	}
+
+	switch {
+	case file == "":
+		file = base
+	case !filepath.IsAbs(file):
+		file = filepath.Join(filepath.Dir(base), filepath.FromSlash(file))
+	}
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return "", "", err
+	}
+
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return "", "", fmt.Errorf("invalid reference %q: fragment is not a JSON pointer", ref)
+	}
+	return file, pointer, nil
+}
+
+// document returns the decoded JSON tree of the file at path.
+func (r *Resolver) document(path string) (interface{}, error) {
+	if doc, ok := r.documents[path]; ok {
+		return doc, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeTree(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.documents[path] = doc
+	return doc, nil
+}
+
+// decodeTree decodes JSON or YAML into the values encoding/json produces.
+func decodeTree(data []byte) (interface{}, error) {
+	data, err := jsonext.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	return doc, nil
+}
+
+// specification re-encodes a JSON tree and parses it into the model.
+func specification(doc interface{}) (Specification, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// lookup evaluates a JSON pointer against a decoded document.
+func lookup(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+
+	value := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			item, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%s not found", pointer)
+			}
+			value = item
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%s not found", pointer)
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("%s not found", pointer)
+		}
+	}
+	return value, nil
+}
+
+// refOf returns the $ref of a reference object.
+func refOf(node map[string]interface{}) (string, bool) {
+	ref, ok := node["$ref"].(string)
+	return ref, ok
+}
+
+// sortedKeys returns the keys of m in order, so output is deterministic.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dereferencer inlines references, caching the inlined value of each
+// location so shared references are only expanded once.
+type dereferencer struct {
+	resolver *Resolver
+	done     map[string]interface{}
+}
+
+func (d *dereferencer) walk(base string, node interface{}, chain []string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := refOf(v); ok {
+			return d.inline(base, ref, v, chain)
+		}
+		out := make(map[string]interface{}, len(v))
+		for _, key := range sortedKeys(v) {
+			item, err := d.walk(base, v[key], chain)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = item
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			walked, err := d.walk(base, item, chain)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = walked
+		}
+		return out, nil
+	}
+	return node, nil
+}
+
+// inline returns the dereferenced target of ref. Keys next to the $ref,
+// such as a description, override those of the target.
+func (d *dereferencer) inline(base, ref string, node map[string]interface{}, chain []string) (interface{}, error) {
+	target, location, err := d.resolver.Resolve(base, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, ok := d.done[location]
+	if !ok {
+		for _, seen := range chain {
+			if seen == location {
+				return nil, &CycleError{Chain: append(append([]string(nil), chain...), location)}
+			}
+		}
+		file, _, _ := strings.Cut(location, "#")
+		resolved, err = d.walk(file, target, append(chain[:len(chain):len(chain)], location))
+		if err != nil {
+			return nil, err
+		}
+		d.done[location] = resolved
+	}
+
+	object, ok := resolved.(map[string]interface{})
+	if !ok || len(node) == 1 {
+		return resolved, nil
+	}
+	merged := make(map[string]interface{}, len(object)+len(node)-1)
+	for key, value := range object {
+		merged[key] = value
+	}
+	for _, key := range sortedKeys(node) {
+		if key == "$ref" {
+			continue
+		}
+		value, err := d.walk(base, node[key], chain)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+// hoistedComponent is an external value moved into the bundled document.
+type hoistedComponent struct {
+	section string
+	name    string
+	value   interface{}
+}
+
+// bundler rewrites external references as local ones, hoisting their
+// targets into the root document's components.
+type bundler struct {
+	resolver *Resolver
+	root     string
+	v2       bool
+	refs     map[string]string // location -> local $ref
+	names    map[string]bool   // "section/name" already in use
+	inlining map[string]bool   // locations being inlined, to catch cycles
+	hoisted  []hoistedComponent
+}
+
+// walk copies node, rewriting its references. path holds the JSON pointer
+// tokens of node in the bundled document and identifies schema positions.
+func (b *bundler) walk(base string, node interface{}, path []string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		if ref, ok := refOf(v); ok {
+			local, err := b.reference(base, ref, path)
+			if err != nil {
+				return nil, err
+			}
+			ref, ok := local.(string)
+			if !ok {
+				return b.mergeSiblings(base, local, v, path)
+			}
+			out["$ref"] = ref
+		}
+		for _, key := range sortedKeys(v) {
+			if _, done := out[key]; done {
+				continue
+			}
+			item, err := b.walk(base, v[key], append(path[:len(path):len(path)], key))
+			if err != nil {
+				return nil, err
+			}
+			out[key] = item
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			walked, err := b.walk(base, item, append(path[:len(path):len(path)], strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = walked
+		}
+		return out, nil
+	}
+	return node, nil
+}
+
+// reference returns the local $ref string that replaces ref or, when the
+// target has no component to live in, the bundled target itself.
+func (b *bundler) reference(base, ref string, path []string) (interface{}, error) {
+	file, pointer, err := b.resolver.locate(base, ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == b.root {
+		if base == b.root {
+			return ref, nil
+		}
+		return "#" + pointer, nil
+	}
+
+	location := file + "#" + pointer
+	if local, ok := b.refs[location]; ok {
+		return local, nil
+	}
+	target, _, err := b.resolver.Resolve(base, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	section, name := b.component(file, pointer, path)
+	if section == "" {
+		if b.inlining[location] {
+			return nil, &CycleError{Chain: []string{location}}
+		}
+		b.inlining[location] = true
+		defer delete(b.inlining, location)
+		return b.walk(file, target, path)
+	}
+
+	name = b.unique(section, name)
+	local := "#/" + section + "/" + name
+	b.refs[location] = local
+
+	componentPath := append(strings.Split(section, "/"), name)
+	value, err := b.walk(file, target, componentPath)
+	if err != nil {
+		return nil, err
+	}
+	b.hoisted = append(b.hoisted, hoistedComponent{section: section, name: name, value: value})
+	return local, nil
+}
+
+// mergeSiblings overlays the keys next to an inlined $ref onto its target.
+func (b *bundler) mergeSiblings(base string, inlined interface{}, node map[string]interface{}, path []string) (interface{}, error) {
+	object, ok := inlined.(map[string]interface{})
+	if !ok || len(node) == 1 {
+		return inlined, nil
+	}
+	for _, key := range sortedKeys(node) {
+		if key == "$ref" {
+			continue
+		}
+		value, err := b.walk(base, node[key], append(path[:len(path):len(path)], key))
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	return object, nil
+}
+
+// sections returns the component sections of the root document.
+func (b *bundler) sections() []string {
+	if b.v2 {
+		return []string{"definitions", "parameters", "responses"}
+	}
+	return []string{
+		"components/schemas", "components/responses", "components/parameters",
+		"components/examples", "components/requestBodies", "components/headers",
+		"components/securitySchemes", "components/links", "components/callbacks",
+		"components/pathItems",
+	}
+}
+
+// schemaSection returns the section schemas are hoisted into.
+func (b *bundler) schemaSection() string {
+	if b.v2 {
+		return "definitions"
+	}
+	return "components/schemas"
+}
+
+// component picks the section and name an external target is hoisted
+// under: the same component when the pointer names one, a schema when the
+// reference sits where a schema is expected, or none to inline it.
+func (b *bundler) component(file, pointer string, path []string) (string, string) {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	if pointer != "" && len(tokens) >= 2 {
+		section := strings.Join(tokens[:len(tokens)-1], "/")
+		for _, known := range b.sections() {
+			if section == known {
+				return section, tokens[len(tokens)-1]
+			}
+		}
+		// A 3.x component referenced from a 2.0 root, or the reverse
+		if len(tokens) == 3 && tokens[0] == "components" || len(tokens) == 2 {
+			if kind := tokens[len(tokens)-2]; kind == "schemas" || kind == "definitions" {
+				return b.schemaSection(), tokens[len(tokens)-1]
+			}
+		}
+	}
+
+	if !isSchemaPosition(path) {
+		return "", ""
+	}
+	if pointer != "" {
+		return b.schemaSection(), tokens[len(tokens)-1]
+	}
+	return b.schemaSection(), strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
+
+// container returns the map holding a section of doc, or nil.
+func container(doc map[string]interface{}, section string) map[string]interface{} {
+	current := doc
+	for _, token := range strings.Split(section, "/") {
+		next, ok := current[token].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// setContainer stores a section map in doc, creating parents as needed.
+func setContainer(doc map[string]interface{}, section string, value map[string]interface{}) {
+	tokens := strings.Split(section, "/")
+	current := doc
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := current[token].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[token] = next
+		}
+		current = next
+	}
+	current[tokens[len(tokens)-1]] = value
+}
+
+// invalidComponentName matches characters not allowed in component names.
+var invalidComponentName = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// unique returns name, sanitized and suffixed until it is free in section.
+func (b *bundler) unique(section, name string) string {
+	name = invalidComponentName.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "Component"
+	}
+	candidate := name
+	for i := 2; b.names[section+"/"+candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	b.names[section+"/"+candidate] = true
+	return candidate
+}
+
+// isSchemaPosition reports whether path points at a schema: the value of a
+// schema keyword, a property, an element of a composition or a component.
+func isSchemaPosition(path []string) bool {
+	n := len(path)
+	if n == 0 {
+		return false
+	}
+	switch path[n-1] {
+	case "schema", "items", "additionalProperties", "not", "contains", "if", "then", "else":
+		return true
+	}
+	if n < 2 {
+		return false
+	}
+	switch path[n-2] {
+	case "properties", "patternProperties", "definitions", "$defs", "dependentSchemas",
+		"allOf", "oneOf", "anyOf", "prefixItems", "schemas":
+		return true
+	}
+	return false
+}
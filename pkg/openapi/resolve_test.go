@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// writeFiles writes name -> content pairs into a temporary directory and
+// returns its path.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const splitRoot = `openapi: 3.1.0
+info:
+  title: Pets
+  version: "1"
+paths:
+  /pets:
+    $ref: paths/pets.yaml
+components:
+  schemas:
+    Error:
+      type: string
+`
+
+var splitFiles = map[string]string{
+	"openapi.yaml": splitRoot,
+	"paths/pets.yaml": `get:
+  responses:
+    200:
+      description: OK
+      content:
+        application/json:
+          schema:
+            $ref: ../schemas/Pet.yaml
+    default:
+      description: Failure
+      content:
+        application/json:
+          schema:
+            $ref: ../common.yaml#/components/schemas/Error
+`,
+	"schemas/Pet.yaml": `type: object
+properties:
+  name:
+    type: string
+  owner:
+    $ref: ../openapi.yaml#/components/schemas/Error
+  tags:
+    type: array
+    items:
+      $ref: '#/definitions/Tag'
+definitions:
+  Tag:
+    type: string
+`,
+	"common.yaml": `components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code:
+          $ref: '#/components/schemas/Code'
+    Code:
+      type: integer
+`,
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, splitFiles)
+	base := filepath.Join(dir, "paths", "pets.yaml")
+	r := NewResolver()
+
+	tests := []struct {
+		name     string
+		ref      string
+		location string
+		wantErr  string
+	}{
+		{name: "external pointer", ref: "../common.yaml#/components/schemas/Code", location: filepath.Join(dir, "common.yaml") + "#/components/schemas/Code"},
+		{name: "whole file", ref: "../schemas/Pet.yaml", location: filepath.Join(dir, "schemas", "Pet.yaml") + "#"},
+		{name: "local", ref: "#/get/responses/200/description", location: base + "#/get/responses/200/description"},
+		{name: "escaped", ref: "../openapi.yaml#/paths/~1pets", location: filepath.Join(dir, "openapi.yaml") + "#/paths/~1pets"},
+		{name: "missing pointer", ref: "../common.yaml#/components/schemas/Nope", wantErr: "not found"},
+		{name: "missing file", ref: "nope.yaml", wantErr: "no such file"},
+		{name: "remote", ref: "https://example.com/a.yaml", wantErr: "not supported"},
+		{name: "anchor", ref: "#Pet", wantErr: "not a JSON pointer"},
+	}
+
+	for _, tt := range tests {
+		_, location, err := r.Resolve(base, tt.ref)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: Resolve() error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Resolve() error = %v", tt.name, err)
+			continue
+		}
+		if location != tt.location {
+			t.Errorf("%s: Resolve() location = %q, want %q", tt.name, location, tt.location)
+		}
+	}
+}
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, splitFiles)
+	spec, err := Bundle(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Bundle() error = %v", err)
+	}
+	doc := spec.(*openapi.OpenAPI)
+
+	schemas := doc.Components.Schemas
+	for _, name := range []string{"Error", "Error2", "Code", "Pet", "Tag"} {
+		if schemas[name] == nil {
+			t.Errorf("components.schemas.%s missing; have %v", name, sortedKeys(toMap(schemas)))
+		}
+	}
+
+	get := doc.Paths["/pets"].Get
+	if get == nil {
+		t.Fatal("path item /pets was not inlined")
+	}
+	refs := map[string]string{
+		"200":     get.Responses["200"].Content["application/json"].Schema.Ref,
+		"default": get.Responses["default"].Content["application/json"].Schema.Ref,
+		"owner":   schemas["Pet"].Properties["owner"].Ref,
+		"tags":    schemas["Pet"].Properties["tags"].Items.Ref,
+		"code":    schemas["Error2"].Properties["code"].Ref,
+	}
+	want := map[string]string{
+		"200":     "#/components/schemas/Pet",
+		"default": "#/components/schemas/Error2",
+		"owner":   "#/components/schemas/Error",
+		"tags":    "#/components/schemas/Tag",
+		"code":    "#/components/schemas/Code",
+	}
+	for key, ref := range want {
+		if refs[key] != ref {
+			t.Errorf("%s $ref = %q, want %q", key, refs[key], ref)
+		}
+	}
+}
+
+func TestBundleSwagger(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"swagger.json": `{"swagger":"2.0","info":{"title":"A","version":"1"},"paths":{"/a":{"get":{
+			"parameters":[{"$ref":"params.json#/parameters/Limit"}],
+			"responses":{"200":{"description":"OK","schema":{"$ref":"models.json#/definitions/Item"}}}}}}}`,
+		"params.json": `{"parameters":{"Limit":{"name":"limit","in":"query","type":"integer"}}}`,
+		"models.json": `{"definitions":{"Item":{"type":"object","properties":{"next":{"$ref":"#/definitions/Item"}}}}}`,
+	})
+
+	spec, err := Bundle(filepath.Join(dir, "swagger.json"))
+	if err != nil {
+		t.Fatalf("Bundle() error = %v", err)
+	}
+	doc := spec.(*swagger.Swagger)
+
+	item := doc.Definitions["Item"]
+	if item == nil || item.Properties["next"].Ref != "#/definitions/Item" {
+		t.Errorf("definitions.Item = %+v, want a self-referencing schema", item)
+	}
+	if doc.Parameters["Limit"] == nil {
+		t.Errorf("parameters = %v, want Limit", doc.Parameters)
+	}
+	if ref := doc.Paths["/a"].Get.Parameters[0].Ref; ref != "#/parameters/Limit" {
+		t.Errorf("parameter $ref = %q, want #/parameters/Limit", ref)
+	}
+}
+
+func TestDereference(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, splitFiles)
+	spec, err := Dereference(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Dereference() error = %v", err)
+	}
+	doc := spec.(*openapi.OpenAPI)
+
+	get := doc.Paths["/pets"].Get
+	pet := get.Responses["200"].Content["application/json"].Schema
+	if pet.Ref != "" || pet.Properties["owner"].Type != "string" || pet.Properties["tags"].Items.Type != "string" {
+		t.Errorf("Pet schema was not fully inlined: %+v", pet)
+	}
+	failure := get.Responses["default"].Content["application/json"].Schema
+	if code := failure.Properties["code"]; code == nil || code.Type != "integer" {
+		t.Errorf("Error.code = %+v, want an inlined integer schema", code)
+	}
+}
+
+func TestDereferenceCycle(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"openapi.yaml": `openapi: 3.0.3
+info: {title: A, version: "1"}
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: node.yaml
+`,
+		"node.yaml": `type: object
+properties:
+  parent:
+    $ref: openapi.yaml#/components/schemas/Node
+`,
+	})
+
+	_, err := Dereference(filepath.Join(dir, "openapi.yaml"))
+	var cycle *CycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Dereference() error = %v, want a CycleError", err)
+	}
+	if len(cycle.Chain) != 3 || cycle.Chain[0] != cycle.Chain[2] {
+		t.Errorf("CycleError.Chain = %v, want a loop through node.yaml", cycle.Chain)
+	}
+
+	// Bundling keeps the cycle as local references
+	spec, err := Bundle(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Bundle() error = %v", err)
+	}
+	schemas := spec.(*openapi.OpenAPI).Components.Schemas
+	if ref := schemas["node"].Properties["parent"].Ref; ref != "#/components/schemas/Node" {
+		t.Errorf("node.parent $ref = %q, want #/components/schemas/Node", ref)
+	}
+}
+
+func toMap(schemas map[string]*openapi.Schema) map[string]interface{} {
+	m := make(map[string]interface{}, len(schemas))
+	for name, schema := range schemas {
+		m[name] = schema
+	}
+	return m
+}
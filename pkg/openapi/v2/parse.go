@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fsvxavier/nexs-swag/pkg/openapi/internal/jsonext"
+)
+
+// Load reads a Swagger 2.0 document in JSON or YAML.
+func Load(path string) (*Swagger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes a Swagger 2.0 document in JSON or YAML. Extensions and
+// fields the model does not declare are kept in Extensions.
+func Parse(data []byte) (*Swagger, error) {
+	data, err := jsonext.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Swagger{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid Swagger 2.0 document: %w", err)
+	}
+	if !strings.HasPrefix(spec.Swagger, "2.") {
+		return nil, fmt.Errorf("unsupported swagger version %q", spec.Swagger)
+	}
+	return spec, nil
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (s *Swagger) UnmarshalJSON(data []byte) error {
+	type Alias Swagger
+	return jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type Alias Info
+	return jsonext.Unmarshal(data, (*Alias)(i), &i.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	type Alias PathItem
+	return jsonext.Unmarshal(data, (*Alias)(p), &p.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type Alias Operation
+	return jsonext.Unmarshal(data, (*Alias)(o), &o.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type Alias Parameter
+	return jsonext.Unmarshal(data, (*Alias)(p), &p.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into
+// Extensions. An additionalProperties object is decoded as a *Schema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type Alias Schema
+	if err := jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions); err != nil {
+		return err
+	}
+
+	switch v := s.AdditionalProperties.(type) {
+	case nil, bool:
+	case map[string]interface{}:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		schema := &Schema{}
+		if err := json.Unmarshal(raw, schema); err != nil {
+			return err
+		}
+		s.AdditionalProperties = schema
+	default:
+		return fmt.Errorf("invalid additionalProperties %v", v)
+	}
+	return nil
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type Alias Response
+	return jsonext.Unmarshal(data, (*Alias)(r), &r.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type Alias SecurityScheme
+	return jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type Alias Tag
+	return jsonext.Unmarshal(data, (*Alias)(t), &t.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type Alias Contact
+	return jsonext.Unmarshal(data, (*Alias)(c), &c.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (l *License) UnmarshalJSON(data []byte) error {
+	type Alias License
+	return jsonext.Unmarshal(data, (*Alias)(l), &l.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (i *Items) UnmarshalJSON(data []byte) error {
+	type Alias Items
+	return jsonext.Unmarshal(data, (*Alias)(i), &i.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type Alias Header
+	return jsonext.Unmarshal(data, (*Alias)(h), &h.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (x *XML) UnmarshalJSON(data []byte) error {
+	type Alias XML
+	return jsonext.Unmarshal(data, (*Alias)(x), &x.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
+	type Alias ExternalDocs
+	return jsonext.Unmarshal(data, (*Alias)(e), &e.Extensions)
+}
@@ -0,0 +1,188 @@
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const parseYAML = `swagger: "2.0"
+x-owner: platform
+info:
+  title: Pets
+  version: "1.0"
+  x-audience: public
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-rate-limit: 100
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+          x-example: 10
+      responses:
+        200:
+          description: OK
+          schema:
+            $ref: '#/definitions/Pets'
+definitions:
+  Pets:
+    type: object
+    x-go-type: Pets
+    additionalProperties:
+      $ref: '#/definitions/Pet'
+  Pet:
+    type: object
+    additionalProperties: true
+securityDefinitions:
+  key:
+    type: apiKey
+    in: header
+    name: X-Key
+    x-internal: true
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(parseYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	op := spec.Paths["/pets"].Get
+	checks := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"document", spec.Extensions["x-owner"], "platform"},
+		{"info", spec.Info.Extensions["x-audience"], "public"},
+		{"operation", op.Extensions["x-rate-limit"], float64(100)},
+		{"parameter", op.Parameters[0].Extensions["x-example"], float64(10)},
+		{"schema", spec.Definitions["Pets"].Extensions["x-go-type"], "Pets"},
+		{"security scheme", spec.SecurityDefinitions["key"].Extensions["x-internal"], true},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s extension = %#v, want %#v", check.name, check.got, check.want)
+		}
+	}
+
+	if additional, ok := spec.Definitions["Pets"].AdditionalProperties.(*Schema); !ok || additional.Ref != "#/definitions/Pet" {
+		t.Errorf("Pets additionalProperties = %#v, want *Schema", spec.Definitions["Pets"].AdditionalProperties)
+	}
+	if additional, ok := spec.Definitions["Pet"].AdditionalProperties.(bool); !ok || !additional {
+		t.Errorf("Pet additionalProperties = %#v, want true", spec.Definitions["Pet"].AdditionalProperties)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	again, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() of re-encoded document error = %v", err)
+	}
+	if !reflect.DeepEqual(spec, again) {
+		t.Errorf("document changed across encode/parse:\n%s", data)
+	}
+
+	if _, err := Parse([]byte(`{"openapi":"3.1.0"}`)); err == nil || !strings.Contains(err.Error(), "unsupported swagger version") {
+		t.Errorf("Parse() of an OpenAPI 3 document error = %v", err)
+	}
+}
+
+const extensionsJSON = `{
+  "swagger": "2.0",
+  "x-root": 1,
+  "info": {
+    "title": "Pets", "version": "1.0", "x-info": 1,
+    "contact": {"name": "Core", "x-team": "core"},
+    "license": {"name": "MIT", "x-spdx": true}
+  },
+  "paths": {
+    "/pets": {
+      "x-path": 1,
+      "get": {
+        "operationId": "listPets", "x-operation": 1,
+        "parameters": [{
+          "name": "ids", "in": "query", "type": "array", "x-parameter": 1,
+          "items": {"type": "integer", "x-items": 1}
+        }],
+        "responses": {
+          "200": {
+            "description": "OK", "x-response": 1,
+            "headers": {"X-Rate": {"type": "integer", "x-header": 1}}
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object", "x-schema": 1,
+      "xml": {"name": "pet", "x-xml": 1},
+      "externalDocs": {"url": "https://docs.example.com", "x-docs": 1}
+    }
+  },
+  "securityDefinitions": {"key": {"type": "apiKey", "in": "header", "name": "X-Key", "x-scheme": 1}},
+  "tags": [{"name": "pets", "x-tag": 1, "externalDocs": {"url": "https://tags.example.com", "x-docs": 1}}],
+  "externalDocs": {"url": "https://example.com", "x-docs": 1}
+}`
+
+func TestParseRoundTripKeepsExtensions(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(extensionsJSON))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(extensionsJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("document changed across load/marshal:\n%s", data)
+	}
+}
+
+func TestMarshalReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"parameter", &Parameter{Ref: "#/parameters/Limit", Extensions: map[string]interface{}{"x-ignored": true}}},
+		{"response", &Response{Ref: "#/responses/Error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var got map[string]interface{}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got["$ref"] == nil {
+				t.Errorf("reference = %s, want a bare $ref", data)
+			}
+		})
+	}
+}
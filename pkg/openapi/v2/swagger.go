@@ -3,8 +3,9 @@
 package v2
 
 import (
-	"encoding/json"
 	"fmt"
+
+	"github.com/fsvxavier/nexs-swag/pkg/openapi/internal/jsonext"
 )
 
 // Swagger represents the root document object of the Swagger 2.0 specification.
@@ -62,53 +63,33 @@ func (s *Swagger) Validate() error {
 // MarshalJSON implements custom JSON marshaling with extensions support.
 func (s *Swagger) MarshalJSON() ([]byte, error) {
 	type Alias Swagger
-	aux := &struct {
-		*Alias
-	}{
-		Alias: (*Alias)(s),
-	}
-
-	data, err := json.Marshal(aux)
-	if err != nil {
-		return nil, err
-	}
-
-	// Merge extensions if any
-	if len(s.Extensions) > 0 {
-		var m map[string]interface{}
-		if err := json.Unmarshal(data, &m); err != nil {
-			return nil, err
-		}
-		for k, v := range s.Extensions {
-			m[k] = v
-		}
-		return json.Marshal(m)
-	}
-
-	return data, nil
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
 }
 
 // Info provides metadata about the API.
 type Info struct {
-	Title          string   `json:"title"                    yaml:"title"`                    // REQUIRED. Application title
-	Description    string   `json:"description,omitempty"    yaml:"description,omitempty"`    // Description (CommonMark syntax)
-	TermsOfService string   `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"` // URL to terms of service
-	Contact        *Contact `json:"contact,omitempty"        yaml:"contact,omitempty"`        // Contact information
-	License        *License `json:"license,omitempty"        yaml:"license,omitempty"`        // License information
-	Version        string   `json:"version"                  yaml:"version"`                  // REQUIRED. API version
+	Title          string                 `json:"title"                    yaml:"title"`                    // REQUIRED. Application title
+	Description    string                 `json:"description,omitempty"    yaml:"description,omitempty"`    // Description (CommonMark syntax)
+	TermsOfService string                 `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"` // URL to terms of service
+	Contact        *Contact               `json:"contact,omitempty"        yaml:"contact,omitempty"`        // Contact information
+	License        *License               `json:"license,omitempty"        yaml:"license,omitempty"`        // License information
+	Version        string                 `json:"version"                  yaml:"version"`                  // REQUIRED. API version
+	Extensions     map[string]interface{} `json:"-"                        yaml:"-"`                        // Custom extensions (x-*)
 }
 
 // Contact information for the API.
 type Contact struct {
-	Name  string `json:"name,omitempty"  yaml:"name,omitempty"`  // Contact name
-	URL   string `json:"url,omitempty"   yaml:"url,omitempty"`   // Contact URL
-	Email string `json:"email,omitempty" yaml:"email,omitempty"` // Contact email
+	Name       string                 `json:"name,omitempty"  yaml:"name,omitempty"`  // Contact name
+	URL        string                 `json:"url,omitempty"   yaml:"url,omitempty"`   // Contact URL
+	Email      string                 `json:"email,omitempty" yaml:"email,omitempty"` // Contact email
+	Extensions map[string]interface{} `json:"-"               yaml:"-"`               // Custom extensions (x-*)
 }
 
 // License information for the API.
 type License struct {
-	Name string `json:"name"           yaml:"name"`          // REQUIRED. License name
-	URL  string `json:"url,omitempty"  yaml:"url,omitempty"` // URL to license
+	Name       string                 `json:"name"           yaml:"name"`          // REQUIRED. License name
+	URL        string                 `json:"url,omitempty"  yaml:"url,omitempty"` // URL to license
+	Extensions map[string]interface{} `json:"-"              yaml:"-"`             // Custom extensions (x-*)
 }
 
 // Paths holds the relative paths to the individual endpoints.
@@ -183,24 +164,25 @@ type Parameter struct {
 
 // Items describes the type of items in an array.
 type Items struct {
-	Type             string        `json:"type,omitempty"             yaml:"type,omitempty"`             // Type: string, number, integer, boolean, array
-	Format           string        `json:"format,omitempty"           yaml:"format,omitempty"`           // Format modifier
-	Items            *Items        `json:"items,omitempty"            yaml:"items,omitempty"`            // Nested items (for nested arrays)
-	CollectionFormat string        `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"` // Collection format
-	Default          interface{}   `json:"default,omitempty"          yaml:"default,omitempty"`          // Default value
-	Maximum          *float64      `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
-	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"` // Exclusive maximum
-	Minimum          *float64      `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
-	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"` // Exclusive minimum
-	MaxLength        *int          `json:"maxLength,omitempty"        yaml:"maxLength,omitempty"`        // Maximum length
-	MinLength        *int          `json:"minLength,omitempty"        yaml:"minLength,omitempty"`        // Minimum length
-	Pattern          string        `json:"pattern,omitempty"          yaml:"pattern,omitempty"`          // Regex pattern
-	MaxItems         *int          `json:"maxItems,omitempty"         yaml:"maxItems,omitempty"`         // Maximum array items
-	MinItems         *int          `json:"minItems,omitempty"         yaml:"minItems,omitempty"`         // Minimum array items
-	UniqueItems      bool          `json:"uniqueItems,omitempty"      yaml:"uniqueItems,omitempty"`      // Unique array items
-	Enum             []interface{} `json:"enum,omitempty"             yaml:"enum,omitempty"`             // Enumeration of values
-	MultipleOf       *float64      `json:"multipleOf,omitempty"       yaml:"multipleOf,omitempty"`       // Multiple of
-	Ref              string        `json:"$ref,omitempty"             yaml:"$ref,omitempty"`             // Reference
+	Type             string                 `json:"type,omitempty"             yaml:"type,omitempty"`             // Type: string, number, integer, boolean, array
+	Format           string                 `json:"format,omitempty"           yaml:"format,omitempty"`           // Format modifier
+	Items            *Items                 `json:"items,omitempty"            yaml:"items,omitempty"`            // Nested items (for nested arrays)
+	CollectionFormat string                 `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"` // Collection format
+	Default          interface{}            `json:"default,omitempty"          yaml:"default,omitempty"`          // Default value
+	Maximum          *float64               `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
+	ExclusiveMaximum bool                   `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"` // Exclusive maximum
+	Minimum          *float64               `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
+	ExclusiveMinimum bool                   `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"` // Exclusive minimum
+	MaxLength        *int                   `json:"maxLength,omitempty"        yaml:"maxLength,omitempty"`        // Maximum length
+	MinLength        *int                   `json:"minLength,omitempty"        yaml:"minLength,omitempty"`        // Minimum length
+	Pattern          string                 `json:"pattern,omitempty"          yaml:"pattern,omitempty"`          // Regex pattern
+	MaxItems         *int                   `json:"maxItems,omitempty"         yaml:"maxItems,omitempty"`         // Maximum array items
+	MinItems         *int                   `json:"minItems,omitempty"         yaml:"minItems,omitempty"`         // Minimum array items
+	UniqueItems      bool                   `json:"uniqueItems,omitempty"      yaml:"uniqueItems,omitempty"`      // Unique array items
+	Enum             []interface{}          `json:"enum,omitempty"             yaml:"enum,omitempty"`             // Enumeration of values
+	MultipleOf       *float64               `json:"multipleOf,omitempty"       yaml:"multipleOf,omitempty"`       // Multiple of
+	Ref              string                 `json:"$ref,omitempty"             yaml:"$ref,omitempty"`             // Reference
+	Extensions       map[string]interface{} `json:"-"                          yaml:"-"`                          // Custom extensions (x-*)
 }
 
 // Schema represents a data type definition (JSON Schema Draft 4 subset).
@@ -249,11 +231,12 @@ type Schema struct {
 
 // XML describes XML representation of a schema.
 type XML struct {
-	Name      string `json:"name,omitempty"      yaml:"name,omitempty"`      // XML element name
-	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"` // XML namespace URI
-	Prefix    string `json:"prefix,omitempty"    yaml:"prefix,omitempty"`    // XML namespace prefix
-	Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"` // Translate to XML attribute
-	Wrapped   bool   `json:"wrapped,omitempty"   yaml:"wrapped,omitempty"`   // Wrap array elements
+	Name       string                 `json:"name,omitempty"      yaml:"name,omitempty"`      // XML element name
+	Namespace  string                 `json:"namespace,omitempty" yaml:"namespace,omitempty"` // XML namespace URI
+	Prefix     string                 `json:"prefix,omitempty"    yaml:"prefix,omitempty"`    // XML namespace prefix
+	Attribute  bool                   `json:"attribute,omitempty" yaml:"attribute,omitempty"` // Translate to XML attribute
+	Wrapped    bool                   `json:"wrapped,omitempty"   yaml:"wrapped,omitempty"`   // Wrap array elements
+	Extensions map[string]interface{} `json:"-"                   yaml:"-"`                   // Custom extensions (x-*)
 }
 
 // Responses is a container for the expected responses of an operation.
@@ -271,24 +254,25 @@ type Response struct {
 
 // Header represents a single HTTP header.
 type Header struct {
-	Description      string        `json:"description,omitempty"      yaml:"description,omitempty"`      // Header description
-	Type             string        `json:"type"                       yaml:"type"`                       // REQUIRED. Type
-	Format           string        `json:"format,omitempty"           yaml:"format,omitempty"`           // Format modifier
-	Items            *Items        `json:"items,omitempty"            yaml:"items,omitempty"`            // Items definition (for type=array)
-	CollectionFormat string        `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"` // Collection format
-	Default          interface{}   `json:"default,omitempty"          yaml:"default,omitempty"`          // Default value
-	Maximum          *float64      `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
-	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"` // Exclusive maximum
-	Minimum          *float64      `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
-	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"` // Exclusive minimum
-	MaxLength        *int          `json:"maxLength,omitempty"        yaml:"maxLength,omitempty"`        // Maximum length
-	MinLength        *int          `json:"minLength,omitempty"        yaml:"minLength,omitempty"`        // Minimum length
-	Pattern          string        `json:"pattern,omitempty"          yaml:"pattern,omitempty"`          // Regex pattern
-	MaxItems         *int          `json:"maxItems,omitempty"         yaml:"maxItems,omitempty"`         // Maximum array items
-	MinItems         *int          `json:"minItems,omitempty"         yaml:"minItems,omitempty"`         // Minimum array items
-	UniqueItems      bool          `json:"uniqueItems,omitempty"      yaml:"uniqueItems,omitempty"`      // Unique array items
-	Enum             []interface{} `json:"enum,omitempty"             yaml:"enum,omitempty"`             // Enumeration of values
-	MultipleOf       *float64      `json:"multipleOf,omitempty"       yaml:"multipleOf,omitempty"`       // Multiple of
+	Description      string                 `json:"description,omitempty"      yaml:"description,omitempty"`      // Header description
+	Type             string                 `json:"type"                       yaml:"type"`                       // REQUIRED. Type
+	Format           string                 `json:"format,omitempty"           yaml:"format,omitempty"`           // Format modifier
+	Items            *Items                 `json:"items,omitempty"            yaml:"items,omitempty"`            // Items definition (for type=array)
+	CollectionFormat string                 `json:"collectionFormat,omitempty" yaml:"collectionFormat,omitempty"` // Collection format
+	Default          interface{}            `json:"default,omitempty"          yaml:"default,omitempty"`          // Default value
+	Maximum          *float64               `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
+	ExclusiveMaximum bool                   `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"` // Exclusive maximum
+	Minimum          *float64               `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
+	ExclusiveMinimum bool                   `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"` // Exclusive minimum
+	MaxLength        *int                   `json:"maxLength,omitempty"        yaml:"maxLength,omitempty"`        // Maximum length
+	MinLength        *int                   `json:"minLength,omitempty"        yaml:"minLength,omitempty"`        // Minimum length
+	Pattern          string                 `json:"pattern,omitempty"          yaml:"pattern,omitempty"`          // Regex pattern
+	MaxItems         *int                   `json:"maxItems,omitempty"         yaml:"maxItems,omitempty"`         // Maximum array items
+	MinItems         *int                   `json:"minItems,omitempty"         yaml:"minItems,omitempty"`         // Minimum array items
+	UniqueItems      bool                   `json:"uniqueItems,omitempty"      yaml:"uniqueItems,omitempty"`      // Unique array items
+	Enum             []interface{}          `json:"enum,omitempty"             yaml:"enum,omitempty"`             // Enumeration of values
+	MultipleOf       *float64               `json:"multipleOf,omitempty"       yaml:"multipleOf,omitempty"`       // Multiple of
+	Extensions       map[string]interface{} `json:"-"                          yaml:"-"`                          // Custom extensions (x-*)
 }
 
 // SecurityScheme defines a security scheme that can be used by operations.
@@ -309,13 +293,168 @@ type SecurityRequirement map[string][]string
 
 // Tag allows adding metadata to a single tag.
 type Tag struct {
-	Name         string        `json:"name"                   yaml:"name"`                   // REQUIRED. Tag name
-	Description  string        `json:"description,omitempty"  yaml:"description,omitempty"`  // Tag description
-	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"` // Additional external documentation
+	Name         string                 `json:"name"                   yaml:"name"`                   // REQUIRED. Tag name
+	Description  string                 `json:"description,omitempty"  yaml:"description,omitempty"`  // Tag description
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"` // Additional external documentation
+	Extensions   map[string]interface{} `json:"-"                      yaml:"-"`                      // Custom extensions (x-*)
 }
 
 // ExternalDocs allows referencing an external resource for extended documentation.
 type ExternalDocs struct {
-	Description string `json:"description,omitempty" yaml:"description,omitempty"` // Documentation description
-	URL         string `json:"url"                   yaml:"url"`                   // REQUIRED. Documentation URL
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Documentation description
+	URL         string                 `json:"url"                   yaml:"url"`                   // REQUIRED. Documentation URL
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (i *Info) MarshalJSON() ([]byte, error) {
+	type Alias Info
+	return jsonext.Marshal((*Alias)(i), i.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (p *PathItem) MarshalJSON() ([]byte, error) {
+	type Alias PathItem
+	return jsonext.Marshal((*Alias)(p), p.Extensions)
+}
+
+// Methods lists the HTTP methods a path item holds operations for, in
+// document order.
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// Operation returns the operation for a lower-case HTTP method, or nil.
+func (p *PathItem) Operation(method string) *Operation {
+	if field := p.operationField(method); field != nil {
+		return *field
+	}
+	return nil
+}
+
+// SetOperation sets the operation for a lower-case HTTP method. It reports
+// false for methods a path item has no field for.
+func (p *PathItem) SetOperation(method string, op *Operation) bool {
+	field := p.operationField(method)
+	if field == nil {
+		return false
+	}
+	*field = op
+	return true
+}
+
+// Operations returns the path item's operations keyed by lower-case method.
+func (p *PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for _, method := range Methods {
+		if op := p.Operation(method); op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+func (p *PathItem) operationField(method string) **Operation {
+	switch method {
+	case "get":
+		return &p.Get
+	case "put":
+		return &p.Put
+	case "post":
+		return &p.Post
+	case "delete":
+		return &p.Delete
+	case "options":
+		return &p.Options
+	case "head":
+		return &p.Head
+	case "patch":
+		return &p.Patch
+	}
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type Alias Operation
+	if o.Security != nil && len(o.Security) == 0 {
+		// An empty list removes the inherited security requirements
+		return jsonext.Marshal(&struct {
+			*Alias
+			Security []SecurityRequirement `json:"security"`
+		}{Alias: (*Alias)(o), Security: o.Security}, o.Extensions)
+	}
+	return jsonext.Marshal((*Alias)(o), o.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+// A reference is written as a bare Reference Object.
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return jsonext.MarshalRef(p.Ref)
+	}
+	type Alias Parameter
+	return jsonext.Marshal((*Alias)(p), p.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type Alias Schema
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+// A reference is written as a bare Reference Object.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return jsonext.MarshalRef(r.Ref)
+	}
+	type Alias Response
+	return jsonext.Marshal((*Alias)(r), r.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (s *SecurityScheme) MarshalJSON() ([]byte, error) {
+	type Alias SecurityScheme
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
+}
+
+// MarshalJSON implements custom JSON marshaling with extensions support.
+func (t *Tag) MarshalJSON() ([]byte, error) {
+	type Alias Tag
+	return jsonext.Marshal((*Alias)(t), t.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (c *Contact) MarshalJSON() ([]byte, error) {
+	type Alias Contact
+	return jsonext.Marshal((*Alias)(c), c.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (l *License) MarshalJSON() ([]byte, error) {
+	type Alias License
+	return jsonext.Marshal((*Alias)(l), l.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (i *Items) MarshalJSON() ([]byte, error) {
+	type Alias Items
+	return jsonext.Marshal((*Alias)(i), i.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (h *Header) MarshalJSON() ([]byte, error) {
+	type Alias Header
+	return jsonext.Marshal((*Alias)(h), h.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (x *XML) MarshalJSON() ([]byte, error) {
+	type Alias XML
+	return jsonext.Marshal((*Alias)(x), x.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (e *ExternalDocs) MarshalJSON() ([]byte, error) {
+	type Alias ExternalDocs
+	return jsonext.Marshal((*Alias)(e), e.Extensions)
 }
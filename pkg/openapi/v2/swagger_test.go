@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -574,3 +575,41 @@ func TestTag(t *testing.T) {
 		t.Errorf("ExternalDocs.URL = %v, want https://example.com/docs", tag.ExternalDocs.URL)
 	}
 }
+
+func TestMarshalJSONExtensions(t *testing.T) {
+	t.Parallel()
+	ext := map[string]interface{}{"x-internal": true}
+
+	spec := &Swagger{
+		Swagger: "2.0",
+		Info:    Info{Title: "API", Version: "1.0.0", Extensions: ext},
+		Tags:    []Tag{{Name: "users", Extensions: ext}},
+		Paths: Paths{
+			"/users": {
+				Get: &Operation{
+					Parameters: []*Parameter{{Name: "id", In: "query", Extensions: ext}},
+					Responses:  Responses{"200": {Description: "OK", Extensions: ext}},
+					Extensions: ext,
+				},
+			},
+		},
+		Definitions: map[string]*Schema{"User": {Type: "object", Extensions: ext}},
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"info":{"title":"API","version":"1.0.0","x-internal":true}`,
+		`"tags":[{"name":"users","x-internal":true}]`,
+		`{"in":"query","name":"id","x-internal":true}`,
+		`"200":{"description":"OK","x-internal":true}`,
+		`"User":{"type":"object","x-internal":true}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON does not contain %s:\n%s", want, data)
+		}
+	}
+}
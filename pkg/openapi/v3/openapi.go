@@ -2,27 +2,32 @@
 package v3
 
 import (
-	"encoding/json"
 	"fmt"
+
+	"github.com/fsvxavier/nexs-swag/pkg/openapi/internal/jsonext"
 )
 
 // OpenAPI represents the root document object of the OpenAPI 3.1.x document.
 // Spec: https://spec.openapis.org/oas/v3.1.0#openapi-object
 type OpenAPI struct {
-	OpenAPI           string                `json:"openapi"                     yaml:"openapi"`                     // REQUIRED. OpenAPI Specification version (3.1.0)
-	Info              Info                  `json:"info"                        yaml:"info"`                        // REQUIRED. Metadata about the API
-	JSONSchemaDialect string                `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"` // Default JSON Schema dialect
-	Servers           []Server              `json:"servers,omitempty"           yaml:"servers,omitempty"`           // Array of Server Objects
-	Paths             Paths                 `json:"paths,omitempty"             yaml:"paths,omitempty"`             // Available paths and operations
-	Webhooks          map[string]*PathItem  `json:"webhooks,omitempty"          yaml:"webhooks,omitempty"`          // Webhooks (new in 3.1)
-	Components        *Components           `json:"components,omitempty"        yaml:"components,omitempty"`        // Reusable components
-	Security          []SecurityRequirement `json:"security,omitempty"          yaml:"security,omitempty"`          // Security requirements
-	Tags              []Tag                 `json:"tags,omitempty"              yaml:"tags,omitempty"`              // List of tags
-	ExternalDocs      *ExternalDocs         `json:"externalDocs,omitempty"      yaml:"externalDocs,omitempty"`      // Additional external documentation
-}
-
-// GetVersion returns the OpenAPI specification version.
+	OpenAPI           string                 `json:"openapi"                     yaml:"openapi"`                     // REQUIRED. OpenAPI Specification version (3.1.0)
+	Info              Info                   `json:"info"                        yaml:"info"`                        // REQUIRED. Metadata about the API
+	JSONSchemaDialect string                 `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"` // Default JSON Schema dialect
+	Servers           []Server               `json:"servers,omitempty"           yaml:"servers,omitempty"`           // Array of Server Objects
+	Paths             Paths                  `json:"paths,omitempty"             yaml:"paths,omitempty"`             // Available paths and operations
+	Webhooks          map[string]*PathItem   `json:"webhooks,omitempty"          yaml:"webhooks,omitempty"`          // Webhooks (new in 3.1)
+	Components        *Components            `json:"components,omitempty"        yaml:"components,omitempty"`        // Reusable components
+	Security          []SecurityRequirement  `json:"security,omitempty"          yaml:"security,omitempty"`          // Security requirements
+	Tags              []Tag                  `json:"tags,omitempty"              yaml:"tags,omitempty"`              // List of tags
+	ExternalDocs      *ExternalDocs          `json:"externalDocs,omitempty"      yaml:"externalDocs,omitempty"`      // Additional external documentation
+	Extensions        map[string]interface{} `json:"-"                           yaml:"-"`                           // Custom extensions (x-*)
+}
+
+// GetVersion returns the OpenAPI specification version, 3.1.0 when unset.
 func (o *OpenAPI) GetVersion() string {
+	if o.OpenAPI != "" {
+		return o.OpenAPI
+	}
 	return "3.1.0"
 }
 
@@ -50,35 +55,38 @@ func (o *OpenAPI) Validate() error {
 	return nil
 }
 
-// MarshalJSON implements custom JSON marshaling.
+// MarshalJSON implements custom JSON marshaling with extensions support.
 func (o *OpenAPI) MarshalJSON() ([]byte, error) {
 	type Alias OpenAPI
-	return json.Marshal((*Alias)(o))
+	return jsonext.Marshal((*Alias)(o), o.Extensions)
 }
 
 // Info provides metadata about the API.
 type Info struct {
-	Title          string   `json:"title"                    yaml:"title"`                    // REQUIRED. Title of the API
-	Summary        string   `json:"summary,omitempty"        yaml:"summary,omitempty"`        // Short summary (new in 3.1)
-	Description    string   `json:"description,omitempty"    yaml:"description,omitempty"`    // Description (CommonMark syntax)
-	TermsOfService string   `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"` // URL to terms of service
-	Contact        *Contact `json:"contact,omitempty"        yaml:"contact,omitempty"`        // Contact information
-	License        *License `json:"license,omitempty"        yaml:"license,omitempty"`        // License information
-	Version        string   `json:"version"                  yaml:"version"`                  // REQUIRED. API version
+	Title          string                 `json:"title"                    yaml:"title"`                    // REQUIRED. Title of the API
+	Summary        string                 `json:"summary,omitempty"        yaml:"summary,omitempty"`        // Short summary (new in 3.1)
+	Description    string                 `json:"description,omitempty"    yaml:"description,omitempty"`    // Description (CommonMark syntax)
+	TermsOfService string                 `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"` // URL to terms of service
+	Contact        *Contact               `json:"contact,omitempty"        yaml:"contact,omitempty"`        // Contact information
+	License        *License               `json:"license,omitempty"        yaml:"license,omitempty"`        // License information
+	Version        string                 `json:"version"                  yaml:"version"`                  // REQUIRED. API version
+	Extensions     map[string]interface{} `json:"-"                        yaml:"-"`                        // Custom extensions (x-*)
 }
 
 // Contact information for the API.
 type Contact struct {
-	Name  string `json:"name,omitempty"  yaml:"name,omitempty"`  // Contact name
-	URL   string `json:"url,omitempty"   yaml:"url,omitempty"`   // Contact URL
-	Email string `json:"email,omitempty" yaml:"email,omitempty"` // Contact email
+	Name       string                 `json:"name,omitempty"  yaml:"name,omitempty"`  // Contact name
+	URL        string                 `json:"url,omitempty"   yaml:"url,omitempty"`   // Contact URL
+	Email      string                 `json:"email,omitempty" yaml:"email,omitempty"` // Contact email
+	Extensions map[string]interface{} `json:"-"               yaml:"-"`               // Custom extensions (x-*)
 }
 
 // License information for the API.
 type License struct {
-	Name       string `json:"name"                 yaml:"name"`                 // REQUIRED. License name
-	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"` // SPDX license identifier (new in 3.1)
-	URL        string `json:"url,omitempty"        yaml:"url,omitempty"`        // URL to license
+	Name       string                 `json:"name"                 yaml:"name"`                 // REQUIRED. License name
+	Identifier string                 `json:"identifier,omitempty" yaml:"identifier,omitempty"` // SPDX license identifier (new in 3.1)
+	URL        string                 `json:"url,omitempty"        yaml:"url,omitempty"`        // URL to license
+	Extensions map[string]interface{} `json:"-"                    yaml:"-"`                    // Custom extensions (x-*)
 }
 
 // Server represents a server.
@@ -86,13 +94,15 @@ type Server struct {
 	URL         string                     `json:"url"                   yaml:"url"`                   // REQUIRED. Server URL
 	Description string                     `json:"description,omitempty" yaml:"description,omitempty"` // Server description
 	Variables   map[string]*ServerVariable `json:"variables,omitempty"   yaml:"variables,omitempty"`   // Server variables
+	Extensions  map[string]interface{}     `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // ServerVariable for server URL template substitution.
 type ServerVariable struct {
-	Enum        []string `json:"enum,omitempty"        yaml:"enum,omitempty"`        // Enumeration of values
-	Default     string   `json:"default"               yaml:"default"`               // REQUIRED. Default value
-	Description string   `json:"description,omitempty" yaml:"description,omitempty"` // Description
+	Enum        []string               `json:"enum,omitempty"        yaml:"enum,omitempty"`        // Enumeration of values
+	Default     string                 `json:"default"               yaml:"default"`               // REQUIRED. Default value
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Description
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // Paths holds the relative paths to the individual endpoints.
@@ -100,20 +110,21 @@ type Paths map[string]*PathItem
 
 // PathItem describes operations available on a single path.
 type PathItem struct {
-	Ref         string      `json:"$ref,omitempty"        yaml:"$ref,omitempty"`        // Reference to another PathItem
-	Summary     string      `json:"summary,omitempty"     yaml:"summary,omitempty"`     // Summary for all operations
-	Description string      `json:"description,omitempty" yaml:"description,omitempty"` // Description for all operations
-	Get         *Operation  `json:"get,omitempty"         yaml:"get,omitempty"`         // GET operation
-	Put         *Operation  `json:"put,omitempty"         yaml:"put,omitempty"`         // PUT operation
-	Post        *Operation  `json:"post,omitempty"        yaml:"post,omitempty"`        // POST operation
-	Delete      *Operation  `json:"delete,omitempty"      yaml:"delete,omitempty"`      // DELETE operation
-	Options     *Operation  `json:"options,omitempty"     yaml:"options,omitempty"`     // OPTIONS operation
-	Head        *Operation  `json:"head,omitempty"        yaml:"head,omitempty"`        // HEAD operation
-	Patch       *Operation  `json:"patch,omitempty"       yaml:"patch,omitempty"`       // PATCH operation
-	Trace       *Operation  `json:"trace,omitempty"       yaml:"trace,omitempty"`       // TRACE operation
-	Query       *Operation  `json:"query,omitempty"       yaml:"query,omitempty"`       // QUERY operation (new in 3.2.0)
-	Servers     []Server    `json:"servers,omitempty"     yaml:"servers,omitempty"`     // Alternative servers
-	Parameters  []Parameter `json:"parameters,omitempty"  yaml:"parameters,omitempty"`  // Common parameters
+	Ref         string                 `json:"$ref,omitempty"        yaml:"$ref,omitempty"`        // Reference to another PathItem
+	Summary     string                 `json:"summary,omitempty"     yaml:"summary,omitempty"`     // Summary for all operations
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Description for all operations
+	Get         *Operation             `json:"get,omitempty"         yaml:"get,omitempty"`         // GET operation
+	Put         *Operation             `json:"put,omitempty"         yaml:"put,omitempty"`         // PUT operation
+	Post        *Operation             `json:"post,omitempty"        yaml:"post,omitempty"`        // POST operation
+	Delete      *Operation             `json:"delete,omitempty"      yaml:"delete,omitempty"`      // DELETE operation
+	Options     *Operation             `json:"options,omitempty"     yaml:"options,omitempty"`     // OPTIONS operation
+	Head        *Operation             `json:"head,omitempty"        yaml:"head,omitempty"`        // HEAD operation
+	Patch       *Operation             `json:"patch,omitempty"       yaml:"patch,omitempty"`       // PATCH operation
+	Trace       *Operation             `json:"trace,omitempty"       yaml:"trace,omitempty"`       // TRACE operation
+	Query       *Operation             `json:"query,omitempty"       yaml:"query,omitempty"`       // QUERY operation (new in 3.2.0)
+	Servers     []Server               `json:"servers,omitempty"     yaml:"servers,omitempty"`     // Alternative servers
+	Parameters  []Parameter            `json:"parameters,omitempty"  yaml:"parameters,omitempty"`  // Common parameters
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // Operation describes a single API operation on a path.
@@ -135,41 +146,47 @@ type Operation struct {
 
 // Parameter describes a single operation parameter.
 type Parameter struct {
-	Name            string              `json:"name"                      yaml:"name"`                      // REQUIRED. Parameter name
-	In              string              `json:"in"                        yaml:"in"`                        // REQUIRED. Location: query, header, path, cookie
-	Description     string              `json:"description,omitempty"     yaml:"description,omitempty"`     // Parameter description
-	Required        bool                `json:"required,omitempty"        yaml:"required,omitempty"`        // Required (true for path parameters)
-	Deprecated      bool                `json:"deprecated,omitempty"      yaml:"deprecated,omitempty"`      // Parameter is deprecated
-	AllowEmptyValue bool                `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"` // Allow empty value
-	Schema          *Schema             `json:"schema,omitempty"          yaml:"schema,omitempty"`          // Parameter schema
-	Example         interface{}         `json:"example,omitempty"         yaml:"example,omitempty"`         // Example value
-	Examples        map[string]*Example `json:"examples,omitempty"        yaml:"examples,omitempty"`        // Multiple examples
+	Ref             string                 `json:"$ref,omitempty"            yaml:"$ref,omitempty"`            // Reference to a component parameter
+	Name            string                 `json:"name"                      yaml:"name"`                      // REQUIRED. Parameter name
+	In              string                 `json:"in"                        yaml:"in"`                        // REQUIRED. Location: query, header, path, cookie
+	Description     string                 `json:"description,omitempty"     yaml:"description,omitempty"`     // Parameter description
+	Required        bool                   `json:"required,omitempty"        yaml:"required,omitempty"`        // Required (true for path parameters)
+	Deprecated      bool                   `json:"deprecated,omitempty"      yaml:"deprecated,omitempty"`      // Parameter is deprecated
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"` // Allow empty value
+	Schema          *Schema                `json:"schema,omitempty"          yaml:"schema,omitempty"`          // Parameter schema
+	Example         interface{}            `json:"example,omitempty"         yaml:"example,omitempty"`         // Example value
+	Examples        map[string]*Example    `json:"examples,omitempty"        yaml:"examples,omitempty"`        // Multiple examples
+	Extensions      map[string]interface{} `json:"-"                         yaml:"-"`                         // Custom extensions (x-*)
 }
 
 // RequestBody describes a single request body.
 type RequestBody struct {
-	Description string                `json:"description,omitempty" yaml:"description,omitempty"` // Description
-	Content     map[string]*MediaType `json:"content"               yaml:"content"`               // REQUIRED. Content (MIME types)
-	Required    bool                  `json:"required,omitempty"    yaml:"required,omitempty"`    // Request body is required
+	Ref         string                 `json:"$ref,omitempty"        yaml:"$ref,omitempty"`        // Reference to a component request body
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Description
+	Content     map[string]*MediaType  `json:"content"               yaml:"content"`               // REQUIRED. Content (MIME types)
+	Required    bool                   `json:"required,omitempty"    yaml:"required,omitempty"`    // Request body is required
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // MediaType provides schema and examples for the media type.
 type MediaType struct {
-	Schema       *Schema              `json:"schema,omitempty"       yaml:"schema,omitempty"`       // Schema
-	Example      interface{}          `json:"example,omitempty"      yaml:"example,omitempty"`      // Example value
-	Examples     map[string]*Example  `json:"examples,omitempty"     yaml:"examples,omitempty"`     // Multiple examples
-	Encoding     map[string]*Encoding `json:"encoding,omitempty"     yaml:"encoding,omitempty"`     // Encoding for multipart
-	ItemSchema   *Schema              `json:"itemSchema,omitempty"   yaml:"itemSchema,omitempty"`   // Schema for streaming items (new in 3.2.0)
-	ItemEncoding map[string]*Encoding `json:"itemEncoding,omitempty" yaml:"itemEncoding,omitempty"` // Encoding for streaming items (new in 3.2.0)
+	Schema       *Schema                `json:"schema,omitempty"       yaml:"schema,omitempty"`       // Schema
+	Example      interface{}            `json:"example,omitempty"      yaml:"example,omitempty"`      // Example value
+	Examples     map[string]*Example    `json:"examples,omitempty"     yaml:"examples,omitempty"`     // Multiple examples
+	Encoding     map[string]*Encoding   `json:"encoding,omitempty"     yaml:"encoding,omitempty"`     // Encoding for multipart
+	ItemSchema   *Schema                `json:"itemSchema,omitempty"   yaml:"itemSchema,omitempty"`   // Schema for streaming items (new in 3.2.0)
+	ItemEncoding map[string]*Encoding   `json:"itemEncoding,omitempty" yaml:"itemEncoding,omitempty"` // Encoding for streaming items (new in 3.2.0)
+	Extensions   map[string]interface{} `json:"-"                      yaml:"-"`                      // Custom extensions (x-*)
 }
 
 // Encoding for request body properties.
 type Encoding struct {
-	ContentType   string             `json:"contentType,omitempty"   yaml:"contentType,omitempty"`   // Content-Type
-	Headers       map[string]*Header `json:"headers,omitempty"       yaml:"headers,omitempty"`       // Headers
-	Style         string             `json:"style,omitempty"         yaml:"style,omitempty"`         // Serialization style
-	Explode       bool               `json:"explode,omitempty"       yaml:"explode,omitempty"`       // Explode parameter
-	AllowReserved bool               `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"` // Allow reserved characters
+	ContentType   string                 `json:"contentType,omitempty"   yaml:"contentType,omitempty"`   // Content-Type
+	Headers       map[string]*Header     `json:"headers,omitempty"       yaml:"headers,omitempty"`       // Headers
+	Style         string                 `json:"style,omitempty"         yaml:"style,omitempty"`         // Serialization style
+	Explode       bool                   `json:"explode,omitempty"       yaml:"explode,omitempty"`       // Explode parameter
+	AllowReserved bool                   `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"` // Allow reserved characters
+	Extensions    map[string]interface{} `json:"-"                       yaml:"-"`                       // Custom extensions (x-*)
 }
 
 // Responses container for the expected responses of an operation.
@@ -177,28 +194,33 @@ type Responses map[string]*Response
 
 // Response describes a single response from an API operation.
 type Response struct {
-	Description string                `json:"description"       yaml:"description"`       // REQUIRED. Response description
-	Headers     map[string]*Header    `json:"headers,omitempty" yaml:"headers,omitempty"` // Response headers
-	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"` // Response content
-	Links       map[string]*Link      `json:"links,omitempty"   yaml:"links,omitempty"`   // Links to other operations
+	Ref         string                 `json:"$ref,omitempty"    yaml:"$ref,omitempty"`    // Reference to a component response
+	Description string                 `json:"description"       yaml:"description"`       // REQUIRED. Response description
+	Headers     map[string]*Header     `json:"headers,omitempty" yaml:"headers,omitempty"` // Response headers
+	Content     map[string]*MediaType  `json:"content,omitempty" yaml:"content,omitempty"` // Response content
+	Links       map[string]*Link       `json:"links,omitempty"   yaml:"links,omitempty"`   // Links to other operations
+	Extensions  map[string]interface{} `json:"-"                 yaml:"-"`                 // Custom extensions (x-*)
 }
 
 // Header describes a single header.
 type Header struct {
-	Description string              `json:"description,omitempty" yaml:"description,omitempty"` // Header description
-	Required    bool                `json:"required,omitempty"    yaml:"required,omitempty"`    // Header is required
-	Deprecated  bool                `json:"deprecated,omitempty"  yaml:"deprecated,omitempty"`  // Header is deprecated
-	Schema      *Schema             `json:"schema,omitempty"      yaml:"schema,omitempty"`      // Header schema
-	Example     interface{}         `json:"example,omitempty"     yaml:"example,omitempty"`     // Example value
-	Examples    map[string]*Example `json:"examples,omitempty"    yaml:"examples,omitempty"`    // Multiple examples
+	Ref         string                 `json:"$ref,omitempty"        yaml:"$ref,omitempty"`        // Reference to a component header
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Header description
+	Required    bool                   `json:"required,omitempty"    yaml:"required,omitempty"`    // Header is required
+	Deprecated  bool                   `json:"deprecated,omitempty"  yaml:"deprecated,omitempty"`  // Header is deprecated
+	Schema      *Schema                `json:"schema,omitempty"      yaml:"schema,omitempty"`      // Header schema
+	Example     interface{}            `json:"example,omitempty"     yaml:"example,omitempty"`     // Example value
+	Examples    map[string]*Example    `json:"examples,omitempty"    yaml:"examples,omitempty"`    // Multiple examples
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // Example for parameter, request body, or response.
 type Example struct {
-	Summary       string      `json:"summary,omitempty"       yaml:"summary,omitempty"`       // Short description
-	Description   string      `json:"description,omitempty"   yaml:"description,omitempty"`   // Long description
-	Value         interface{} `json:"value,omitempty"         yaml:"value,omitempty"`         // Embedded example value
-	ExternalValue string      `json:"externalValue,omitempty" yaml:"externalValue,omitempty"` // URL to external example
+	Summary       string                 `json:"summary,omitempty"       yaml:"summary,omitempty"`       // Short description
+	Description   string                 `json:"description,omitempty"   yaml:"description,omitempty"`   // Long description
+	Value         interface{}            `json:"value,omitempty"         yaml:"value,omitempty"`         // Embedded example value
+	ExternalValue string                 `json:"externalValue,omitempty" yaml:"externalValue,omitempty"` // URL to external example
+	Extensions    map[string]interface{} `json:"-"                       yaml:"-"`                       // Custom extensions (x-*)
 }
 
 // Link represents a possible design-time link for a response.
@@ -209,6 +231,7 @@ type Link struct {
 	RequestBody  interface{}            `json:"requestBody,omitempty"  yaml:"requestBody,omitempty"`  // Request body value
 	Description  string                 `json:"description,omitempty"  yaml:"description,omitempty"`  // Description
 	Server       *Server                `json:"server,omitempty"       yaml:"server,omitempty"`       // Server object
+	Extensions   map[string]interface{} `json:"-"                      yaml:"-"`                      // Custom extensions (x-*)
 }
 
 // Callback represents a callback request.
@@ -227,9 +250,9 @@ type Schema struct {
 
 	// Number validation
 	MultipleOf       float64     `json:"multipleOf,omitempty"       yaml:"multipleOf,omitempty"`       // Multiple of
-	Maximum          float64     `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
+	Maximum          *float64    `json:"maximum,omitempty"          yaml:"maximum,omitempty"`          // Maximum value
 	ExclusiveMaximum interface{} `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"` // Exclusive maximum
-	Minimum          float64     `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
+	Minimum          *float64    `json:"minimum,omitempty"          yaml:"minimum,omitempty"`          // Minimum value
 	ExclusiveMinimum interface{} `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"` // Exclusive minimum
 
 	// String validation
@@ -265,6 +288,7 @@ type Schema struct {
 	XML           *XML                   `json:"xml,omitempty"           yaml:"xml,omitempty"`           // XML representation
 	ExternalDocs  *ExternalDocs          `json:"externalDocs,omitempty"  yaml:"externalDocs,omitempty"`  // External documentation
 	Example       interface{}            `json:"example,omitempty"       yaml:"example,omitempty"`       // Example value (deprecated, use examples)
+	Examples      []interface{}          `json:"examples,omitempty"      yaml:"examples,omitempty"`      // Example values (JSON Schema 2020-12)
 	Deprecated    bool                   `json:"deprecated,omitempty"    yaml:"deprecated,omitempty"`    // Deprecated
 	Extensions    map[string]interface{} `json:"-"                       yaml:"-"`                       // Custom extensions (x-*)
 
@@ -274,17 +298,20 @@ type Schema struct {
 
 // Discriminator for polymorphism.
 type Discriminator struct {
-	PropertyName string            `json:"propertyName"      yaml:"propertyName"`      // REQUIRED. Property name
-	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"` // Mapping of values to schemas
+	PropertyName string                 `json:"propertyName"      yaml:"propertyName"`      // REQUIRED. Property name
+	Mapping      map[string]string      `json:"mapping,omitempty" yaml:"mapping,omitempty"` // Mapping of values to schemas
+	Extensions   map[string]interface{} `json:"-"                 yaml:"-"`                 // Custom extensions (x-*)
 }
 
 // XML representation hints.
 type XML struct {
-	Name      string `json:"name,omitempty"      yaml:"name,omitempty"`      // XML element name
-	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"` // XML namespace URI
-	Prefix    string `json:"prefix,omitempty"    yaml:"prefix,omitempty"`    // XML namespace prefix
-	Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"` // XML attribute
-	Wrapped   bool   `json:"wrapped,omitempty"   yaml:"wrapped,omitempty"`   // XML wrapped
+	Name       string                 `json:"name,omitempty"      yaml:"name,omitempty"`      // XML element name
+	Namespace  string                 `json:"namespace,omitempty" yaml:"namespace,omitempty"` // XML namespace URI
+	Prefix     string                 `json:"prefix,omitempty"    yaml:"prefix,omitempty"`    // XML namespace prefix
+	Attribute  bool                   `json:"attribute,omitempty" yaml:"attribute,omitempty"` // XML attribute
+	Wrapped    bool                   `json:"wrapped,omitempty"   yaml:"wrapped,omitempty"`   // XML wrapped
+	NodeType   string                 `json:"nodeType,omitempty"  yaml:"nodeType,omitempty"`  // Node type: element, attribute, text, cdata, none (new in 3.2.0)
+	Extensions map[string]interface{} `json:"-"                   yaml:"-"`                   // Custom extensions (x-*)
 }
 
 // Components holds reusable objects.
@@ -299,37 +326,41 @@ type Components struct {
 	Links           map[string]*Link           `json:"links,omitempty"           yaml:"links,omitempty"`           // Reusable links
 	Callbacks       map[string]*Callback       `json:"callbacks,omitempty"       yaml:"callbacks,omitempty"`       // Reusable callbacks
 	PathItems       map[string]*PathItem       `json:"pathItems,omitempty"       yaml:"pathItems,omitempty"`       // Reusable path items (new in 3.1)
+	Extensions      map[string]interface{}     `json:"-"                         yaml:"-"`                         // Custom extensions (x-*)
 }
 
 // SecurityScheme defines a security scheme.
 type SecurityScheme struct {
-	Type              string      `json:"type"                       yaml:"type"`                         // REQUIRED. Type: apiKey, http, oauth2, openIdConnect, mutualTLS
-	Description       string      `json:"description,omitempty"      yaml:"description,omitempty"`        // Description
-	Name              string      `json:"name,omitempty"             yaml:"name,omitempty"`               // Name (for apiKey)
-	In                string      `json:"in,omitempty"               yaml:"in,omitempty"`                 // Location (for apiKey): query, header, cookie
-	Scheme            string      `json:"scheme,omitempty"           yaml:"scheme,omitempty"`             // HTTP scheme (for http): basic, bearer, etc.
-	BearerFormat      string      `json:"bearerFormat,omitempty"     yaml:"bearerFormat,omitempty"`       // Bearer token format (for http bearer)
-	Flows             *OAuthFlows `json:"flows,omitempty"            yaml:"flows,omitempty"`              // OAuth flows (for oauth2)
-	OpenIDConnectURL  string      `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`   // OpenID Connect URL (for openIdConnect)
-	Deprecated        bool        `json:"deprecated,omitempty"       yaml:"deprecated,omitempty"`         // Deprecated (new in 3.2.0)
-	OAuth2MetadataURL string      `json:"oauth2MetadataUrl,omitempty" yaml:"oauth2MetadataUrl,omitempty"` // OAuth2 metadata URL (new in 3.2.0)
+	Type              string                 `json:"type"                       yaml:"type"`                         // REQUIRED. Type: apiKey, http, oauth2, openIdConnect, mutualTLS
+	Description       string                 `json:"description,omitempty"      yaml:"description,omitempty"`        // Description
+	Name              string                 `json:"name,omitempty"             yaml:"name,omitempty"`               // Name (for apiKey)
+	In                string                 `json:"in,omitempty"               yaml:"in,omitempty"`                 // Location (for apiKey): query, header, cookie
+	Scheme            string                 `json:"scheme,omitempty"           yaml:"scheme,omitempty"`             // HTTP scheme (for http): basic, bearer, etc.
+	BearerFormat      string                 `json:"bearerFormat,omitempty"     yaml:"bearerFormat,omitempty"`       // Bearer token format (for http bearer)
+	Flows             *OAuthFlows            `json:"flows,omitempty"            yaml:"flows,omitempty"`              // OAuth flows (for oauth2)
+	OpenIDConnectURL  string                 `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`   // OpenID Connect URL (for openIdConnect)
+	Deprecated        bool                   `json:"deprecated,omitempty"       yaml:"deprecated,omitempty"`         // Deprecated (new in 3.2.0)
+	OAuth2MetadataURL string                 `json:"oauth2MetadataUrl,omitempty" yaml:"oauth2MetadataUrl,omitempty"` // OAuth2 metadata URL (new in 3.2.0)
+	Extensions        map[string]interface{} `json:"-"                          yaml:"-"`                            // Custom extensions (x-*)
 }
 
 // OAuthFlows configuration for OAuth 2.0.
 type OAuthFlows struct {
-	Implicit            *OAuthFlow `json:"implicit,omitempty"          yaml:"implicit,omitempty"`              // Implicit flow
-	Password            *OAuthFlow `json:"password,omitempty"          yaml:"password,omitempty"`              // Password flow
-	ClientCredentials   *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`     // Client credentials flow
-	AuthorizationCode   *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`     // Authorization code flow
-	DeviceAuthorization *OAuthFlow `json:"deviceAuthorization,omitempty" yaml:"deviceAuthorization,omitempty"` // Device authorization flow (new in 3.2.0)
+	Implicit            *OAuthFlow             `json:"implicit,omitempty"          yaml:"implicit,omitempty"`              // Implicit flow
+	Password            *OAuthFlow             `json:"password,omitempty"          yaml:"password,omitempty"`              // Password flow
+	ClientCredentials   *OAuthFlow             `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`     // Client credentials flow
+	AuthorizationCode   *OAuthFlow             `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`     // Authorization code flow
+	DeviceAuthorization *OAuthFlow             `json:"deviceAuthorization,omitempty" yaml:"deviceAuthorization,omitempty"` // Device authorization flow (new in 3.2.0)
+	Extensions          map[string]interface{} `json:"-"                           yaml:"-"`                               // Custom extensions (x-*)
 }
 
 // OAuthFlow configuration for a single OAuth flow.
 type OAuthFlow struct {
-	AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"` // Authorization URL
-	TokenURL         string            `json:"tokenUrl,omitempty"         yaml:"tokenUrl,omitempty"`         // Token URL
-	RefreshURL       string            `json:"refreshUrl,omitempty"       yaml:"refreshUrl,omitempty"`       // Refresh URL
-	Scopes           map[string]string `json:"scopes"                     yaml:"scopes"`                     // REQUIRED. Available scopes
+	AuthorizationURL string                 `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"` // Authorization URL
+	TokenURL         string                 `json:"tokenUrl,omitempty"         yaml:"tokenUrl,omitempty"`         // Token URL
+	RefreshURL       string                 `json:"refreshUrl,omitempty"       yaml:"refreshUrl,omitempty"`       // Refresh URL
+	Scopes           map[string]string      `json:"scopes"                     yaml:"scopes"`                     // REQUIRED. Available scopes
+	Extensions       map[string]interface{} `json:"-"                          yaml:"-"`                          // Custom extensions (x-*)
 }
 
 // SecurityRequirement lists required security schemes.
@@ -337,73 +368,240 @@ type SecurityRequirement map[string][]string
 
 // Tag for API documentation organization.
 type Tag struct {
-	Name         string        `json:"name"                   yaml:"name"`                   // REQUIRED. Tag name
-	Description  string        `json:"description,omitempty"  yaml:"description,omitempty"`  // Tag description
-	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"` // Additional documentation
+	Name         string                 `json:"name"                   yaml:"name"`                   // REQUIRED. Tag name
+	Description  string                 `json:"description,omitempty"  yaml:"description,omitempty"`  // Tag description
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"` // Additional documentation
+	Extensions   map[string]interface{} `json:"-"                      yaml:"-"`                      // Custom extensions (x-*)
 }
 
 // ExternalDocs references external documentation.
 type ExternalDocs struct {
-	Description string `json:"description,omitempty" yaml:"description,omitempty"` // Description
-	URL         string `json:"url"                   yaml:"url"`                   // REQUIRED. URL
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Description
+	URL         string                 `json:"url"                   yaml:"url"`                   // REQUIRED. URL
+	Extensions  map[string]interface{} `json:"-"                     yaml:"-"`                     // Custom extensions (x-*)
 }
 
 // MarshalJSON customizes JSON encoding to include extensions as top-level fields.
 func (s *Schema) MarshalJSON() ([]byte, error) {
-	// Create a type alias to avoid infinite recursion
 	type Alias Schema
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
+}
 
-	// Marshal the schema normally
-	base, err := json.Marshal((*Alias)(s))
-	if err != nil {
-		return nil, err
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type Alias Operation
+	if o.Security != nil && len(o.Security) == 0 {
+		// An empty list removes the inherited security requirements
+		return jsonext.Marshal(&struct {
+			*Alias
+			Security []SecurityRequirement `json:"security"`
+		}{Alias: (*Alias)(o), Security: o.Security}, o.Extensions)
 	}
+	return jsonext.Marshal((*Alias)(o), o.Extensions)
+}
 
-	// If no extensions, return as is
-	if len(s.Extensions) == 0 {
-		return base, nil
-	}
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (i *Info) MarshalJSON() ([]byte, error) {
+	type Alias Info
+	return jsonext.Marshal((*Alias)(i), i.Extensions)
+}
 
-	// Unmarshal to map to add extensions
-	var result map[string]interface{}
-	if err := json.Unmarshal(base, &result); err != nil {
-		return nil, err
-	}
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (s *Server) MarshalJSON() ([]byte, error) {
+	type Alias Server
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (p *PathItem) MarshalJSON() ([]byte, error) {
+	type Alias PathItem
+	return jsonext.Marshal((*Alias)(p), p.Extensions)
+}
+
+// Methods lists the HTTP methods a path item holds operations for, in
+// document order.
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "query"}
 
-	// Add extensions to top level
-	for k, v := range s.Extensions {
-		result[k] = v
+// Operation returns the operation for a lower-case HTTP method, or nil.
+func (p *PathItem) Operation(method string) *Operation {
+	if field := p.operationField(method); field != nil {
+		return *field
 	}
+	return nil
+}
 
-	return json.Marshal(result)
+// SetOperation sets the operation for a lower-case HTTP method. It reports
+// false for methods a path item has no field for.
+func (p *PathItem) SetOperation(method string, op *Operation) bool {
+	field := p.operationField(method)
+	if field == nil {
+		return false
+	}
+	*field = op
+	return true
 }
 
-// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
-func (o *Operation) MarshalJSON() ([]byte, error) {
-	// Create a type alias to avoid infinite recursion
-	type Alias Operation
+// Operations returns the path item's operations keyed by lower-case method.
+func (p *PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for _, method := range Methods {
+		if op := p.Operation(method); op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+func (p *PathItem) operationField(method string) **Operation {
+	switch method {
+	case "get":
+		return &p.Get
+	case "put":
+		return &p.Put
+	case "post":
+		return &p.Post
+	case "delete":
+		return &p.Delete
+	case "options":
+		return &p.Options
+	case "head":
+		return &p.Head
+	case "patch":
+		return &p.Patch
+	case "trace":
+		return &p.Trace
+	case "query":
+		return &p.Query
+	}
+	return nil
+}
 
-	// Marshal the operation normally
-	base, err := json.Marshal((*Alias)(o))
-	if err != nil {
-		return nil, err
+// MarshalJSON customizes JSON encoding to include extensions as top-level
+// fields. A reference is written as a bare Reference Object.
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return jsonext.MarshalRef(p.Ref)
 	}
+	type Alias Parameter
+	return jsonext.Marshal((*Alias)(p), p.Extensions)
+}
 
-	// If no extensions, return as is
-	if len(o.Extensions) == 0 {
-		return base, nil
+// MarshalJSON customizes JSON encoding to include extensions as top-level
+// fields. A reference is written as a bare Reference Object.
+func (r *RequestBody) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return jsonext.MarshalRef(r.Ref)
 	}
+	type Alias RequestBody
+	return jsonext.Marshal((*Alias)(r), r.Extensions)
+}
 
-	// Unmarshal to map to add extensions
-	var result map[string]interface{}
-	if err := json.Unmarshal(base, &result); err != nil {
-		return nil, err
+// MarshalJSON customizes JSON encoding to include extensions as top-level
+// fields. A reference is written as a bare Reference Object.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return jsonext.MarshalRef(r.Ref)
 	}
+	type Alias Response
+	return jsonext.Marshal((*Alias)(r), r.Extensions)
+}
 
-	// Add extensions to top level
-	for k, v := range o.Extensions {
-		result[k] = v
+// MarshalJSON customizes JSON encoding to include extensions as top-level
+// fields. A reference is written as a bare Reference Object.
+func (h *Header) MarshalJSON() ([]byte, error) {
+	if h.Ref != "" {
+		return jsonext.MarshalRef(h.Ref)
 	}
+	type Alias Header
+	return jsonext.Marshal((*Alias)(h), h.Extensions)
+}
 
-	return json.Marshal(result)
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (t *Tag) MarshalJSON() ([]byte, error) {
+	type Alias Tag
+	return jsonext.Marshal((*Alias)(t), t.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (s *SecurityScheme) MarshalJSON() ([]byte, error) {
+	type Alias SecurityScheme
+	return jsonext.Marshal((*Alias)(s), s.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (c *Contact) MarshalJSON() ([]byte, error) {
+	type Alias Contact
+	return jsonext.Marshal((*Alias)(c), c.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (l *License) MarshalJSON() ([]byte, error) {
+	type Alias License
+	return jsonext.Marshal((*Alias)(l), l.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (v *ServerVariable) MarshalJSON() ([]byte, error) {
+	type Alias ServerVariable
+	return jsonext.Marshal((*Alias)(v), v.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (m *MediaType) MarshalJSON() ([]byte, error) {
+	type Alias MediaType
+	return jsonext.Marshal((*Alias)(m), m.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (e *Encoding) MarshalJSON() ([]byte, error) {
+	type Alias Encoding
+	return jsonext.Marshal((*Alias)(e), e.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (e *Example) MarshalJSON() ([]byte, error) {
+	type Alias Example
+	return jsonext.Marshal((*Alias)(e), e.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (l *Link) MarshalJSON() ([]byte, error) {
+	type Alias Link
+	return jsonext.Marshal((*Alias)(l), l.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (d *Discriminator) MarshalJSON() ([]byte, error) {
+	type Alias Discriminator
+	return jsonext.Marshal((*Alias)(d), d.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (x *XML) MarshalJSON() ([]byte, error) {
+	type Alias XML
+	return jsonext.Marshal((*Alias)(x), x.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (c *Components) MarshalJSON() ([]byte, error) {
+	type Alias Components
+	return jsonext.Marshal((*Alias)(c), c.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (f *OAuthFlows) MarshalJSON() ([]byte, error) {
+	type Alias OAuthFlows
+	return jsonext.Marshal((*Alias)(f), f.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (f *OAuthFlow) MarshalJSON() ([]byte, error) {
+	type Alias OAuthFlow
+	return jsonext.Marshal((*Alias)(f), f.Extensions)
+}
+
+// MarshalJSON customizes JSON encoding to include extensions as top-level fields.
+func (e *ExternalDocs) MarshalJSON() ([]byte, error) {
+	type Alias ExternalDocs
+	return jsonext.Marshal((*Alias)(e), e.Extensions)
 }
@@ -698,16 +698,16 @@ func TestSchemaNumericValidation(t *testing.T) {
 	t.Parallel()
 	schema := &Schema{
 		Type:       "number",
-		Minimum:    0,
-		Maximum:    100,
+		Minimum:    &[]float64{0}[0],
+		Maximum:    &[]float64{100}[0],
 		MultipleOf: 5,
 	}
 
-	if schema.Minimum != 0 {
-		t.Errorf("Schema.Minimum = %f, want 0", schema.Minimum)
+	if schema.Minimum == nil || *schema.Minimum != 0 {
+		t.Errorf("Schema.Minimum = %v, want 0", schema.Minimum)
 	}
-	if schema.Maximum != 100 {
-		t.Errorf("Schema.Maximum = %f, want 100", schema.Maximum)
+	if schema.Maximum == nil || *schema.Maximum != 100 {
+		t.Errorf("Schema.Maximum = %v, want 100", schema.Maximum)
 	}
 	if schema.MultipleOf != 5 {
 		t.Errorf("Schema.MultipleOf = %f, want 5", schema.MultipleOf)
@@ -884,3 +884,98 @@ func TestCompleteOpenAPIStructure(t *testing.T) {
 		t.Errorf("Tags length = %d, want 1", len(api.Tags))
 	}
 }
+
+func TestMarshalJSONExtensions(t *testing.T) {
+	t.Parallel()
+	ext := map[string]interface{}{"x-internal": true}
+
+	spec := &OpenAPI{
+		OpenAPI:    "3.1.0",
+		Info:       Info{Title: "API", Version: "1.0.0", Extensions: ext},
+		Servers:    []Server{{URL: "https://api.example.com", Extensions: ext}},
+		Tags:       []Tag{{Name: "users", Extensions: ext}},
+		Extensions: ext,
+		Paths: Paths{
+			"/users": {
+				Extensions: ext,
+				Get: &Operation{
+					Parameters:  []Parameter{{Name: "id", In: "query", Extensions: ext}},
+					RequestBody: &RequestBody{Content: map[string]*MediaType{}, Extensions: ext},
+					Responses: Responses{
+						"200": {
+							Description: "OK",
+							Headers:     map[string]*Header{"X-Rate": {Extensions: ext}},
+							Extensions:  ext,
+						},
+					},
+				},
+			},
+		},
+		Components: &Components{
+			SecuritySchemes: map[string]*SecurityScheme{"key": {Type: "apiKey", Extensions: ext}},
+		},
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	get := doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})
+	response := get["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	objects := map[string]interface{}{
+		"root":           doc,
+		"info":           doc["info"],
+		"server":         doc["servers"].([]interface{})[0],
+		"tag":            doc["tags"].([]interface{})[0],
+		"path item":      doc["paths"].(map[string]interface{})["/users"],
+		"parameter":      get["parameters"].([]interface{})[0],
+		"request body":   get["requestBody"],
+		"response":       response,
+		"header":         response["headers"].(map[string]interface{})["X-Rate"],
+		"securityScheme": doc["components"].(map[string]interface{})["securitySchemes"].(map[string]interface{})["key"],
+	}
+
+	for name, object := range objects {
+		if object.(map[string]interface{})["x-internal"] != true {
+			t.Errorf("%s is missing x-internal: %v", name, object)
+		}
+	}
+}
+
+func TestPathItemOperationAccessors(t *testing.T) {
+	t.Parallel()
+
+	item := &PathItem{}
+	for _, method := range Methods {
+		if !item.SetOperation(method, &Operation{OperationID: method}) {
+			t.Errorf("SetOperation(%q) = false", method)
+		}
+	}
+	if item.SetOperation("connect", &Operation{}) {
+		t.Error("SetOperation(\"connect\") = true, want false")
+	}
+
+	ops := item.Operations()
+	if len(ops) != len(Methods) {
+		t.Fatalf("Operations() returned %d operations, want %d", len(ops), len(Methods))
+	}
+	for method, op := range ops {
+		if op.OperationID != method {
+			t.Errorf("Operations()[%q].OperationID = %q", method, op.OperationID)
+		}
+	}
+	if item.Query == nil || item.Operation("query") != item.Query {
+		t.Error("query operation not stored in PathItem.Query")
+	}
+
+	item.SetOperation("get", nil)
+	if _, ok := item.Operations()["get"]; ok || item.Get != nil {
+		t.Error("SetOperation(\"get\", nil) did not clear the operation")
+	}
+}
@@ -0,0 +1,225 @@
+package v3
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fsvxavier/nexs-swag/pkg/openapi/internal/jsonext"
+)
+
+// Load reads an OpenAPI 3.x document in JSON or YAML.
+func Load(path string) (*OpenAPI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes an OpenAPI 3.x document in JSON or YAML. Extensions and
+// fields the model does not declare are kept in Extensions.
+func Parse(data []byte) (*OpenAPI, error) {
+	data, err := jsonext.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &OpenAPI{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported openapi version %q", spec.OpenAPI)
+	}
+	return spec, nil
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (o *OpenAPI) UnmarshalJSON(data []byte) error {
+	type Alias OpenAPI
+	return jsonext.Unmarshal(data, (*Alias)(o), &o.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type Alias Info
+	return jsonext.Unmarshal(data, (*Alias)(i), &i.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type Alias Server
+	return jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	type Alias PathItem
+	return jsonext.Unmarshal(data, (*Alias)(p), &p.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type Alias Operation
+	return jsonext.Unmarshal(data, (*Alias)(o), &o.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type Alias Parameter
+	return jsonext.Unmarshal(data, (*Alias)(p), &p.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (r *RequestBody) UnmarshalJSON(data []byte) error {
+	type Alias RequestBody
+	return jsonext.Unmarshal(data, (*Alias)(r), &r.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type Alias Response
+	return jsonext.Unmarshal(data, (*Alias)(r), &r.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type Alias Header
+	return jsonext.Unmarshal(data, (*Alias)(h), &h.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into
+// Extensions. A type list is decoded as []string and an additionalProperties
+// object as a *Schema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type Alias Schema
+	if err := jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions); err != nil {
+		return err
+	}
+
+	if list, ok := s.Type.([]interface{}); ok {
+		types := make([]string, len(list))
+		for i, item := range list {
+			name, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("invalid schema type %v", s.Type)
+			}
+			types[i] = name
+		}
+		s.Type = types
+	}
+
+	switch v := s.AdditionalProperties.(type) {
+	case nil, bool:
+	case map[string]interface{}:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		schema := &Schema{}
+		if err := json.Unmarshal(raw, schema); err != nil {
+			return err
+		}
+		s.AdditionalProperties = schema
+	default:
+		return fmt.Errorf("invalid additionalProperties %v", v)
+	}
+	return nil
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type Alias SecurityScheme
+	return jsonext.Unmarshal(data, (*Alias)(s), &s.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type Alias Tag
+	return jsonext.Unmarshal(data, (*Alias)(t), &t.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type Alias Contact
+	return jsonext.Unmarshal(data, (*Alias)(c), &c.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (l *License) UnmarshalJSON(data []byte) error {
+	type Alias License
+	return jsonext.Unmarshal(data, (*Alias)(l), &l.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (v *ServerVariable) UnmarshalJSON(data []byte) error {
+	type Alias ServerVariable
+	return jsonext.Unmarshal(data, (*Alias)(v), &v.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (m *MediaType) UnmarshalJSON(data []byte) error {
+	type Alias MediaType
+	return jsonext.Unmarshal(data, (*Alias)(m), &m.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (e *Encoding) UnmarshalJSON(data []byte) error {
+	type Alias Encoding
+	return jsonext.Unmarshal(data, (*Alias)(e), &e.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (e *Example) UnmarshalJSON(data []byte) error {
+	type Alias Example
+	return jsonext.Unmarshal(data, (*Alias)(e), &e.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type Alias Link
+	return jsonext.Unmarshal(data, (*Alias)(l), &l.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	type Alias Discriminator
+	return jsonext.Unmarshal(data, (*Alias)(d), &d.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (x *XML) UnmarshalJSON(data []byte) error {
+	type Alias XML
+	return jsonext.Unmarshal(data, (*Alias)(x), &x.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (c *Components) UnmarshalJSON(data []byte) error {
+	type Alias Components
+	return jsonext.Unmarshal(data, (*Alias)(c), &c.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (f *OAuthFlows) UnmarshalJSON(data []byte) error {
+	type Alias OAuthFlows
+	return jsonext.Unmarshal(data, (*Alias)(f), &f.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (f *OAuthFlow) UnmarshalJSON(data []byte) error {
+	type Alias OAuthFlow
+	return jsonext.Unmarshal(data, (*Alias)(f), &f.Extensions)
+}
+
+// UnmarshalJSON reads top-level fields other than the declared ones into Extensions.
+func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
+	type Alias ExternalDocs
+	return jsonext.Unmarshal(data, (*Alias)(e), &e.Extensions)
+}
@@ -0,0 +1,301 @@
+package v3
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const parseYAML = `openapi: 3.1.0
+x-owner: platform
+info:
+  title: Pets
+  version: "1.0"
+  x-audience: public
+servers:
+  - url: https://api.example.com
+    x-region: eu
+paths:
+  /pets:
+    x-path-note: kept
+    get:
+      operationId: listPets
+      x-rate-limit: 100
+      x-unknown-vendor-field: {a: 1}
+      responses:
+        200:
+          description: OK
+          x-cache: true
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+components:
+  schemas:
+    Pets:
+      type: object
+      additionalProperties:
+        $ref: '#/components/schemas/Pet'
+    Pet:
+      type: [string, "null"]
+      x-go-type: Pet
+    Open:
+      type: object
+      additionalProperties: false
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header
+      name: X-Key
+      x-internal: true
+tags:
+  - name: pets
+    x-display: Pets
+futureField: 42
+`
+
+func TestParseExtensionsAndUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(parseYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	op := spec.Paths["/pets"].Get
+	checks := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"document", spec.Extensions["x-owner"], "platform"},
+		{"unknown document field", spec.Extensions["futureField"], float64(42)},
+		{"info", spec.Info.Extensions["x-audience"], "public"},
+		{"server", spec.Servers[0].Extensions["x-region"], "eu"},
+		{"path item", spec.Paths["/pets"].Extensions["x-path-note"], "kept"},
+		{"operation", op.Extensions["x-rate-limit"], float64(100)},
+		{"operation object", op.Extensions["x-unknown-vendor-field"], map[string]interface{}{"a": float64(1)}},
+		{"response", op.Responses["200"].Extensions["x-cache"], true},
+		{"schema", spec.Components.Schemas["Pet"].Extensions["x-go-type"], "Pet"},
+		{"security scheme", spec.Components.SecuritySchemes["key"].Extensions["x-internal"], true},
+		{"tag", spec.Tags[0].Extensions["x-display"], "Pets"},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s extension = %#v, want %#v", check.name, check.got, check.want)
+		}
+	}
+
+	if len(op.Extensions) != 2 || op.OperationID != "listPets" {
+		t.Errorf("declared fields leaked into Extensions: %v", op.Extensions)
+	}
+
+	// Re-encoding writes every preserved field back
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	again, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() of re-encoded document error = %v", err)
+	}
+	if !reflect.DeepEqual(spec, again) {
+		t.Errorf("document changed across encode/parse:\n%s", data)
+	}
+}
+
+func TestParseSchemaShapes(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(parseYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	schemas := spec.Components.Schemas
+
+	if types, ok := schemas["Pet"].Type.([]string); !ok || !reflect.DeepEqual(types, []string{"string", "null"}) {
+		t.Errorf("Pet type = %#v, want []string{string, null}", schemas["Pet"].Type)
+	}
+	if typ, ok := schemas["Pets"].Type.(string); !ok || typ != "object" {
+		t.Errorf("Pets type = %#v, want object", schemas["Pets"].Type)
+	}
+	if additional, ok := schemas["Pets"].AdditionalProperties.(*Schema); !ok || additional.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Pets additionalProperties = %#v, want *Schema", schemas["Pets"].AdditionalProperties)
+	}
+	if additional, ok := schemas["Open"].AdditionalProperties.(bool); !ok || additional {
+		t.Errorf("Open additionalProperties = %#v, want false", schemas["Open"].AdditionalProperties)
+	}
+
+	for _, data := range []string{
+		`{"type":["string",1]}`,
+		`{"additionalProperties":"yes"}`,
+	} {
+		var schema Schema
+		if err := json.Unmarshal([]byte(data), &schema); err == nil {
+			t.Errorf("Unmarshal(%s) should fail", data)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	v2Path := filepath.Join(dir, "swagger.json")
+	if err := os.WriteFile(v2Path, []byte(`{"swagger":"2.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(v2Path); err == nil || !strings.Contains(err.Error(), "unsupported openapi version") {
+		t.Errorf("Load() of a Swagger 2.0 file error = %v", err)
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+	if _, err := Parse([]byte("paths: [")); err == nil || !strings.Contains(err.Error(), "invalid YAML") {
+		t.Errorf("Parse() of invalid YAML error = %v", err)
+	}
+}
+
+const extensionsJSON = `{
+  "openapi": "3.1.0",
+  "x-root": 1,
+  "info": {
+    "title": "Pets", "version": "1.0", "x-info": 1,
+    "contact": {"name": "Core", "x-team": "core"},
+    "license": {"name": "MIT", "x-spdx": true}
+  },
+  "servers": [{
+    "url": "https://{region}.example.com", "x-server": 1,
+    "variables": {"region": {"default": "eu", "x-variable": 1}}
+  }],
+  "paths": {
+    "/pets": {
+      "x-path": 1,
+      "post": {
+        "operationId": "addPet", "x-operation": 1,
+        "parameters": [{"name": "id", "in": "query", "x-parameter": 1}],
+        "requestBody": {
+          "x-body": 1,
+          "content": {
+            "multipart/form-data": {
+              "x-media": 1,
+              "schema": {"$ref": "#/components/schemas/Pet"},
+              "examples": {"one": {"value": {"id": 1}, "x-example": 1}},
+              "encoding": {"photo": {
+                "contentType": "image/png", "x-encoding": 1,
+                "headers": {"X-Rate": {"description": "rate", "x-header": 1}}
+              }}
+            }
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "Created", "x-response": 1,
+            "links": {"self": {"operationId": "addPet", "x-link": 1}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "x-components": 1,
+    "schemas": {
+      "Pet": {
+        "type": "object", "x-schema": 1,
+        "discriminator": {"propertyName": "kind", "x-discriminator": 1},
+        "xml": {"name": "pet", "x-xml": 1},
+        "externalDocs": {"url": "https://docs.example.com", "x-docs": 1}
+      }
+    },
+    "securitySchemes": {
+      "oauth": {
+        "type": "oauth2", "x-scheme": 1,
+        "flows": {
+          "x-flows": 1,
+          "clientCredentials": {"tokenUrl": "https://auth.example.com", "scopes": {}, "x-flow": 1}
+        }
+      }
+    }
+  },
+  "tags": [{"name": "pets", "x-tag": 1, "externalDocs": {"url": "https://tags.example.com", "x-docs": 1}}]
+}`
+
+func TestParseRoundTripKeepsExtensions(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(extensionsJSON))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(extensionsJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("document changed across load/marshal:\n%s", data)
+	}
+}
+
+const referencesJSON = `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0"},
+  "paths": {
+    "/pets": {
+      "post": {
+        "parameters": [{"$ref": "#/components/parameters/Limit"}],
+        "requestBody": {"$ref": "#/components/requestBodies/Pet"},
+        "responses": {
+          "201": {"description": "Created", "headers": {"X-Rate": {"$ref": "#/components/headers/Rate"}}},
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {"Limit": {"name": "limit", "in": "query"}},
+    "requestBodies": {"Pet": {"content": {"application/json": {"schema": {"type": "object"}}}}},
+    "responses": {"Error": {"description": "Error"}},
+    "headers": {"Rate": {"schema": {"type": "integer"}}}
+  }
+}`
+
+func TestParseRoundTripKeepsReferences(t *testing.T) {
+	t.Parallel()
+
+	spec, err := Parse([]byte(referencesJSON))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	post := spec.Paths["/pets"].Post
+	if post.Parameters[0].Ref == "" || post.RequestBody.Ref == "" || post.Responses["default"].Ref == "" {
+		t.Errorf("references not read into Ref fields: %+v", post)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(referencesJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("references changed across load/marshal:\n%s", data)
+	}
+}
@@ -0,0 +1,382 @@
+package openapi
+
+import (
+	"strconv"
+	"strings"
+
+	v2 "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	v3 "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Location identifies a node visited by a walk.
+type Location struct {
+	Pointer string // JSON pointer to the node, e.g. /paths/~1pets/get/responses/200
+	Path    string // Path template, webhook name or callback expression of the enclosing path item
+	Method  string // Lower-case method of the enclosing operation
+	Webhook bool   // The node is under the document's webhooks
+}
+
+// child returns the location of a node below l.
+func (l Location) child(tokens ...string) Location {
+	var b strings.Builder
+	b.WriteString(l.Pointer)
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escapePointerToken(token))
+	}
+	l.Pointer = b.String()
+	return l
+}
+
+// escapePointerToken escapes "~" and "/" in a JSON pointer token.
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// V3Visitor holds the callbacks of WalkV3. Nil callbacks are skipped; the
+// walk descends into every node either way. References are not followed.
+type V3Visitor struct {
+	PathItem    func(Location, *v3.PathItem)
+	Operation   func(Location, *v3.Operation)
+	Parameter   func(Location, *v3.Parameter)
+	RequestBody func(Location, *v3.RequestBody)
+	Response    func(Location, *v3.Response)
+	MediaType   func(Location, *v3.MediaType)
+	Header      func(Location, *v3.Header)
+	Schema      func(Location, *v3.Schema)
+}
+
+// WalkV3 visits the paths, webhooks and components of an OpenAPI 3.x
+// document in document order, sorting map keys.
+func WalkV3(spec *v3.OpenAPI, visitor V3Visitor) {
+	w := v3Walker{visitor}
+	root := Location{}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		loc := root.child("paths", path)
+		loc.Path = path
+		w.pathItem(loc, spec.Paths[path])
+	}
+	for _, name := range sortedKeys(spec.Webhooks) {
+		loc := root.child("webhooks", name)
+		loc.Path, loc.Webhook = name, true
+		w.pathItem(loc, spec.Webhooks[name])
+	}
+
+	components := spec.Components
+	if components == nil {
+		return
+	}
+	for _, name := range sortedKeys(components.Schemas) {
+		w.schema(root.child("components", "schemas", name), components.Schemas[name])
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		w.response(root.child("components", "responses", name), components.Responses[name])
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		w.parameter(root.child("components", "parameters", name), components.Parameters[name])
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		w.requestBody(root.child("components", "requestBodies", name), components.RequestBodies[name])
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		w.header(root.child("components", "headers", name), components.Headers[name])
+	}
+	for _, name := range sortedKeys(components.Callbacks) {
+		w.callback(root.child("components", "callbacks", name), components.Callbacks[name])
+	}
+	for _, name := range sortedKeys(components.PathItems) {
+		w.pathItem(root.child("components", "pathItems", name), components.PathItems[name])
+	}
+}
+
+// WalkV3Operation visits an operation and everything below it.
+func WalkV3Operation(loc Location, op *v3.Operation, visitor V3Visitor) {
+	v3Walker{visitor}.operation(loc, op)
+}
+
+// WalkV3Schema calls fn for schema and each of its nested schemas.
+func WalkV3Schema(loc Location, schema *v3.Schema, fn func(Location, *v3.Schema)) {
+	v3Walker{V3Visitor{Schema: fn}}.schema(loc, schema)
+}
+
+type v3Walker struct {
+	visitor V3Visitor
+}
+
+func (w v3Walker) pathItem(loc Location, item *v3.PathItem) {
+	if item == nil {
+		return
+	}
+	if w.visitor.PathItem != nil {
+		w.visitor.PathItem(loc, item)
+	}
+	for i := range item.Parameters {
+		w.parameter(loc.child("parameters", strconv.Itoa(i)), &item.Parameters[i])
+	}
+	for _, method := range v3.Methods {
+		if op := item.Operation(method); op != nil {
+			opLoc := loc.child(method)
+			opLoc.Method = method
+			w.operation(opLoc, op)
+		}
+	}
+}
+
+func (w v3Walker) operation(loc Location, op *v3.Operation) {
+	if op == nil {
+		return
+	}
+	if w.visitor.Operation != nil {
+		w.visitor.Operation(loc, op)
+	}
+	for i := range op.Parameters {
+		w.parameter(loc.child("parameters", strconv.Itoa(i)), &op.Parameters[i])
+	}
+	w.requestBody(loc.child("requestBody"), op.RequestBody)
+	for _, code := range sortedKeys(op.Responses) {
+		w.response(loc.child("responses", code), op.Responses[code])
+	}
+	for _, name := range sortedKeys(op.Callbacks) {
+		w.callback(loc.child("callbacks", name), op.Callbacks[name])
+	}
+}
+
+func (w v3Walker) callback(loc Location, callback *v3.Callback) {
+	if callback == nil {
+		return
+	}
+	for _, expression := range sortedKeys(*callback) {
+		itemLoc := loc.child(expression)
+		itemLoc.Path, itemLoc.Method = expression, ""
+		w.pathItem(itemLoc, (*callback)[expression])
+	}
+}
+
+func (w v3Walker) parameter(loc Location, param *v3.Parameter) {
+	if param == nil {
+		return
+	}
+	if w.visitor.Parameter != nil {
+		w.visitor.Parameter(loc, param)
+	}
+	w.schema(loc.child("schema"), param.Schema)
+}
+
+func (w v3Walker) requestBody(loc Location, body *v3.RequestBody) {
+	if body == nil {
+		return
+	}
+	if w.visitor.RequestBody != nil {
+		w.visitor.RequestBody(loc, body)
+	}
+	w.content(loc, body.Content)
+}
+
+func (w v3Walker) response(loc Location, resp *v3.Response) {
+	if resp == nil {
+		return
+	}
+	if w.visitor.Response != nil {
+		w.visitor.Response(loc, resp)
+	}
+	for _, name := range sortedKeys(resp.Headers) {
+		w.header(loc.child("headers", name), resp.Headers[name])
+	}
+	w.content(loc, resp.Content)
+}
+
+func (w v3Walker) content(loc Location, content map[string]*v3.MediaType) {
+	for _, contentType := range sortedKeys(content) {
+		w.mediaType(loc.child("content", contentType), content[contentType])
+	}
+}
+
+func (w v3Walker) mediaType(loc Location, mediaType *v3.MediaType) {
+	if mediaType == nil {
+		return
+	}
+	if w.visitor.MediaType != nil {
+		w.visitor.MediaType(loc, mediaType)
+	}
+	w.schema(loc.child("schema"), mediaType.Schema)
+	w.schema(loc.child("itemSchema"), mediaType.ItemSchema)
+	w.encoding(loc.child("encoding"), mediaType.Encoding)
+	w.encoding(loc.child("itemEncoding"), mediaType.ItemEncoding)
+}
+
+func (w v3Walker) encoding(loc Location, encoding map[string]*v3.Encoding) {
+	for _, property := range sortedKeys(encoding) {
+		if encoding[property] == nil {
+			continue
+		}
+		headers := encoding[property].Headers
+		for _, name := range sortedKeys(headers) {
+			w.header(loc.child(property, "headers", name), headers[name])
+		}
+	}
+}
+
+func (w v3Walker) header(loc Location, header *v3.Header) {
+	if header == nil {
+		return
+	}
+	if w.visitor.Header != nil {
+		w.visitor.Header(loc, header)
+	}
+	w.schema(loc.child("schema"), header.Schema)
+}
+
+func (w v3Walker) schema(loc Location, schema *v3.Schema) {
+	if schema == nil {
+		return
+	}
+	if w.visitor.Schema != nil {
+		w.visitor.Schema(loc, schema)
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		w.schema(loc.child("properties", name), schema.Properties[name])
+	}
+	w.schema(loc.child("items"), schema.Items)
+	for i, item := range schema.PrefixItems {
+		w.schema(loc.child("prefixItems", strconv.Itoa(i)), item)
+	}
+	if additional, ok := schema.AdditionalProperties.(*v3.Schema); ok {
+		w.schema(loc.child("additionalProperties"), additional)
+	}
+	for i := range schema.AllOf {
+		w.schema(loc.child("allOf", strconv.Itoa(i)), &schema.AllOf[i])
+	}
+	for i := range schema.OneOf {
+		w.schema(loc.child("oneOf", strconv.Itoa(i)), &schema.OneOf[i])
+	}
+	for i := range schema.AnyOf {
+		w.schema(loc.child("anyOf", strconv.Itoa(i)), &schema.AnyOf[i])
+	}
+	w.schema(loc.child("not"), schema.Not)
+}
+
+// V2Visitor holds the callbacks of WalkV2. Nil callbacks are skipped; the
+// walk descends into every node either way. References are not followed.
+type V2Visitor struct {
+	PathItem  func(Location, *v2.PathItem)
+	Operation func(Location, *v2.Operation)
+	Parameter func(Location, *v2.Parameter)
+	Response  func(Location, *v2.Response)
+	Header    func(Location, *v2.Header)
+	Schema    func(Location, *v2.Schema)
+}
+
+// WalkV2 visits the paths, definitions, parameters and responses of a
+// Swagger 2.0 document in document order, sorting map keys.
+func WalkV2(spec *v2.Swagger, visitor V2Visitor) {
+	w := v2Walker{visitor}
+	root := Location{}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		loc := root.child("paths", path)
+		loc.Path = path
+		w.pathItem(loc, spec.Paths[path])
+	}
+	for _, name := range sortedKeys(spec.Definitions) {
+		w.schema(root.child("definitions", name), spec.Definitions[name])
+	}
+	for _, name := range sortedKeys(spec.Parameters) {
+		w.parameter(root.child("parameters", name), spec.Parameters[name])
+	}
+	for _, name := range sortedKeys(spec.Responses) {
+		w.response(root.child("responses", name), spec.Responses[name])
+	}
+}
+
+// WalkV2Operation visits an operation and everything below it.
+func WalkV2Operation(loc Location, op *v2.Operation, visitor V2Visitor) {
+	v2Walker{visitor}.operation(loc, op)
+}
+
+// WalkV2Schema calls fn for schema and each of its nested schemas.
+func WalkV2Schema(loc Location, schema *v2.Schema, fn func(Location, *v2.Schema)) {
+	v2Walker{V2Visitor{Schema: fn}}.schema(loc, schema)
+}
+
+type v2Walker struct {
+	visitor V2Visitor
+}
+
+func (w v2Walker) pathItem(loc Location, item *v2.PathItem) {
+	if item == nil {
+		return
+	}
+	if w.visitor.PathItem != nil {
+		w.visitor.PathItem(loc, item)
+	}
+	for i, param := range item.Parameters {
+		w.parameter(loc.child("parameters", strconv.Itoa(i)), param)
+	}
+	for _, method := range v2.Methods {
+		if op := item.Operation(method); op != nil {
+			opLoc := loc.child(method)
+			opLoc.Method = method
+			w.operation(opLoc, op)
+		}
+	}
+}
+
+func (w v2Walker) operation(loc Location, op *v2.Operation) {
+	if op == nil {
+		return
+	}
+	if w.visitor.Operation != nil {
+		w.visitor.Operation(loc, op)
+	}
+	for i, param := range op.Parameters {
+		w.parameter(loc.child("parameters", strconv.Itoa(i)), param)
+	}
+	for _, code := range sortedKeys(op.Responses) {
+		w.response(loc.child("responses", code), op.Responses[code])
+	}
+}
+
+func (w v2Walker) parameter(loc Location, param *v2.Parameter) {
+	if param == nil {
+		return
+	}
+	if w.visitor.Parameter != nil {
+		w.visitor.Parameter(loc, param)
+	}
+	w.schema(loc.child("schema"), param.Schema)
+}
+
+func (w v2Walker) response(loc Location, resp *v2.Response) {
+	if resp == nil {
+		return
+	}
+	if w.visitor.Response != nil {
+		w.visitor.Response(loc, resp)
+	}
+	for _, name := range sortedKeys(resp.Headers) {
+		if w.visitor.Header != nil && resp.Headers[name] != nil {
+			w.visitor.Header(loc.child("headers", name), resp.Headers[name])
+		}
+	}
+	w.schema(loc.child("schema"), resp.Schema)
+}
+
+func (w v2Walker) schema(loc Location, schema *v2.Schema) {
+	if schema == nil {
+		return
+	}
+	if w.visitor.Schema != nil {
+		w.visitor.Schema(loc, schema)
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		w.schema(loc.child("properties", name), schema.Properties[name])
+	}
+	w.schema(loc.child("items"), schema.Items)
+	if additional, ok := schema.AdditionalProperties.(*v2.Schema); ok {
+		w.schema(loc.child("additionalProperties"), additional)
+	}
+	for i, item := range schema.AllOf {
+		w.schema(loc.child("allOf", strconv.Itoa(i)), item)
+	}
+}
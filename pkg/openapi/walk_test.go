@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	swagger "github.com/fsvxavier/nexs-swag/pkg/openapi/v2"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestWalkV3(t *testing.T) {
+	t.Parallel()
+
+	jsonContent := func(schema *openapi.Schema) map[string]*openapi.MediaType {
+		return map[string]*openapi.MediaType{"application/json": {Schema: schema}}
+	}
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.2.0",
+		Paths: openapi.Paths{
+			"/pets/{id}": {
+				Parameters: []openapi.Parameter{{Name: "id", In: "path", Schema: &openapi.Schema{Type: "string"}}},
+				Query: &openapi.Operation{
+					RequestBody: &openapi.RequestBody{Content: jsonContent(&openapi.Schema{
+						Properties: map[string]*openapi.Schema{"a/b": {Items: &openapi.Schema{Ref: "#/components/schemas/Tag"}}},
+					})},
+					Responses: openapi.Responses{
+						"200": {
+							Headers: map[string]*openapi.Header{"X-Rate": {Schema: &openapi.Schema{Type: "integer"}}},
+							Content: jsonContent(&openapi.Schema{OneOf: []openapi.Schema{{Ref: "#/components/schemas/Pet"}}}),
+						},
+					},
+					Callbacks: map[string]*openapi.Callback{
+						"done": {"{$request.body#/url}": {Post: &openapi.Operation{RequestBody: &openapi.RequestBody{Content: jsonContent(&openapi.Schema{})}}}},
+					},
+				},
+			},
+		},
+		Webhooks: map[string]*openapi.PathItem{
+			"adopted": {Post: &openapi.Operation{RequestBody: &openapi.RequestBody{Content: jsonContent(&openapi.Schema{Ref: "#/components/schemas/Pet"})}}},
+		},
+		Components: &openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"Pet": {AdditionalProperties: &openapi.Schema{Not: &openapi.Schema{}}},
+			},
+		},
+	}
+
+	var schemas []string
+	var operations []Location
+	mediaTypes, headers, parameters := 0, 0, 0
+	WalkV3(spec, V3Visitor{
+		Operation: func(loc Location, _ *openapi.Operation) { operations = append(operations, loc) },
+		Parameter: func(Location, *openapi.Parameter) { parameters++ },
+		MediaType: func(Location, *openapi.MediaType) { mediaTypes++ },
+		Header:    func(Location, *openapi.Header) { headers++ },
+		Schema:    func(loc Location, _ *openapi.Schema) { schemas = append(schemas, loc.Pointer) },
+	})
+
+	wantSchemas := []string{
+		"/paths/~1pets~1{id}/parameters/0/schema",
+		"/paths/~1pets~1{id}/query/requestBody/content/application~1json/schema",
+		"/paths/~1pets~1{id}/query/requestBody/content/application~1json/schema/properties/a~1b",
+		"/paths/~1pets~1{id}/query/requestBody/content/application~1json/schema/properties/a~1b/items",
+		"/paths/~1pets~1{id}/query/responses/200/headers/X-Rate/schema",
+		"/paths/~1pets~1{id}/query/responses/200/content/application~1json/schema",
+		"/paths/~1pets~1{id}/query/responses/200/content/application~1json/schema/oneOf/0",
+		"/paths/~1pets~1{id}/query/callbacks/done/{$request.body#~1url}/post/requestBody/content/application~1json/schema",
+		"/webhooks/adopted/post/requestBody/content/application~1json/schema",
+		"/components/schemas/Pet",
+		"/components/schemas/Pet/additionalProperties",
+		"/components/schemas/Pet/additionalProperties/not",
+	}
+	if !reflect.DeepEqual(schemas, wantSchemas) {
+		t.Errorf("schema pointers =\n%v\nwant\n%v", schemas, wantSchemas)
+	}
+
+	wantOperations := []Location{
+		{Pointer: "/paths/~1pets~1{id}/query", Path: "/pets/{id}", Method: "query"},
+		{Pointer: "/paths/~1pets~1{id}/query/callbacks/done/{$request.body#~1url}/post", Path: "{$request.body#/url}", Method: "post"},
+		{Pointer: "/webhooks/adopted/post", Path: "adopted", Method: "post", Webhook: true},
+	}
+	if !reflect.DeepEqual(operations, wantOperations) {
+		t.Errorf("operations = %+v, want %+v", operations, wantOperations)
+	}
+	if parameters != 1 || mediaTypes != 4 || headers != 1 {
+		t.Errorf("parameters, media types, headers = %d, %d, %d, want 1, 4, 1", parameters, mediaTypes, headers)
+	}
+}
+
+func TestWalkV2(t *testing.T) {
+	t.Parallel()
+
+	spec := &swagger.Swagger{
+		Swagger: "2.0",
+		Paths: swagger.Paths{
+			"/pets": {
+				Patch: &swagger.Operation{
+					Parameters: []*swagger.Parameter{{Name: "body", In: "body", Schema: &swagger.Schema{Ref: "#/definitions/Pet"}}},
+					Responses: swagger.Responses{
+						"200": {Headers: map[string]*swagger.Header{"X-Rate": {Type: "integer"}}, Schema: &swagger.Schema{Items: &swagger.Schema{}}},
+					},
+				},
+			},
+		},
+		Definitions: map[string]*swagger.Schema{
+			"Pet": {AllOf: []*swagger.Schema{{Properties: map[string]*swagger.Schema{"name": {}}}}},
+		},
+	}
+
+	var schemas []string
+	var headers, methods []string
+	WalkV2(spec, V2Visitor{
+		Operation: func(loc Location, _ *swagger.Operation) { methods = append(methods, loc.Method) },
+		Header:    func(loc Location, _ *swagger.Header) { headers = append(headers, loc.Pointer) },
+		Schema:    func(loc Location, _ *swagger.Schema) { schemas = append(schemas, loc.Pointer) },
+	})
+
+	wantSchemas := []string{
+		"/paths/~1pets/patch/parameters/0/schema",
+		"/paths/~1pets/patch/responses/200/schema",
+		"/paths/~1pets/patch/responses/200/schema/items",
+		"/definitions/Pet",
+		"/definitions/Pet/allOf/0",
+		"/definitions/Pet/allOf/0/properties/name",
+	}
+	if !reflect.DeepEqual(schemas, wantSchemas) {
+		t.Errorf("schema pointers = %v, want %v", schemas, wantSchemas)
+	}
+	if !reflect.DeepEqual(methods, []string{"patch"}) || !reflect.DeepEqual(headers, []string{"/paths/~1pets/patch/responses/200/headers/X-Rate"}) {
+		t.Errorf("methods = %v, headers = %v", methods, headers)
+	}
+}
@@ -0,0 +1,32 @@
+package openapi
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLNode converts a specification to a YAML document through its JSON
+// encoding, so that custom marshaling such as x-* extensions is preserved
+// and keys keep their JSON order.
+func YAMLNode(spec Specification) (*yaml.Node, error) {
+	data, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	resetYAMLStyle(&doc)
+	return &doc, nil
+}
+
+// resetYAMLStyle drops the flow and quoting styles inherited from JSON so
+// the document is written in block style with quotes only where needed.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
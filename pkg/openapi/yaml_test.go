@@ -0,0 +1,47 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLNode(t *testing.T) {
+	t.Parallel()
+	spec := &openapi.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info: openapi.Info{
+			Title:      "Test API",
+			Version:    "1.0",
+			Extensions: map[string]interface{}{"x-audience": "public"},
+		},
+		Paths: openapi.Paths{},
+	}
+
+	node, err := YAMLNode(spec)
+	if err != nil {
+		t.Fatalf("YAMLNode() error = %v", err)
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	output := string(data)
+
+	for _, want := range []string{
+		"openapi: 3.1.0\n",
+		"    title: Test API\n",
+		"    version: \"1.0\"\n",
+		"    x-audience: public\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("YAML does not contain %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "{") {
+		t.Errorf("YAML should be written in block style:\n%s", output)
+	}
+}
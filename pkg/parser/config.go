@@ -2,7 +2,7 @@
 package parser
 
 import (
-	"encoding/json"
+	"fmt"
 	"go/parser"
 	"os"
 	"path/filepath"
@@ -30,6 +30,62 @@ func (p *Parser) SetPropertyStrategy(strategy string) {
 	p.propertyStrategy = strategy
 }
 
+// SetEmbeddedStrategy sets how embedded structs are rendered.
+// Valid values: "flatten" (default) merges promoted fields into the parent,
+// "allOf" composes the parent from a $ref to the embedded type and its own fields.
+func (p *Parser) SetEmbeddedStrategy(strategy string) error {
+	if !oneOf(strategy, embeddedAllOf, embeddedFlatten) {
+		return fmt.Errorf("unknown embedded strategy %q (valid: allOf, flatten)", strategy)
+	}
+	p.embeddedStrategy = strategy
+	return nil
+}
+
+// SetReadWriteSplit sets whether schemas with readOnly or writeOnly fields are
+// split into request and response variants.
+// Valid values: "none" (default), "inputOutput" (UserInput/UserOutput),
+// "requestResponse" (User.Request/User.Response).
+func (p *Parser) SetReadWriteSplit(naming string) error {
+	if !oneOf(naming, readWriteSplitNone, readWriteSplitInputOutput, readWriteSplitRequestResponse) {
+		return fmt.Errorf("unknown read/write split %q (valid: none, inputOutput, requestResponse)", naming)
+	}
+	p.readWriteSplit = naming
+	return nil
+}
+
+// SetOperationIDStrategy sets how operation IDs are generated for operations
+// without @ID. Valid values: "none" (default), "funcName", "receiverMethod",
+// "methodPath", or a template such as "{tag}_{func}" using the {func},
+// {receiver}, {method}, {path} and {tag} placeholders.
+func (p *Parser) SetOperationIDStrategy(strategy string) error {
+	if !strings.Contains(strategy, "{") &&
+		!oneOf(strategy, operationIDNone, operationIDFuncName, operationIDReceiverMethod, operationIDMethodPath) {
+		return fmt.Errorf("unknown operation ID strategy %q (valid: none, funcName, receiverMethod, methodPath or a template)", strategy)
+	}
+	p.operationIDStrategy = strategy
+	return nil
+}
+
+// SetOperationIDCase sets the casing of generated operation IDs.
+// Valid values: "none" (default), "camel", "pascal", "snake", "kebab".
+func (p *Parser) SetOperationIDCase(casing string) error {
+	if !oneOf(casing, operationIDCaseNone, operationIDCaseCamel, operationIDCasePascal, operationIDCaseSnake, operationIDCaseKebab) {
+		return fmt.Errorf("unknown operation ID case %q (valid: none, camel, pascal, snake, kebab)", casing)
+	}
+	p.operationIDCase = casing
+	return nil
+}
+
+// oneOf reports whether value case-insensitively equals one of the options.
+func oneOf(value string, options ...string) bool {
+	for _, option := range options {
+		if strings.EqualFold(value, option) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetRequiredByDefault sets whether all fields should be required by default.
 func (p *Parser) SetRequiredByDefault(required bool) {
 	p.requiredByDefault = required
@@ -58,10 +114,11 @@ func (p *Parser) SetMarkdownFilesDir(dir string) {
 	}
 }
 
-// SetOverridesFile sets the file path for type overrides.
-func (p *Parser) SetOverridesFile(path string) {
+// SetOverridesFile sets the file path for type overrides and loads it.
+// A missing file is not an error; malformed files are reported with line numbers.
+func (p *Parser) SetOverridesFile(path string) error {
 	p.overridesFile = path
-	p.loadTypeOverrides()
+	return p.loadTypeOverrides()
 }
 
 // SetTagFilters sets tag filters for API operations.
@@ -80,29 +137,6 @@ func (p *Parser) SetParseVendor(parse bool) {
 	p.parseVendor = parse
 }
 
-// loadTypeOverrides loads type overrides from file.
-func (p *Parser) loadTypeOverrides() {
-	if p.overridesFile == "" {
-		return
-	}
-
-	data, err := os.ReadFile(p.overridesFile)
-	if err != nil {
-		// File doesn't exist or can't be read, skip silently
-		return
-	}
-
-	var config struct {
-		Replace map[string]string `json:"replace"`
-	}
-
-	if err := json.Unmarshal(data, &config); err != nil {
-		return
-	}
-
-	p.typeOverrides = config.Replace
-}
-
 // loadMarkdownFiles loads all markdown files from the specified directory.
 func (p *Parser) loadMarkdownFiles() {
 	if markdownCache == nil {
@@ -156,28 +190,6 @@ func (p *Parser) GetParseDepth() int {
 	return p.parseDepth
 }
 
-// GetTypeOverride returns the override type for a given type name.
-// Supports both exact matches and partial matches (e.g., "NullInt64" matches "database/sql.NullInt64").
-func (p *Parser) GetTypeOverride(typeName string) (string, bool) {
-	if p.typeOverrides == nil {
-		return "", false
-	}
-
-	// Try exact match first
-	if override, exists := p.typeOverrides[typeName]; exists {
-		return override, true
-	}
-
-	// Try partial match (match suffix after last dot)
-	for fullName, override := range p.typeOverrides {
-		if strings.HasSuffix(fullName, "."+typeName) {
-			return override, true
-		}
-	}
-
-	return "", false
-}
-
 // ShouldIncludeOperation checks if an operation should be included based on tag filters.
 func (p *Parser) ShouldIncludeOperation(tags []string) bool {
 	// If no filters, include everything
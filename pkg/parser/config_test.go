@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -57,6 +58,37 @@ func TestSetPropertyStrategy(t *testing.T) {
 	}
 }
 
+func TestSetStrategyValidation(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	if p.embeddedStrategy != embeddedFlatten {
+		t.Errorf("default embedded strategy = %q, want flatten", p.embeddedStrategy)
+	}
+
+	tests := []struct {
+		name  string
+		set   func(string) error
+		valid []string
+	}{
+		{"embedded strategy", p.SetEmbeddedStrategy, []string{"allOf", "flatten", "ALLOF"}},
+		{"read/write split", p.SetReadWriteSplit, []string{"none", "inputOutput", "requestResponse"}},
+		{"operation ID strategy", p.SetOperationIDStrategy, []string{"none", "funcName", "receiverMethod", "methodPath", "{tag}_{func}"}},
+		{"operation ID case", p.SetOperationIDCase, []string{"none", "camel", "pascal", "snake", "kebab"}},
+	}
+
+	for _, tt := range tests {
+		for _, value := range tt.valid {
+			if err := tt.set(value); err != nil {
+				t.Errorf("%s %q returned error: %v", tt.name, value, err)
+			}
+		}
+		if err := tt.set("flaten"); err == nil || !strings.Contains(err.Error(), `"flaten"`) {
+			t.Errorf("%s with a typo: error = %v, want it to name the value", tt.name, err)
+		}
+	}
+}
+
 func TestSetRequiredByDefault(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -291,6 +323,43 @@ func TestLoadTypeOverridesValidJSON(t *testing.T) {
 	}
 }
 
+func TestLoadTypeOverridesTypes(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	overridesFile := filepath.Join(tmpDir, "overrides.json")
+	content := `{
+		"types": {
+			"github.com/acme/money.Amount": {"type": "string", "format": "decimal", "pattern": "^[0-9]+$"},
+			"uuid.UUID": {"type": "string", "description": "Identifier"},
+			"database/sql.NullInt64": "integer"
+		}
+	}`
+	if err := os.WriteFile(overridesFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create overrides file: %v", err)
+	}
+
+	p := New()
+	p.SetOverridesFile(overridesFile)
+
+	amount, ok := p.LookupType("money.Amount")
+	if !ok {
+		t.Fatal("money.Amount should be registered")
+	}
+	if amount.Type != "string" || amount.Format != "decimal" || amount.Pattern != "^[0-9]+$" {
+		t.Errorf("money.Amount = %+v, want string/decimal with pattern", amount)
+	}
+
+	id, ok := p.LookupType("uuid.UUID")
+	if !ok || id.Description != "Identifier" || id.Format != "" {
+		t.Errorf("uuid.UUID should be replaced by the configured fragment, got %+v", id)
+	}
+
+	if override, ok := p.GetTypeOverride("sql.NullInt64"); !ok || override != "integer" {
+		t.Errorf("type name values should act as replacements, got %q", override)
+	}
+}
+
 func TestMultipleConfigurationMethods(t *testing.T) {
 	t.Parallel()
 	p := New()
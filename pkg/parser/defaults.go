@@ -0,0 +1,167 @@
+// Package parser - Operation defaults declared in the general API info
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// defaultRegex matches `@Default.<Annotation>[(scope)] <arguments>`, e.g.
+// `@Default.Failure 401 {object} Error "Unauthorized"` or
+// `@Default.Security(tag=admin) AdminAuth`.
+var defaultRegex = regexp.MustCompile(`^@Default\.(Success|Failure|Response|Param|Header|Security)(?:\(([^)]*)\))?\s+(.+)$`)
+
+// operationDefault is an operation annotation merged into every operation
+// within its scope.
+type operationDefault struct {
+	annotation string // Operation annotation, e.g. "@Failure 401 {object} Error"
+	tags       []string
+	pathPrefix string
+}
+
+// parseOperationDefault parses a @Default.* general info annotation.
+func parseOperationDefault(text string) (operationDefault, bool) {
+	matches := defaultRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return operationDefault{}, false
+	}
+
+	def := operationDefault{annotation: "@" + matches[1] + " " + matches[3]}
+	for _, selector := range strings.Split(matches[2], ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(selector), "=")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "tag":
+			def.tags = append(def.tags, strings.TrimSpace(value))
+		case "path":
+			def.pathPrefix = strings.TrimSpace(value)
+		}
+	}
+
+	return def, true
+}
+
+// matches reports whether an operation is within the scope of a default.
+func (d operationDefault) matches(path string, op *openapi.Operation) bool {
+	if d.pathPrefix != "" && !strings.HasPrefix(path, d.pathPrefix) {
+		return false
+	}
+	if len(d.tags) == 0 {
+		return true
+	}
+	for _, tag := range d.tags {
+		for _, opTag := range op.Tags {
+			if tag == opTag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyOperationDefaults merges the declared defaults into every operation.
+// Responses, parameters and headers declared by an operation win; default
+// security only applies to operations without any @Security annotation, so
+// `@Security none` opts out.
+func (p *Parser) applyOperationDefaults() {
+	if len(p.operationDefaults) == 0 {
+		return
+	}
+
+	processor := NewOperationProcessor(p, p.openapi, p.typeCache)
+	for path, item := range p.openapi.Paths {
+		if item == nil {
+			continue
+		}
+		for _, op := range pathItemOperations(item) {
+			var annotations, headers []*ast.Comment
+			for _, def := range p.operationDefaults {
+				if !def.matches(path, op) {
+					continue
+				}
+				comment := &ast.Comment{Text: "// " + def.annotation}
+				if headerRegex.MatchString(def.annotation) {
+					headers = append(headers, comment)
+				} else {
+					annotations = append(annotations, comment)
+				}
+			}
+
+			if len(annotations) > 0 {
+				mergeOperation(op, processor.Process(&ast.CommentGroup{List: annotations}))
+			}
+			for _, header := range headers {
+				mergeResponseHeaders(op, processor, header.Text)
+			}
+		}
+	}
+}
+
+// mergeOperation adds the responses, parameters, request body and security
+// of a defaults operation that the target does not declare itself.
+func mergeOperation(op, defaults *openapi.Operation) {
+	if defaults == nil {
+		return
+	}
+
+	for code, response := range defaults.Responses {
+		if _, exists := op.Responses[code]; !exists {
+			op.Responses[code] = response
+		}
+	}
+
+	for _, param := range defaults.Parameters {
+		if !hasParameter(op.Parameters, param.Name, param.In) {
+			op.Parameters = append(op.Parameters, param)
+		}
+	}
+
+	if op.RequestBody == nil {
+		op.RequestBody = defaults.RequestBody
+	}
+
+	if op.Security == nil {
+		op.Security = defaults.Security
+	}
+}
+
+// mergeResponseHeaders applies a default @Header annotation to the existing
+// responses of an operation without replacing headers it already declares.
+func mergeResponseHeaders(op *openapi.Operation, processor *OperationProcessor, text string) {
+	shadow := &openapi.Operation{Responses: make(openapi.Responses, len(op.Responses))}
+	for code := range op.Responses {
+		shadow.Responses[code] = &openapi.Response{}
+	}
+	processor.processHeader(strings.TrimSpace(strings.TrimPrefix(text, "//")), shadow)
+
+	for code, response := range shadow.Responses {
+		target := op.Responses[code]
+		if target == nil {
+			continue
+		}
+		for name, header := range response.Headers {
+			if _, exists := target.Headers[name]; exists {
+				continue
+			}
+			if target.Headers == nil {
+				target.Headers = make(map[string]*openapi.Header)
+			}
+			target.Headers[name] = header
+		}
+	}
+}
+
+// hasParameter reports whether a parameter list contains name in location in.
+func hasParameter(params []openapi.Parameter, name, in string) bool {
+	for _, param := range params {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyOperationDefaults(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+// @securityDefinitions.apikey ApiKey header X-API-Key
+// @securityDefinitions.apikey AdminKey header X-Admin-Key
+// @Default.Failure 401,500 {object} Error "Unexpected error"
+// @Default.Param X-Request-ID header string false "Request identifier"
+// @Default.Header all {string} X-Trace-ID "Trace identifier"
+// @Default.Security ApiKey
+// @Default.Security(tag=admin) AdminKey
+// @Default.Failure(path=/admin) 403 {object} Error "Forbidden"
+
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// @Summary List users
+// @Tags users
+// @Success 200 {string} string "OK"
+// @Failure 500 {string} string "Own error"
+// @Header 200 {string} X-Trace-ID "Own trace"
+// @Router /users [get]
+func ListUsers() {}
+
+// @Summary Health
+// @Success 200 {string} string "OK"
+// @Security none
+// @Router /health [get]
+func Health() {}
+
+// @Summary Delete user
+// @Tags admin
+// @Param X-Request-ID header string true "Mandatory request identifier"
+// @Success 204 {string} string "Deleted"
+// @Router /admin/users [delete]
+func DeleteUser() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	if _, ok := p.openapi.Components.Schemas["Error"]; !ok {
+		t.Error("Error schema referenced only by defaults should be generated")
+	}
+
+	list := p.openapi.Paths["/users"].Get
+	assertStringSet(t, "list responses", keys(list.Responses), []string{"200", "401", "500"})
+	if got := list.Responses["500"].Description; got != "Own error" {
+		t.Errorf("500 description = %q, the operation's own response should win", got)
+	}
+	if got := list.Responses["200"].Headers["X-Trace-ID"].Description; got != "Own trace" {
+		t.Errorf("X-Trace-ID description = %q, the operation's own header should win", got)
+	}
+	if list.Responses["401"].Headers["X-Trace-ID"] == nil {
+		t.Error("default header should apply to default responses")
+	}
+	if len(list.Parameters) != 1 || list.Parameters[0].Name != "X-Request-ID" {
+		t.Errorf("list parameters = %+v, want X-Request-ID", list.Parameters)
+	}
+	if _, ok := list.Security[0]["ApiKey"]; len(list.Security) != 1 || !ok {
+		t.Errorf("list security = %v, want ApiKey", list.Security)
+	}
+
+	health := p.openapi.Paths["/health"].Get
+	if health.Security == nil || len(health.Security) != 0 {
+		t.Errorf("health security = %#v, want explicit empty list", health.Security)
+	}
+	data, err := json.Marshal(health)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"security":[]`) {
+		t.Errorf("health JSON should contain an empty security list: %s", data)
+	}
+
+	remove := p.openapi.Paths["/admin/users"].Delete
+	assertStringSet(t, "admin responses", keys(remove.Responses), []string{"204", "401", "403", "500"})
+	if len(remove.Parameters) != 1 || !remove.Parameters[0].Required {
+		t.Errorf("admin parameters = %+v, want only the operation's required X-Request-ID", remove.Parameters)
+	}
+	if len(remove.Security) != 2 {
+		t.Errorf("admin security = %v, want ApiKey or AdminKey", remove.Security)
+	}
+}
+
+func TestParseOperationDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text       string
+		ok         bool
+		annotation string
+		tags       []string
+		pathPrefix string
+	}{
+		{text: `@Default.Failure 401 {object} Error`, ok: true, annotation: `@Failure 401 {object} Error`},
+		{text: `@Default.Security(tag=admin,tag=ops) AdminKey`, ok: true, annotation: `@Security AdminKey`, tags: []string{"admin", "ops"}},
+		{text: `@Default.Param(path=/v2) X-Version header string true "Version"`, ok: true, annotation: `@Param X-Version header string true "Version"`, pathPrefix: "/v2"},
+		{text: `@Default.Summary Something`, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			t.Parallel()
+			def, ok := parseOperationDefault(tt.text)
+			if ok != tt.ok {
+				t.Fatalf("parseOperationDefault(%q) ok = %v, want %v", tt.text, ok, tt.ok)
+			}
+			if def.annotation != tt.annotation || def.pathPrefix != tt.pathPrefix ||
+				strings.Join(def.tags, ",") != strings.Join(tt.tags, ",") {
+				t.Errorf("parseOperationDefault(%q) = %+v", tt.text, def)
+			}
+		})
+	}
+}
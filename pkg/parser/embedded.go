@@ -0,0 +1,288 @@
+// Package parser - Embedded struct handling
+package parser
+
+import (
+	"go/ast"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Embedded struct strategies (see SetEmbeddedStrategy).
+const (
+	embeddedFlatten = "flatten"
+	embeddedAllOf   = "allOf"
+)
+
+// structField is a property contributed to a struct schema, either declared
+// on the struct itself or promoted from an embedded struct.
+type structField struct {
+	name         string
+	schema       *openapi.Schema
+	required     bool   // Required in the struct being processed
+	wantRequired bool   // Required in the embedded type that declares it
+	depth        int    // Embedding depth, 0 for fields declared on the struct itself
+	tagged       bool   // Name comes from a json tag
+	via          string // Embedded type at depth 0 the field is promoted from
+}
+
+// embeddedRef is an allOf $ref produced by an embedded field.
+type embeddedRef struct {
+	schema openapi.Schema
+	via    string // Embedded type at depth 0 the reference belongs to
+	group  bool   // Reference to a resolvable embedded struct (allOf strategy only)
+}
+
+// collectStructFields gathers the fields of a struct, descending into
+// embedded structs. Embedded types that cannot be resolved are returned as
+// $refs. optional marks fields reached through an embedded pointer.
+func (s *SchemaProcessor) collectStructFields(structType *ast.StructType, depth int, optional bool, via string, visited map[*ast.StructType]bool) ([]*structField, []embeddedRef) {
+	var fields []*structField
+	var refs []embeddedRef
+
+	// Fields of XML-encoded structs, and of the structs they embed, are
+	// named after the Go field in XML
+	previousXML := s.xmlStruct
+	s.xmlStruct = s.xmlStruct || isXMLStruct(structType)
+	defer func() { s.xmlStruct = previousXML }()
+
+	add := func(field *structField) {
+		if field == nil {
+			return
+		}
+		field.depth = depth
+		field.via = via
+		if optional {
+			field.required = false
+			field.wantRequired = false
+		}
+		fields = append(fields, field)
+	}
+
+	for _, field := range structType.Fields.List {
+		tags := s.parseStructTags(field)
+		if tags.JSON == "-" || isSwaggerIgnored(field) {
+			continue
+		}
+
+		switch {
+		case tags.Inline:
+			// Fields tagged with ",inline" are merged into the parent like embedded fields
+			embeddedFields, embeddedRefs := s.collectEmbeddedFields(field, depth, optional, via, visited)
+			fields = append(fields, embeddedFields...)
+			refs = append(refs, embeddedRefs...)
+
+		case len(field.Names) > 0:
+			// A declaration like "A, B int" produces one property per name
+			for _, name := range field.Names {
+				add(s.processNamedField(field, name.Name))
+			}
+
+		case jsonTagName(tags.JSON) != "":
+			// Embedded fields with an explicit JSON name are encoded as regular fields
+			add(s.processNamedField(field, embeddedFieldName(field.Type)))
+
+		default:
+			embeddedFields, embeddedRefs := s.collectEmbeddedFields(field, depth, optional, via, visited)
+			fields = append(fields, embeddedFields...)
+			refs = append(refs, embeddedRefs...)
+		}
+	}
+
+	return fields, refs
+}
+
+// collectEmbeddedFields returns the fields promoted by an embedded field.
+func (s *SchemaProcessor) collectEmbeddedFields(field *ast.Field, depth int, optional bool, via string, visited map[*ast.StructType]bool) ([]*structField, []embeddedRef) {
+	typeName := embeddedTypeName(field.Type)
+	if typeName == "" || s.parser.IsTypeSkipped(typeName) {
+		return nil, nil
+	}
+
+	ref := embeddedRef{
+		schema: openapi.Schema{Ref: "#/components/schemas/" + typeName},
+		via:    via,
+	}
+
+	// Unknown types and custom marshalers keep the allOf reference
+	typeSpec, _ := s.parser.findTypeSpec(typeName)
+	if typeSpec == nil || s.parser.marshalerKind(typeName) != "" {
+		return nil, []embeddedRef{ref}
+	}
+
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		// Embedded non-struct types are encoded as a field named after the type
+		field := s.processNamedField(field, embeddedFieldName(field.Type))
+		if field == nil {
+			return nil, nil
+		}
+		field.depth = depth
+		field.via = via
+		if optional {
+			field.required = false
+			field.wantRequired = false
+		}
+		return []*structField{field}, nil
+	}
+
+	// Embedding cycles are only possible through pointers; stop at the repeat
+	if visited[structType] {
+		return nil, nil
+	}
+	visited[structType] = true
+	defer delete(visited, structType)
+
+	_, isPointer := field.Type.(*ast.StarExpr)
+
+	var refs []embeddedRef
+	if depth == 0 {
+		// Fields promoted from this type form a group that may be replaced by a $ref
+		via = typeName
+		ref.via = typeName
+		ref.group = true
+		refs = append(refs, ref)
+
+		fields, nested := s.collectStructFields(structType, depth+1, false, via, visited)
+		if isPointer {
+			// A nil embedded pointer omits all of its fields
+			for _, field := range fields {
+				field.required = false
+			}
+		}
+		return fields, append(refs, nested...)
+	}
+
+	fields, nested := s.collectStructFields(structType, depth+1, optional || isPointer, via, visited)
+	return fields, append(refs, nested...)
+}
+
+// applyStructFields adds the visible fields to a struct schema. With the allOf
+// strategy, embedded structs whose fields are all promoted unchanged are
+// replaced by a $ref and the schema becomes allOf: [{$ref}, {own fields}].
+func (s *SchemaProcessor) applyStructFields(schema *openapi.Schema, fields []*structField, refs []embeddedRef) {
+	winners := dominantFields(fields)
+
+	// Decide which embedded structs can be referenced instead of flattened
+	composed := make(map[string]bool)
+	if strings.EqualFold(s.parser.embeddedStrategy, embeddedAllOf) {
+		for _, ref := range refs {
+			if ref.group {
+				composed[ref.via] = true
+			}
+		}
+		for _, field := range fields {
+			if field.via == "" || !composed[field.via] {
+				continue
+			}
+			// Shadowed fields and pointer-optional fields cannot be expressed with a $ref
+			if !winners[field] || field.required != field.wantRequired {
+				composed[field.via] = false
+			}
+		}
+	}
+
+	var allOf []openapi.Schema
+	for _, ref := range refs {
+		switch {
+		case ref.group && composed[ref.via]:
+			allOf = append(allOf, ref.schema)
+		case ref.group, ref.via != "" && composed[ref.via]:
+			// Flattened, or already part of the referenced component
+		default:
+			allOf = append(allOf, ref.schema)
+		}
+	}
+
+	hasComposition := false
+	for _, field := range fields {
+		if composed[field.via] {
+			hasComposition = true
+			continue
+		}
+		if !winners[field] {
+			continue
+		}
+		schema.Properties[field.name] = field.schema
+		if field.required {
+			schema.Required = append(schema.Required, field.name)
+		}
+	}
+	for _, ok := range composed {
+		hasComposition = hasComposition || ok
+	}
+
+	if !hasComposition {
+		schema.AllOf = append(schema.AllOf, allOf...)
+		return
+	}
+
+	// allOf: [{$ref: Embedded}, ..., {own fields}]
+	if len(schema.Properties) > 0 {
+		allOf = append(allOf, openapi.Schema{
+			Type:       schema.Type,
+			Properties: schema.Properties,
+			Required:   schema.Required,
+		})
+	}
+	schema.Type = nil
+	schema.Properties = nil
+	schema.Required = nil
+	schema.AllOf = append(schema.AllOf, allOf...)
+}
+
+// dominantFields applies Go's field promotion rules as encoding/json does:
+// for each JSON name the shallowest field wins, a tagged field wins among
+// fields at the same depth, and remaining ties hide the name entirely.
+func dominantFields(fields []*structField) map[*structField]bool {
+	byName := make(map[string][]*structField)
+	for _, field := range fields {
+		byName[field.name] = append(byName[field.name], field)
+	}
+
+	winners := make(map[*structField]bool)
+	for _, candidates := range byName {
+		minDepth := candidates[0].depth
+		for _, field := range candidates {
+			if field.depth < minDepth {
+				minDepth = field.depth
+			}
+		}
+
+		var shallowest, tagged []*structField
+		for _, field := range candidates {
+			if field.depth != minDepth {
+				continue
+			}
+			shallowest = append(shallowest, field)
+			if field.tagged {
+				tagged = append(tagged, field)
+			}
+		}
+
+		switch {
+		case len(shallowest) == 1:
+			winners[shallowest[0]] = true
+		case len(tagged) == 1:
+			winners[tagged[0]] = true
+		}
+	}
+
+	return winners
+}
+
+// embeddedTypeName returns the type name of an embedded field as used in
+// component names: "Base" or "models.Base".
+func embeddedTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedTypeName(t.X)
+	case *ast.SelectorExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident.Name + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
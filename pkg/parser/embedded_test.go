@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+const embeddedTestTypes = `package main
+
+type BaseModel struct {
+	ID        int    ` + "`json:\"id\"`" + `
+	CreatedAt string ` + "`json:\"createdAt\"`" + `
+}
+
+type Audit struct {
+	By string ` + "`json:\"by\" binding:\"required\"`" + `
+}
+
+type Labels []string
+
+type Named struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Titled struct {
+	Name string
+}
+
+type Other struct {
+	Name string
+}
+
+type Deep struct {
+	BaseModel
+}
+
+type Cycle struct {
+	*Cycle
+	Value string ` + "`json:\"value\"`" + `
+}
+
+type User struct {
+	BaseModel
+	*Audit
+	Labels
+	Email string ` + "`json:\"email\"`" + `
+}
+
+type Shadow struct {
+	BaseModel
+	ID string ` + "`json:\"id\"`" + `
+}
+
+type Ambiguous struct {
+	Titled
+	Other
+}
+
+type TaggedWins struct {
+	Named
+	Titled
+}
+
+type Nested struct {
+	Deep
+	ID string ` + "`json:\"id\"`" + `
+}
+`
+
+func TestEmbeddedFlatten(t *testing.T) {
+	t.Parallel()
+	p := New()
+	p.SetRequiredByDefault(true)
+	processTypes(t, p, embeddedTestTypes)
+	schemas := p.openapi.Components.Schemas
+
+	tests := []struct {
+		schema     string
+		properties []string
+		required   []string
+	}{
+		{"User", []string{"createdAt", "email", "id", "labels", "by"}, []string{"createdAt", "email", "id", "labels"}},
+		{"Shadow", []string{"createdAt", "id"}, []string{"createdAt", "id"}},
+		{"Ambiguous", nil, nil},
+		{"TaggedWins", []string{"name"}, []string{"name"}},
+		{"Nested", []string{"createdAt", "id"}, []string{"createdAt", "id"}},
+		{"Cycle", []string{"value"}, []string{"value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			schema := schemas[tt.schema]
+			if len(schema.AllOf) != 0 {
+				t.Errorf("AllOf = %v, want none in flatten mode", schema.AllOf)
+			}
+			assertStringSet(t, "properties", keys(schema.Properties), tt.properties)
+			assertStringSet(t, "required", schema.Required, tt.required)
+		})
+	}
+
+	if id := schemas["Shadow"].Properties["id"]; id.Type != "string" {
+		t.Errorf("Shadow.id Type = %v, the parent field should shadow the promoted one", id.Type)
+	}
+	if labels := schemas["User"].Properties["labels"]; labels.Ref != "#/components/schemas/Labels" {
+		t.Errorf("embedded non-struct type should be a property, got %+v", labels)
+	}
+}
+
+func TestEmbeddedAllOf(t *testing.T) {
+	t.Parallel()
+	p := New()
+	p.SetRequiredByDefault(true)
+	if err := p.SetEmbeddedStrategy("allOf"); err != nil {
+		t.Fatalf("SetEmbeddedStrategy() returned error: %v", err)
+	}
+	processTypes(t, p, embeddedTestTypes)
+	schemas := p.openapi.Components.Schemas
+
+	// BaseModel composes; *Audit has a required field and must be flattened
+	user := schemas["User"]
+	if user.Type != nil || user.Properties != nil {
+		t.Errorf("composed schema should only hold allOf, got Type=%v Properties=%v", user.Type, user.Properties)
+	}
+	if len(user.AllOf) != 2 || user.AllOf[0].Ref != "#/components/schemas/BaseModel" {
+		t.Fatalf("User.AllOf = %+v, want [$ref BaseModel, own fields]", user.AllOf)
+	}
+	own := user.AllOf[1]
+	assertStringSet(t, "own properties", keys(own.Properties), []string{"by", "email", "labels"})
+	assertStringSet(t, "own required", own.Required, []string{"email", "labels"})
+
+	// Shadowed fields cannot be expressed with a $ref
+	shadow := schemas["Shadow"]
+	if len(shadow.AllOf) != 0 {
+		t.Errorf("Shadow should be flattened, got AllOf %+v", shadow.AllOf)
+	}
+	assertStringSet(t, "Shadow properties", keys(shadow.Properties), []string{"createdAt", "id"})
+
+	// Deep embeds BaseModel; Nested only embeds Deep, which is shadow-free at its own level
+	nested := schemas["Nested"]
+	if len(nested.AllOf) != 0 {
+		t.Errorf("Nested shadows a field promoted from Deep and should be flattened, got %+v", nested.AllOf)
+	}
+	deep := schemas["Deep"]
+	if len(deep.AllOf) != 1 || deep.AllOf[0].Ref != "#/components/schemas/BaseModel" {
+		t.Errorf("Deep.AllOf = %+v, want only the BaseModel $ref", deep.AllOf)
+	}
+}
+
+func TestEmbeddedUnresolvedKeepsRef(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type User struct {
+	models.Base
+	*Missing
+	Name string
+}
+`
+	for _, strategy := range []string{"flatten", "allOf"} {
+		p := New()
+		if err := p.SetEmbeddedStrategy(strategy); err != nil {
+			t.Fatalf("SetEmbeddedStrategy(%q) returned error: %v", strategy, err)
+		}
+		processTypes(t, p, content)
+
+		user := p.openapi.Components.Schemas["User"]
+		if len(user.AllOf) != 2 || user.AllOf[0].Ref != "#/components/schemas/models.Base" || user.AllOf[1].Ref != "#/components/schemas/Missing" {
+			t.Errorf("%s: AllOf = %+v, want refs to models.Base and Missing", strategy, user.AllOf)
+		}
+		if _, ok := user.Properties["name"]; !ok {
+			t.Errorf("%s: own fields should stay on the schema", strategy)
+		}
+	}
+}
+
+func TestParseDirEmbeddedFromOtherPackage(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"main.go": `package main
+
+import "example.com/app/models"
+
+// @title Test API
+// @version 1.0.0
+
+type User struct {
+	models.Base
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Summary Get user
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser() {}
+`,
+		"models/base.go": `package models
+
+type Base struct {
+	ID    int   ` + "`json:\"id\"`" + `
+	Owner Owner ` + "`json:\"owner\"`" + `
+}
+
+type Owner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	schemas := p.openapi.Components.Schemas
+	user := schemas["User"]
+	assertStringSet(t, "User properties", keys(user.Properties), []string{"id", "name", "owner"})
+	if _, ok := schemas["Owner"]; !ok {
+		t.Error("types used by flattened fields should be resolved")
+	}
+	if _, ok := schemas["Base"]; ok {
+		t.Error("flattened embedded types should not produce a component")
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
+
+func assertStringSet(t *testing.T, what string, got, want []string) {
+	t.Helper()
+	got = append([]string(nil), got...)
+	want = append([]string(nil), want...)
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Errorf("%s = %v, want %v", what, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s = %v, want %v", what, got, want)
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Package parser - Vendor extensions
+package parser
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// extensionRegex matches a generic `@x-name value` annotation.
+var extensionRegex = regexp.MustCompile(`^@(x-[\w.-]+)\s+(.+)$`)
+
+// parseExtensionList parses an extensions list as used by the `extensions`
+// struct tag and the extensions(...) attribute:
+// - "x-nullable" - boolean true
+// - "x-abc=def" - string value (numbers and booleans are converted)
+// - "!x-omitempty" - boolean false (negation)
+// - "x-nullable,x-abc=def,!x-omitempty" - multiple extensions.
+func parseExtensionList(list string) map[string]interface{} {
+	extensions := make(map[string]interface{})
+
+	for _, ext := range strings.Split(list, ",") {
+		ext = strings.TrimSpace(ext)
+		if ext == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(ext, "!"):
+			// Negation: !x-omitempty → x-omitempty: false
+			key := strings.TrimPrefix(ext, "!")
+			if strings.HasPrefix(key, "x-") {
+				extensions[key] = false
+			}
+		case strings.Contains(ext, "="):
+			// With value: x-abc=def
+			parts := strings.SplitN(ext, "=", 2)
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			if strings.HasPrefix(key, "x-") {
+				// Try to parse as number, otherwise string
+				if number, err := strconv.ParseFloat(value, 64); err == nil && number == number {
+					extensions[key] = number
+					continue
+				}
+				switch value {
+				case valueTrue:
+					extensions[key] = true
+				case valueFalse:
+					extensions[key] = false
+				default:
+					extensions[key] = value
+				}
+			}
+		default:
+			// Boolean true: x-nullable
+			if strings.HasPrefix(ext, "x-") {
+				extensions[ext] = true
+			}
+		}
+	}
+
+	return extensions
+}
+
+// parseExtensionValue decodes the value of an `@x-name value` annotation:
+// valid JSON is decoded, anything else is kept as a string.
+func parseExtensionValue(value string) interface{} {
+	value = strings.TrimSpace(value)
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+		return decoded
+	}
+	return value
+}
+
+// setExtension stores an extension, creating the map when needed.
+func setExtension(extensions map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+	extensions[key] = value
+	return extensions
+}
+
+// mergeExtensions copies src into dst, creating dst when needed.
+func mergeExtensions(dst, src map[string]interface{}) map[string]interface{} {
+	for key, value := range src {
+		dst = setExtension(dst, key, value)
+	}
+	return dst
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseExtensions(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+// @x-logo {"url": "https://example.com/logo.png"}
+// @info.x-audience public
+// @server https://api.example.com Production
+// @server.x-region eu-west-1
+// @tag.name users
+// @tag.x-displayName Users
+// @securityDefinitions.apikey ApiKey header X-API-Key
+// @securityDefinitions.ApiKey.x-rotation 30
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Summary Create user
+// @Param user body User true "User" extensions(x-example-id=u1)
+// @Param trace query string false "Trace" extensions(x-internal,!x-public)
+// @Success 201 {object} User "Created" extensions(x-cache=true,x-ttl=60)
+// @x-rate-limit {"requests": 100}
+// @x-owner team-a
+// @Router /users [post]
+func CreateUser() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	spec := p.openapi
+	op := spec.Paths["/users"].Post
+	if op == nil {
+		t.Fatal("POST /users missing")
+	}
+
+	tests := []struct {
+		name string
+		got  map[string]interface{}
+		want map[string]interface{}
+	}{
+		{name: "root", got: spec.Extensions, want: map[string]interface{}{
+			"x-logo": map[string]interface{}{"url": "https://example.com/logo.png"},
+		}},
+		{name: "info", got: spec.Info.Extensions, want: map[string]interface{}{"x-audience": "public"}},
+		{name: "server", got: spec.Servers[0].Extensions, want: map[string]interface{}{"x-region": "eu-west-1"}},
+		{name: "tag", got: spec.Tags[0].Extensions, want: map[string]interface{}{"x-displayName": "Users"}},
+		{name: "security scheme", got: spec.Components.SecuritySchemes["ApiKey"].Extensions, want: map[string]interface{}{"x-rotation": float64(30)}},
+		{name: "operation", got: op.Extensions, want: map[string]interface{}{
+			"x-rate-limit": map[string]interface{}{"requests": float64(100)},
+			"x-owner":      "team-a",
+		}},
+		{name: "request body", got: op.RequestBody.Extensions, want: map[string]interface{}{"x-example-id": "u1"}},
+		{name: "parameter", got: op.Parameters[0].Extensions, want: map[string]interface{}{"x-internal": true, "x-public": false}},
+		{name: "response", got: op.Responses["201"].Extensions, want: map[string]interface{}{"x-cache": true, "x-ttl": float64(60)}},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s extensions = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// Operation extensions must not leak into the document root
+	if _, ok := spec.Extensions["x-owner"]; ok {
+		t.Error("operation extension x-owner found on the document root")
+	}
+}
+
+func TestParseExtensionValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  interface{}
+	}{
+		{value: "plain text", want: "plain text"},
+		{value: "42", want: float64(42)},
+		{value: "true", want: true},
+		{value: `"quoted"`, want: "quoted"},
+		{value: `["a", "b"]`, want: []interface{}{"a", "b"}},
+		{value: "{invalid", want: "{invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			if got := parseExtensionValue(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseExtensionValue(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,8 +9,11 @@ import (
 
 // GeneralInfoProcessor processes general API information annotations.
 type GeneralInfoProcessor struct {
-	openapi *openapi.OpenAPI
-	lastTag *openapi.Tag
+	openapi    *openapi.OpenAPI
+	lastTag    *openapi.Tag
+	lastScheme *openapi.SecurityScheme
+	lastFlow   *openapi.OAuthFlow
+	defaults   []operationDefault
 }
 
 // NewGeneralInfoProcessor creates a new general info processor.
@@ -51,12 +54,6 @@ var (
 	tagDocsURLRegex  = regexp.MustCompile(`^@tag\.docs\.url\s+(.+)$`)
 	tagDocsDescRegex = regexp.MustCompile(`^@tag\.docs\.description\s+(.+)$`)
 
-	// Security regex patterns.
-	securityRegex       = regexp.MustCompile(`^@securityDefinitions\.(\w+)\s+(\S+)\s+(.+)$`)
-	securityBasicRegex  = regexp.MustCompile(`^@securityDefinitions\.basic\s+(\S+)\s*(.*)$`)
-	securityAPIKeyRegex = regexp.MustCompile(`^@securityDefinitions\.apikey\s+(\S+)\s+(\w+)\s+(\w+)\s*(.*)$`)
-	securityOAuth2Regex = regexp.MustCompile(`^@securityDefinitions\.oauth2\.(\w+)\s+(\S+)\s*(.*)$`)
-
 	// OpenAPI 3.2.0: SecurityScheme extensions.
 	securityDeprecatedRegex        = regexp.MustCompile(`^@securityDefinitions\.(\w+)\.deprecated\s+(true|false)$`)
 	securityOAuth2MetadataURLRegex = regexp.MustCompile(`^@securityDefinitions\.(\w+)\.oauth2metadataurl\s+(\S+)$`)
@@ -68,12 +65,27 @@ var (
 
 	// Webhook regex patterns.
 	webhookRegex = regexp.MustCompile(`^@webhook\s+(\S+)\s+(.+)$`)
+
+	// Vendor extension regex patterns (@x-name is matched by extensionRegex).
+	infoExtensionRegex     = regexp.MustCompile(`^@info\.(x-[\w.-]+)\s+(.+)$`)
+	tagExtensionRegex      = regexp.MustCompile(`^@tag\.(x-[\w.-]+)\s+(.+)$`)
+	serverExtensionRegex   = regexp.MustCompile(`^@server\.(x-[\w.-]+)\s+(.+)$`)
+	securityExtensionRegex = regexp.MustCompile(`^@securityDefinitions\.(\w+)\.(x-[\w.-]+)\s+(.+)$`)
 )
 
 // Process processes a single general API annotation.
 //
 //nolint:gocyclo // Complex switch for many annotation types is acceptable
 func (g *GeneralInfoProcessor) Process(text string) error {
+	// Attribute lines (@in, @tokenUrl, @scope.read, ...) belong to the
+	// security definition above them
+	if g.lastScheme != nil {
+		if g.processSecurityAttribute(text) {
+			return nil
+		}
+		g.lastScheme, g.lastFlow = nil, nil
+	}
+
 	switch {
 	// Info object
 	case titleRegex.MatchString(text):
@@ -188,6 +200,9 @@ func (g *GeneralInfoProcessor) Process(text string) error {
 		}
 		g.openapi.Servers = append(g.openapi.Servers, server)
 
+	case serverVariableRegex.MatchString(text):
+		addServerVariable(g.openapi.Servers, text)
+
 	case serverDescRegex.MatchString(text):
 		matches := serverDescRegex.FindStringSubmatch(text)
 		// Update the last server's description
@@ -257,24 +272,6 @@ func (g *GeneralInfoProcessor) Process(text string) error {
 			Description: description,
 		}
 
-	// Security definitions
-	case securityBasicRegex.MatchString(text):
-		matches := securityBasicRegex.FindStringSubmatch(text)
-		g.openapi.Components.SecuritySchemes[matches[1]] = &openapi.SecurityScheme{
-			Type:        "http",
-			Scheme:      "basic",
-			Description: strings.TrimSpace(matches[2]),
-		}
-
-	case securityAPIKeyRegex.MatchString(text):
-		matches := securityAPIKeyRegex.FindStringSubmatch(text)
-		g.openapi.Components.SecuritySchemes[matches[1]] = &openapi.SecurityScheme{
-			Type:        "apiKey",
-			Name:        matches[2],
-			In:          matches[3],
-			Description: strings.TrimSpace(matches[4]),
-		}
-
 	// OpenAPI 3.2.0: SecurityScheme.Deprecated
 	case securityDeprecatedRegex.MatchString(text):
 		matches := securityDeprecatedRegex.FindStringSubmatch(text)
@@ -326,6 +323,45 @@ func (g *GeneralInfoProcessor) Process(text string) error {
 			TokenURL:         tokenURL,
 			Scopes:           make(map[string]string),
 		}
+		g.lastScheme, g.lastFlow = scheme, scheme.Flows.DeviceAuthorization
+
+	// Security definitions (@securityDefinitions.<kind>, see security.go)
+	case securityDefinitionRegex.MatchString(text):
+		g.processSecurityDefinition(text)
+
+	// Operation defaults
+	case defaultRegex.MatchString(text):
+		if def, ok := parseOperationDefault(text); ok {
+			g.defaults = append(g.defaults, def)
+		}
+
+	// Vendor extensions
+	case extensionRegex.MatchString(text):
+		matches := extensionRegex.FindStringSubmatch(text)
+		g.openapi.Extensions = setExtension(g.openapi.Extensions, matches[1], parseExtensionValue(matches[2]))
+
+	case infoExtensionRegex.MatchString(text):
+		matches := infoExtensionRegex.FindStringSubmatch(text)
+		g.openapi.Info.Extensions = setExtension(g.openapi.Info.Extensions, matches[1], parseExtensionValue(matches[2]))
+
+	case tagExtensionRegex.MatchString(text):
+		matches := tagExtensionRegex.FindStringSubmatch(text)
+		if g.lastTag != nil {
+			g.lastTag.Extensions = setExtension(g.lastTag.Extensions, matches[1], parseExtensionValue(matches[2]))
+		}
+
+	case serverExtensionRegex.MatchString(text):
+		matches := serverExtensionRegex.FindStringSubmatch(text)
+		if len(g.openapi.Servers) > 0 {
+			server := &g.openapi.Servers[len(g.openapi.Servers)-1]
+			server.Extensions = setExtension(server.Extensions, matches[1], parseExtensionValue(matches[2]))
+		}
+
+	case securityExtensionRegex.MatchString(text):
+		matches := securityExtensionRegex.FindStringSubmatch(text)
+		if scheme, exists := g.openapi.Components.SecuritySchemes[matches[1]]; exists {
+			scheme.Extensions = setExtension(scheme.Extensions, matches[2], parseExtensionValue(matches[3]))
+		}
 	}
 
 	return nil
@@ -0,0 +1,125 @@
+// Package parser - Anonymous struct promotion
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strconv"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// inlineStruct tracks an anonymous struct type so that repeated occurrences
+// can share a component instead of being expanded again.
+type inlineStruct struct {
+	name  string          // Suggested component name: parent type + field path
+	first *openapi.Schema // Schema emitted for the first occurrence
+	ref   string          // Component reference once promoted
+}
+
+// processInlineStruct expands an anonymous struct type. The first occurrence
+// is emitted inline; when the same struct appears again it is promoted to a
+// component named after its first parent and field, and every occurrence
+// becomes a $ref to it.
+func (s *SchemaProcessor) processInlineStruct(structType *ast.StructType) *openapi.Schema {
+	key := ""
+	if structType.Fields != nil && len(structType.Fields.List) > 0 {
+		key = exprSource(structType)
+	}
+
+	if seen, ok := s.parser.inlineStructs[key]; ok && key != "" {
+		if seen.ref == "" {
+			s.promoteInlineStruct(seen)
+		}
+		return &openapi.Schema{Ref: seen.ref}
+	}
+
+	// Depth limit only bounds deeply nested anonymous structs
+	maxDepth := s.parser.GetParseDepth()
+	if maxDepth > 0 && s.depth >= maxDepth {
+		return &openapi.Schema{}
+	}
+
+	s.depth++
+	result := s.ProcessStruct(structType, nil, "")
+	s.depth--
+
+	if key != "" {
+		s.parser.inlineStructs[key] = &inlineStruct{
+			name:  s.inlineStructName(),
+			first: result,
+		}
+	}
+
+	return result
+}
+
+// promoteInlineStruct moves the first occurrence of an anonymous struct into
+// components and turns it into a $ref. Field-level metadata such as the
+// description stays next to the $ref.
+func (s *SchemaProcessor) promoteInlineStruct(seen *inlineStruct) {
+	name := s.parser.uniqueSchemaName(seen.name)
+	seen.ref = "#/components/schemas/" + name
+
+	component := cloneSchema(seen.first)
+	ref := &openapi.Schema{
+		Ref:         seen.ref,
+		Description: component.Description,
+		Example:     component.Example,
+		Default:     component.Default,
+		ReadOnly:    component.ReadOnly,
+		WriteOnly:   component.WriteOnly,
+		Deprecated:  component.Deprecated,
+		Extensions:  component.Extensions,
+	}
+
+	component.Description = ""
+	component.Example = nil
+	component.Default = nil
+	component.ReadOnly = false
+	component.WriteOnly = false
+	component.Deprecated = false
+	component.Extensions = nil
+
+	s.openapi.Components.Schemas[name] = component
+	*seen.first = *ref
+}
+
+// inlineStructName builds a component name from the current type and field path.
+func (s *SchemaProcessor) inlineStructName() string {
+	var name strings.Builder
+	name.WriteString(s.typeName)
+	for _, field := range s.fieldPath {
+		name.WriteString(field)
+	}
+
+	if name.Len() == 0 {
+		return "AnonymousStruct"
+	}
+	return name.String()
+}
+
+// uniqueSchemaName returns name, or name with a numeric suffix, such that it
+// clashes neither with an existing component nor with a declared Go type.
+func (p *Parser) uniqueSchemaName(name string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		_, exists := p.openapi.Components.Schemas[candidate]
+		if typeSpec, _ := p.findTypeSpec(candidate); !exists && typeSpec == nil {
+			return candidate
+		}
+		candidate = name + strconv.Itoa(i)
+	}
+}
+
+// exprSource renders a type expression as Go source, tags included.
+func exprSource(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// processTypes runs a schema processor over every struct declared in content,
+// registering the results like parseSchemas does.
+func processTypes(t *testing.T, p *Parser, content string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+	p.files["test.go"] = file
+
+	sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				p.openapi.Components.Schemas[typeSpec.Name.Name] = sp.ProcessStruct(structType, nil, typeSpec.Name.Name)
+			}
+		}
+	}
+}
+
+func TestProcessInlineStructPromotion(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Order struct {
+	// Where to ship
+	Shipping struct {
+		Street string ` + "`json:\"street\"`" + `
+		City   string ` + "`json:\"city\"`" + `
+	} ` + "`json:\"shipping\"`" + `
+	Billing struct {
+		Street string ` + "`json:\"street\"`" + `
+		City   string ` + "`json:\"city\"`" + `
+	} ` + "`json:\"billing\"`" + `
+	Meta struct {
+		Source string ` + "`json:\"source\"`" + `
+	} ` + "`json:\"meta\"`" + `
+}
+
+type OrderShipping struct {
+	Carrier string ` + "`json:\"carrier\"`" + `
+}
+
+type Invoice struct {
+	Lines []struct {
+		Street string ` + "`json:\"street\"`" + `
+		City   string ` + "`json:\"city\"`" + `
+	} ` + "`json:\"lines\"`" + `
+	Empty struct{} ` + "`json:\"empty\"`" + `
+	Other struct{} ` + "`json:\"other\"`" + `
+}
+`
+	p := New()
+	processTypes(t, p, content)
+
+	schemas := p.openapi.Components.Schemas
+	order := schemas["Order"]
+
+	// OrderShipping is a declared type, so the promoted name gets a suffix
+	const ref = "#/components/schemas/OrderShipping2"
+	shipping := order.Properties["shipping"]
+	if shipping.Ref != ref {
+		t.Fatalf("shipping Ref = %q, want %q", shipping.Ref, ref)
+	}
+	if shipping.Description != "Where to ship" {
+		t.Errorf("shipping Description = %q, field docs should stay next to the $ref", shipping.Description)
+	}
+	if billing := order.Properties["billing"]; billing.Ref != ref {
+		t.Errorf("billing Ref = %q, want %q", billing.Ref, ref)
+	}
+	if lines := schemas["Invoice"].Properties["lines"]; lines.Items == nil || lines.Items.Ref != ref {
+		t.Errorf("lines items should reference %q, got %+v", ref, lines.Items)
+	}
+
+	promoted, ok := schemas["OrderShipping2"]
+	if !ok {
+		t.Fatal("promoted component OrderShipping2 missing")
+	}
+	if len(promoted.Properties) != 2 || promoted.Description != "" {
+		t.Errorf("promoted component = %+v, want 2 properties and no field description", promoted)
+	}
+
+	// Unique anonymous structs stay inline
+	if meta := order.Properties["meta"]; meta.Ref != "" || len(meta.Properties) != 1 {
+		t.Errorf("meta should stay inline, got %+v", meta)
+	}
+
+	// Empty structs are never promoted
+	if empty := schemas["Invoice"].Properties["other"]; empty.Ref != "" {
+		t.Errorf("empty struct should stay inline, got Ref %q", empty.Ref)
+	}
+}
+
+func TestProcessFieldTypeRecursive(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Category struct {
+	Name     string              ` + "`json:\"name\"`" + `
+	Parent   *Category           ` + "`json:\"parent\"`" + `
+	Children [][]Category        ` + "`json:\"children\"`" + `
+	Index    map[string]*Category ` + "`json:\"index\"`" + `
+}
+`
+	p := New()
+	p.SetParseDepth(1)
+	processTypes(t, p, content)
+
+	category := p.openapi.Components.Schemas["Category"]
+	const ref = "#/components/schemas/Category"
+
+	if category.Properties["parent"].Ref != ref {
+		t.Errorf("parent Ref = %q, want %q", category.Properties["parent"].Ref, ref)
+	}
+	children := category.Properties["children"]
+	if children.Items == nil || children.Items.Items == nil || children.Items.Items.Ref != ref {
+		t.Errorf("nested arrays should not be truncated by parseDepth, got %+v", children.Items)
+	}
+	if index := category.Properties["index"]; index.AdditionalProperties == nil {
+		t.Error("map values should not be truncated by parseDepth")
+	}
+}
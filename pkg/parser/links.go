@@ -0,0 +1,121 @@
+// Package parser - Response links
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+var (
+	// @Link 201 GetUser getUser id=$response.body#/id "Fetch the new user"
+	linkRegex = regexp.MustCompile(`^@Link\s+(` + statusCodeListPattern + `)\s+([\w.-]+)\s+(\S+)(.*)$`)
+
+	// Trailing quoted description of a @Link annotation.
+	linkDescriptionRegex = regexp.MustCompile(`\s*"([^"]*)"\s*$`)
+)
+
+// linkRequestBody is the @Link argument that sets the link's request body
+// instead of a parameter.
+const linkRequestBody = "requestBody"
+
+// processLink adds a Link object to the responses of a @Link annotation.
+// Arguments are name=value pairs; values starting with "$" are runtime
+// expressions, anything else is a constant.
+func (o *OperationProcessor) processLink(text string, op *openapi.Operation) {
+	matches := linkRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return
+	}
+
+	link := &openapi.Link{OperationID: matches[3]}
+	args := matches[4]
+	if description := linkDescriptionRegex.FindStringSubmatch(args); description != nil {
+		link.Description = description[1]
+		args = args[:len(args)-len(description[0])]
+	}
+
+	for _, arg := range strings.Fields(args) {
+		name, value, found := strings.Cut(arg, "=")
+		if !found || name == "" {
+			continue
+		}
+
+		var parsed interface{} = value
+		if !strings.HasPrefix(value, "$") {
+			parsed = parseExtensionValue(value)
+		}
+
+		if name == linkRequestBody {
+			link.RequestBody = parsed
+			continue
+		}
+		if link.Parameters == nil {
+			link.Parameters = make(map[string]interface{})
+		}
+		link.Parameters[name] = parsed
+	}
+
+	for _, statusCode := range parseStatusCodes(matches[1]) {
+		response := op.Responses[statusCode]
+		if response == nil {
+			response = &openapi.Response{
+				Description: "Response " + statusCode,
+			}
+			op.Responses[statusCode] = response
+		}
+
+		if response.Links == nil {
+			response.Links = make(map[string]*openapi.Link)
+		}
+		// Each response gets its own copy so editing one leaves the others alone
+		clone := *link
+		clone.Parameters = maps.Clone(link.Parameters)
+		response.Links[matches[2]] = &clone
+	}
+}
+
+// validateLinks reports response links whose operationId does not exist.
+// Operations and links under webhooks, callbacks and components count too.
+func (p *Parser) validateLinks() error {
+	type responseLink struct {
+		where, name, operationID string
+	}
+
+	operationIDs := make(map[string]bool)
+	var links []responseLink
+	oas.WalkV3(p.openapi, oas.V3Visitor{
+		Operation: func(_ oas.Location, op *openapi.Operation) {
+			operationIDs[op.OperationID] = true
+		},
+		Response: func(loc oas.Location, response *openapi.Response) {
+			where := operationLocation(loc)
+			if !strings.HasPrefix(where, "/") {
+				where += " response " + loc.Pointer[strings.LastIndex(loc.Pointer, "/")+1:]
+			}
+			for name, link := range response.Links {
+				if link != nil && link.OperationID != "" {
+					links = append(links, responseLink{where, name, link.OperationID})
+				}
+			}
+		},
+	})
+
+	var unknown []string
+	for _, link := range links {
+		if !operationIDs[link.operationID] {
+			unknown = append(unknown, fmt.Sprintf("%q on %s references %q", link.name, link.where, link.operationID))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	return fmt.Errorf("link to unknown operationId: %s", strings.Join(unknown, "; "))
+}
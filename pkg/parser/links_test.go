@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestParseLinks(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+// @Summary Create user
+// @ID createUser
+// @Link 201 GetUser getUser id=$response.body#/id "Fetch the created user"
+// @Link 201 ListPosts listPosts author=$response.body#/id limit=10
+// @Success 201 {string} string "Created"
+// @Router /users [post]
+func Create() {}
+
+// @Summary Get user
+// @ID getUser
+// @Param id path string true "User ID"
+// @Success 200 {string} string "OK"
+// @Router /users/{id} [get]
+func Get() {}
+
+// @Summary List posts
+// @ID listPosts
+// @Success 200 {string} string "OK"
+// @Router /posts [get]
+func ListPosts() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	response := p.openapi.Paths["/users"].Post.Responses["201"]
+	if response.Description != "Created" {
+		t.Errorf("201 description = %q, want Created", response.Description)
+	}
+
+	want := map[string]*openapi.Link{
+		"GetUser": {
+			OperationID: "getUser",
+			Parameters:  map[string]interface{}{"id": "$response.body#/id"},
+			Description: "Fetch the created user",
+		},
+		"ListPosts": {
+			OperationID: "listPosts",
+			Parameters:  map[string]interface{}{"author": "$response.body#/id", "limit": float64(10)},
+		},
+	}
+	if !reflect.DeepEqual(response.Links, want) {
+		t.Errorf("links = %+v, want %+v", response.Links, want)
+	}
+
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+}
+
+func TestProcessLink(t *testing.T) {
+	t.Parallel()
+	p := New()
+	proc := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	op := &openapi.Operation{Responses: make(openapi.Responses)}
+	proc.processLink(`@Link 200,201 Update updateUser requestBody=$request.body`, op)
+
+	for _, code := range []string{"200", "201"} {
+		link := op.Responses[code].Links["Update"]
+		if link == nil || link.OperationID != "updateUser" || link.RequestBody != "$request.body" || link.Parameters != nil {
+			t.Errorf("response %s link = %+v, want request body link to updateUser", code, link)
+		}
+	}
+
+	// Every status code gets its own Link
+	proc.processLink(`@Link 200,201 Self getUser id=$response.body#/id`, op)
+	op.Responses["200"].Links["Self"].Parameters["id"] = "changed"
+	if id := op.Responses["201"].Links["Self"].Parameters["id"]; id != "$response.body#/id" {
+		t.Errorf("201 link id = %v, editing the 200 link should leave it alone", id)
+	}
+}
+
+func TestValidateLinks(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.openapi.Info.Title, p.openapi.Info.Version = "API", "1.0.0"
+	p.openapi.Paths["/users"] = &openapi.PathItem{
+		Post: &openapi.Operation{
+			OperationID: "createUser",
+			Responses: openapi.Responses{
+				"201": {
+					Description: "Created",
+					Links:       map[string]*openapi.Link{"GetUser": {OperationID: "getUser"}},
+				},
+			},
+		},
+	}
+
+	err := p.Validate()
+	if err == nil || !strings.Contains(err.Error(), `"GetUser" on POST /users response 201 references "getUser"`) {
+		t.Errorf("Validate() error = %v, want unknown operationId", err)
+	}
+}
+
+func TestValidateLinksWebhooksAndCallbacks(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.openapi.Info.Title, p.openapi.Info.Version = "API", "1.0.0"
+	p.openapi.Paths["/subscribe"] = &openapi.PathItem{
+		Post: &openapi.Operation{
+			OperationID: "subscribe",
+			Responses: openapi.Responses{
+				"201": {Description: "Created", Links: map[string]*openapi.Link{"Ack": {OperationID: "acknowledge"}}},
+			},
+			Callbacks: map[string]*openapi.Callback{
+				"onEvent": {"{$request.body#/url}": {Post: &openapi.Operation{
+					OperationID: "acknowledge",
+					Responses: openapi.Responses{
+						"200": {Description: "OK", Links: map[string]*openapi.Link{"Next": {OperationID: "missing"}}},
+					},
+				}}},
+			},
+		},
+	}
+	p.openapi.Webhooks = map[string]*openapi.PathItem{
+		"event": {Post: &openapi.Operation{Responses: openapi.Responses{
+			"200": {Description: "OK", Links: map[string]*openapi.Link{"Back": {OperationID: "subscribe"}}},
+		}}},
+	}
+
+	err := p.validateLinks()
+	if err == nil {
+		t.Fatal("validateLinks() should report the callback link to a missing operation")
+	}
+	want := `link to unknown operationId: "Next" on /paths/~1subscribe/post/callbacks/onEvent/{$request.body#~1url}/post/responses/200 references "missing"`
+	if err.Error() != want {
+		t.Errorf("validateLinks() error = %q, want %q", err, want)
+	}
+}
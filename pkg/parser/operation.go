@@ -3,6 +3,7 @@ package parser
 import (
 	"go/ast"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -11,9 +12,13 @@ import (
 
 // OperationProcessor processes operation annotations from function comments.
 type OperationProcessor struct {
-	parser    *Parser
-	openapi   *openapi.OpenAPI
-	typeCache map[string]*TypeInfo
+	parser      *Parser
+	openapi     *openapi.OpenAPI
+	typeCache   map[string]*TypeInfo
+	consumes    []string            // Content types from @Accept for the current operation
+	produces    []string            // Content types from @Produce for the current operation
+	pathParams  []openapi.Parameter // @PathParam parameters shared by the operation's path items
+	pathServers []openapi.Server    // @PathServer servers of the operation's path items
 }
 
 // RouteInfo contains routing information for an operation.
@@ -31,6 +36,19 @@ func NewOperationProcessor(p *Parser, spec *openapi.OpenAPI, typeCache map[strin
 	}
 }
 
+// Response status codes: 200, 5XX, default, or a comma-separated list.
+const (
+	statusDefault         = "default"
+	statusCodePattern     = `(?:\d{3}|[1-5][xX]{2}|default)`
+	statusCodeListPattern = statusCodePattern + `(?:,` + statusCodePattern + `)*`
+)
+
+const (
+	securityNone    = "none"
+	typeFile        = "file"
+	mimeOctetStream = "application/octet-stream"
+)
+
 var (
 	// Operation-level annotations.
 	summaryOpRegex     = regexp.MustCompile(`^@Summary\s+(.+)$`)
@@ -43,24 +61,25 @@ var (
 	stateRegex         = regexp.MustCompile(`^@State\s+(.+)$`)
 
 	// Router annotation.
-	routerRegex = regexp.MustCompile(`^@Router\s+(\S+)\s+\[(\w+)\]`)
+	routerRegex = regexp.MustCompile(`^@Router\s+(\S+)\s+\[(\w+(?:\s*,\s*\w+)*)\]`)
 
 	// Parameter annotations.
-	paramRegex = regexp.MustCompile(`^@Param\s+(\S+)\s+(\w+)\s+(\S+)\s+(true|false)\s+"([^"]*)"(?:\s+(.+))?`)
+	pathParamRegex = regexp.MustCompile(`^@PathParam\s+(.+)$`)
+	paramRegex     = regexp.MustCompile(`^@Param\s+(\S+)\s+(\w+)\s+(\S+)\s+(true|false)\s+"([^"]*)"(?:\s+(.+))?`)
 
 	// Response annotations.
-	successRegex  = regexp.MustCompile(`^@Success\s+(\d+)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?`)
-	failureRegex  = regexp.MustCompile(`^@Failure\s+(\d+)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?`)
-	responseRegex = regexp.MustCompile(`^@Response\s+(\d+)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?`)
+	successRegex  = regexp.MustCompile(`^@Success\s+(` + statusCodeListPattern + `)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?(?:\s+(.+))?`)
+	failureRegex  = regexp.MustCompile(`^@Failure\s+(` + statusCodeListPattern + `)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?(?:\s+(.+))?`)
+	responseRegex = regexp.MustCompile(`^@Response\s+(` + statusCodeListPattern + `)\s+\{(\w+)\}\s+(\S+)(?:\s+"([^"]*)")?(?:\s+(.+))?`)
 
 	// OpenAPI 3.2.0: Streaming response annotation.
 	streamSuccessRegex = regexp.MustCompile(`^@Success\s+(\d+)\s+\{stream\}\s+(\S+)(?:\s+"([^"]*)")?`)
 
 	// Header annotation.
-	headerRegex = regexp.MustCompile(`^@Header\s+(\d+)\s+\{(\w+)\}\s+(\S+)\s+"([^"]*)"`)
+	headerRegex = regexp.MustCompile(`^@Header\s+(all|` + statusCodeListPattern + `)\s+\{(\w+)\}\s+(\S+)\s+"([^"]*)"`)
 
 	// Security annotation - capture name before [ and scopes inside [].
-	securityOpRegex = regexp.MustCompile(`^@Security\s+([^\[\s]+)(?:\[([^\]]+)\])?`)
+	securityOpRegex = regexp.MustCompile(`^@Security\s+(.+)$`)
 
 	// Callback annotation.
 	callbackRegex = regexp.MustCompile(`^@Callback\s+(\S+)\s+(\S+)\s+\[(\w+)\]`)
@@ -68,6 +87,7 @@ var (
 	// Extension annotations.
 	xCodeSamplesRegex = regexp.MustCompile(`^@x-codeSamples\s+(.+)$`)
 	xVisibilityRegex  = regexp.MustCompile(`^@x-visibility\s+(public|private)$`)
+	xAudienceRegex    = regexp.MustCompile(`^@x-audience\s+([\w-]+(?:\s*,\s*[\w-]+)*)$`)
 )
 
 // Process processes function documentation and returns an Operation.
@@ -75,8 +95,10 @@ func (o *OperationProcessor) Process(doc *ast.CommentGroup) *openapi.Operation {
 	op := &openapi.Operation{
 		Responses: make(openapi.Responses),
 	}
+	o.consumes, o.produces, o.pathParams, o.pathServers = nil, nil, nil, nil
 
 	hasAnnotations := false
+	var headers, links []string
 
 	for _, comment := range doc.List {
 		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
@@ -108,12 +130,28 @@ func (o *OperationProcessor) Process(doc *ast.CommentGroup) *openapi.Operation {
 		case paramRegex.MatchString(text):
 			o.processParameter(text, op)
 
-		case successRegex.MatchString(text):
-			o.processResponse(text, successRegex, op)
+		case pathParamRegex.MatchString(text):
+			o.processPathParameter(text)
+
+		case operationServerRegex.MatchString(text):
+			op.Servers = append(op.Servers, parseServer(operationServerRegex, text))
+
+		case pathServerRegex.MatchString(text):
+			o.pathServers = append(o.pathServers, parseServer(pathServerRegex, text))
+
+		case serverVariableRegex.MatchString(text):
+			if strings.HasPrefix(text, "@PathServer") {
+				addServerVariable(o.pathServers, text)
+			} else {
+				addServerVariable(op.Servers, text)
+			}
 
 		case streamSuccessRegex.MatchString(text):
 			o.processStreamResponse(text, op)
 
+		case successRegex.MatchString(text):
+			o.processResponse(text, successRegex, op)
+
 		case failureRegex.MatchString(text):
 			o.processResponse(text, failureRegex, op)
 
@@ -121,7 +159,12 @@ func (o *OperationProcessor) Process(doc *ast.CommentGroup) *openapi.Operation {
 			o.processResponse(text, responseRegex, op)
 
 		case headerRegex.MatchString(text):
-			o.processHeader(text, op)
+			// Applied once every response is known, so "all" and
+			// headers declared before their response work
+			headers = append(headers, text)
+
+		case linkRegex.MatchString(text):
+			links = append(links, text)
 
 		case securityOpRegex.MatchString(text):
 			o.processSecurity(text, op)
@@ -140,6 +183,13 @@ func (o *OperationProcessor) Process(doc *ast.CommentGroup) *openapi.Operation {
 
 		case xVisibilityRegex.MatchString(text):
 			o.processVisibility(text, op)
+
+		case xAudienceRegex.MatchString(text):
+			o.processAudience(text, op)
+
+		case extensionRegex.MatchString(text):
+			matches := extensionRegex.FindStringSubmatch(text)
+			op.Extensions = setExtension(op.Extensions, matches[1], parseExtensionValue(matches[2]))
 		}
 	}
 
@@ -147,22 +197,43 @@ func (o *OperationProcessor) Process(doc *ast.CommentGroup) *openapi.Operation {
 		return nil
 	}
 
+	for _, text := range headers {
+		o.processHeader(text, op)
+	}
+	for _, text := range links {
+		o.processLink(text, op)
+	}
+
 	return op
 }
 
-// GetRouteInfo extracts routing information from function documentation.
+// GetRouteInfo extracts the first route from function documentation.
 func (o *OperationProcessor) GetRouteInfo(doc *ast.CommentGroup) RouteInfo {
+	routes := o.GetRoutes(doc)
+	if len(routes) == 0 {
+		return RouteInfo{}
+	}
+	return routes[0]
+}
+
+// GetRoutes extracts every route from function documentation. Each @Router
+// line may list several methods: @Router /users [get,head].
+func (o *OperationProcessor) GetRoutes(doc *ast.CommentGroup) []RouteInfo {
+	var routes []RouteInfo
 	for _, comment := range doc.List {
 		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
-		if routerRegex.MatchString(text) {
-			matches := routerRegex.FindStringSubmatch(text)
-			return RouteInfo{
+		matches := routerRegex.FindStringSubmatch(text)
+		if matches == nil {
+			continue
+		}
+		for _, method := range strings.Split(matches[2], ",") {
+			routes = append(routes, RouteInfo{
 				Path:   matches[1],
-				Method: matches[2],
-			}
+				Method: strings.TrimSpace(method),
+			})
 		}
 	}
-	return RouteInfo{}
+	return routes
 }
 
 // processSummary processes @Summary annotation.
@@ -218,6 +289,7 @@ func (o *OperationProcessor) processAccept(text string, op *openapi.Operation) {
 
 	// Store accepted content types for later use in RequestBody
 	contentTypes := o.parseMimeTypes(matches[1])
+	o.consumes = contentTypes
 
 	// Store in operation metadata for use when creating RequestBody
 	if op.RequestBody != nil && len(contentTypes) > 0 {
@@ -252,6 +324,7 @@ func (o *OperationProcessor) processProduce(text string, op *openapi.Operation)
 
 	// Store produced content types for later use in Responses
 	contentTypes := o.parseMimeTypes(matches[1])
+	o.produces = contentTypes
 
 	// Store in operation for use when creating responses
 	// We'll use this when processing @Success/@Failure annotations
@@ -351,6 +424,9 @@ func (o *OperationProcessor) processParameter(text string, op *openapi.Operation
 	// Handle body parameter (request body in OpenAPI 3.x)
 	if in == "body" {
 		o.processRequestBody(schemaType, required, description, op)
+		if extensions, ok := attributes["extensions"]; ok {
+			op.RequestBody.Extensions = mergeExtensions(op.RequestBody.Extensions, parseExtensionList(extensions))
+		}
 		return
 	}
 
@@ -375,6 +451,21 @@ func (o *OperationProcessor) processParameter(text string, op *openapi.Operation
 	op.Parameters = append(op.Parameters, param)
 }
 
+// processPathParameter processes @PathParam, which has the @Param syntax but
+// is written to PathItem.Parameters instead of the operation.
+// Example: @PathParam id path int true "User ID"
+func (o *OperationProcessor) processPathParameter(text string) {
+	matches := pathParamRegex.FindStringSubmatch(text)
+	shadow := &openapi.Operation{}
+	o.processParameter("@Param "+matches[1], shadow)
+
+	for _, param := range shadow.Parameters {
+		if !hasParameter(o.pathParams, param.Name, param.In) {
+			o.pathParams = append(o.pathParams, param)
+		}
+	}
+}
+
 // processRequestBody processes body parameter as RequestBody.
 func (o *OperationProcessor) processRequestBody(schemaType string, required bool, description string, op *openapi.Operation) {
 	// Register referenced type
@@ -388,22 +479,36 @@ func (o *OperationProcessor) processRequestBody(schemaType string, required bool
 		}
 	}
 
-	mediaType := &openapi.MediaType{
-		Schema: o.parseSchemaType(schemaType),
+	schema := o.parseSchemaType(schemaType)
+	for _, contentType := range contentTypesOrJSON(o.consumes) {
+		op.RequestBody.Content[contentType] = &openapi.MediaType{
+			Schema: schema,
+		}
 	}
+}
 
-	// Default to application/json
-	op.RequestBody.Content["application/json"] = mediaType
+// contentTypesOrJSON returns the declared content types, defaulting to application/json.
+func contentTypesOrJSON(contentTypes []string) []string {
+	if len(contentTypes) == 0 {
+		return []string{"application/json"}
+	}
+	return contentTypes
 }
 
 // processResponse processes @Success, @Failure, and @Response annotations.
+// Examples:
+//   - @Success 200 {object} User "OK"
+//   - @Failure 400,404 {object} Error
+//   - @Failure default {object} Error "Unexpected error"
+//   - @Success 200 {string} string "Plain text"
+//   - @Success 200 {file} file "Download"
 func (o *OperationProcessor) processResponse(text string, regex *regexp.Regexp, op *openapi.Operation) {
 	matches := regex.FindStringSubmatch(text)
 	if len(matches) < 4 {
 		return
 	}
 
-	statusCode := matches[1]
+	statusCodes := parseStatusCodes(matches[1])
 	responseType := matches[2]
 	schemaRef := matches[3]
 	description := "Success"
@@ -411,16 +516,42 @@ func (o *OperationProcessor) processResponse(text string, regex *regexp.Regexp,
 		description = matches[4]
 	}
 
-	// Register referenced type
-	o.parser.AddReferencedType(schemaRef)
+	var extensions map[string]interface{}
+	// Trailing attributes: extensions(x-name=value,...)
+	if len(matches) > 5 && matches[5] != "" {
+		if list, ok := o.parseAttributes(matches[5])["extensions"]; ok {
+			extensions = parseExtensionList(list)
+		}
+	}
 
-	response := &openapi.Response{
-		Description: description,
+	schema, contentTypes := o.responseSchema(responseType, schemaRef)
+
+	for _, statusCode := range statusCodes {
+		response := &openapi.Response{
+			Description: description,
+			Extensions:  extensions,
+		}
+
+		if schema != nil {
+			response.Content = make(map[string]*openapi.MediaType, len(contentTypes))
+			for _, contentType := range contentTypes {
+				response.Content[contentType] = &openapi.MediaType{
+					Schema: schema,
+				}
+			}
+		}
+
+		op.Responses[statusCode] = response
 	}
+}
 
-	// Add content if schema is specified
-	if responseType == typeObject || responseType == typeArray {
-		content := make(map[string]*openapi.MediaType)
+// responseSchema returns the schema and content types of a response body,
+// or a nil schema when the response has no body.
+func (o *OperationProcessor) responseSchema(responseType, schemaRef string) (*openapi.Schema, []string) {
+	switch responseType {
+	case typeObject, typeArray:
+		// Register referenced type
+		o.parser.AddReferencedType(schemaRef)
 
 		schema := o.parseSchemaType(schemaRef)
 		if responseType == typeArray {
@@ -429,14 +560,39 @@ func (o *OperationProcessor) processResponse(text string, regex *regexp.Regexp,
 				Items: schema,
 			}
 		}
+		return schema, contentTypesOrJSON(o.produces)
 
-		content["application/json"] = &openapi.MediaType{
-			Schema: schema,
+	case typeString, typeInteger, typeNumber, typeBoolean:
+		// {integer} int64 refines the format; unknown names fall back to the type
+		schema := o.parseSchemaType(schemaRef)
+		if schema.Type != responseType {
+			schema = o.parseSchemaType(responseType)
+		}
+		return schema, contentTypesOrJSON(o.produces)
+
+	case typeFile:
+		contentTypes := o.produces
+		if len(contentTypes) == 0 {
+			contentTypes = []string{mimeOctetStream}
 		}
-		response.Content = content
+		return o.parseSchemaType(typeFile), contentTypes
 	}
 
-	op.Responses[statusCode] = response
+	return nil, nil
+}
+
+// parseStatusCodes splits a comma-separated status code list and normalizes
+// ranges to upper case (5xx → 5XX).
+func parseStatusCodes(list string) []string {
+	codes := strings.Split(list, ",")
+	for i, code := range codes {
+		code = strings.TrimSpace(code)
+		if code != statusDefault {
+			code = strings.ToUpper(code)
+		}
+		codes[i] = code
+	}
+	return codes
 }
 
 // processStreamResponse processes @Success with {stream} type (OpenAPI 3.2.0).
@@ -477,58 +633,64 @@ func (o *OperationProcessor) processStreamResponse(text string, op *openapi.Oper
 }
 
 // processHeader processes @Header annotation.
+// Examples:
+//   - @Header 200 {string} X-Request-ID "Request identifier"
+//   - @Header 200,201 {string} Location "Resource URL"
+//   - @Header all {string} X-API-Version "API version"
 func (o *OperationProcessor) processHeader(text string, op *openapi.Operation) {
 	matches := headerRegex.FindStringSubmatch(text)
 	if len(matches) < 5 {
 		return
 	}
 
-	statusCode := matches[1]
 	headerType := matches[2]
 	headerName := matches[3]
 	description := matches[4]
 
-	response := op.Responses[statusCode]
-	if response == nil {
-		response = &openapi.Response{
-			Description: "Response " + statusCode,
-			Headers:     make(map[string]*openapi.Header),
+	var statusCodes []string
+	if matches[1] == "all" {
+		for statusCode := range op.Responses {
+			statusCodes = append(statusCodes, statusCode)
 		}
-		op.Responses[statusCode] = response
+	} else {
+		statusCodes = parseStatusCodes(matches[1])
 	}
 
-	if response.Headers == nil {
-		response.Headers = make(map[string]*openapi.Header)
-	}
+	for _, statusCode := range statusCodes {
+		response := op.Responses[statusCode]
+		if response == nil {
+			response = &openapi.Response{
+				Description: "Response " + statusCode,
+			}
+			op.Responses[statusCode] = response
+		}
 
-	response.Headers[headerName] = &openapi.Header{
-		Description: description,
-		Schema:      o.parseSchemaType(headerType),
+		if response.Headers == nil {
+			response.Headers = make(map[string]*openapi.Header)
+		}
+
+		response.Headers[headerName] = &openapi.Header{
+			Description: description,
+			Schema:      o.parseSchemaType(headerType),
+		}
 	}
 }
 
-// processSecurity processes @Security annotation.
+// processSecurity processes @Security annotation. Each annotation adds
+// alternative requirements; see parseSecurityRequirements for "&&" and "||".
 func (o *OperationProcessor) processSecurity(text string, op *openapi.Operation) {
 	matches := securityOpRegex.FindStringSubmatch(text)
 	if len(matches) < 2 {
 		return
 	}
 
-	schemeName := matches[1]
-	var scopes []string
-
-	if len(matches) > 2 && matches[2] != "" {
-		scopeList := strings.Split(matches[2], ",")
-		for _, scope := range scopeList {
-			scopes = append(scopes, strings.TrimSpace(scope))
-		}
-	}
-
-	security := openapi.SecurityRequirement{
-		schemeName: scopes,
+	// @Security none: explicit empty requirement, no default security applies
+	if strings.TrimSpace(matches[1]) == securityNone {
+		op.Security = []openapi.SecurityRequirement{}
+		return
 	}
 
-	op.Security = append(op.Security, security)
+	op.Security = append(op.Security, parseSecurityRequirements(matches[1])...)
 }
 
 // parseAttributes parses additional parameter attributes.
@@ -560,12 +722,12 @@ func (o *OperationProcessor) applyParameterAttributes(param *openapi.Parameter,
 		switch key {
 		case "minimum", "min":
 			if minVal, err := strconv.ParseFloat(value, 64); err == nil {
-				param.Schema.Minimum = minVal
+				param.Schema.Minimum = &minVal
 			}
 
 		case "maximum", "max":
 			if maxVal, err := strconv.ParseFloat(value, 64); err == nil {
-				param.Schema.Maximum = maxVal
+				param.Schema.Maximum = &maxVal
 			}
 
 		case "exclusiveminimum":
@@ -650,6 +812,9 @@ func (o *OperationProcessor) applyParameterAttributes(param *openapi.Parameter,
 
 		case "allowemptyvalue":
 			param.AllowEmptyValue = value == valueTrue
+
+		case "extensions":
+			param.Extensions = mergeExtensions(param.Extensions, parseExtensionList(value))
 		}
 	}
 }
@@ -786,7 +951,7 @@ func (o *OperationProcessor) parseSchemaType(typeName string) *openapi.Schema {
 	case formatDateTime, "time.Time":
 		schema.Type = typeString
 		schema.Format = formatDateTime
-	case "file":
+	case typeFile:
 		schema.Type = typeString
 		schema.Format = formatBinary
 	case typeObject:
@@ -794,6 +959,14 @@ func (o *OperationProcessor) parseSchemaType(typeName string) *openapi.Schema {
 	case typeArray:
 		schema.Type = typeArray
 	default:
+		// Check for type override from the overrides file
+		if override, exists := o.parser.GetTypeOverride(typeName); exists {
+			return NewSchemaProcessor(o.parser, o.openapi, o.typeCache).parseOverrideType(override)
+		}
+		// Well-known and registered types are rendered inline
+		if known, ok := o.parser.LookupType(typeName); ok {
+			return known
+		}
 		// Assume it's a reference to a schema
 		schema.Ref = "#/components/schemas/" + typeName
 	}
@@ -834,6 +1007,35 @@ func (o *OperationProcessor) processVisibility(text string, op *openapi.Operatio
 	op.Extensions["x-visibility"] = visibility
 }
 
+// processAudience processes @x-audience annotation.
+// Format: @x-audience name[,name...]
+// Example: @x-audience partner,internal.
+func (o *OperationProcessor) processAudience(text string, op *openapi.Operation) {
+	matches := xAudienceRegex.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return
+	}
+
+	audiences := splitAudiences(matches[1])
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]interface{})
+	}
+	op.Extensions["x-audience"] = audiences
+}
+
+// splitAudiences splits a comma-separated audience list, dropping blanks
+// and duplicates.
+func splitAudiences(list string) []string {
+	var audiences []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(audiences, name) {
+			audiences = append(audiences, name)
+		}
+	}
+	return audiences
+}
+
 // processCodeSamples processes @x-codeSamples annotation.
 // Format: @x-codeSamples lang:filename
 // Example: @x-codeSamples go:examples/create_user.go.
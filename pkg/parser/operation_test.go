@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"go/ast"
 	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
 	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
@@ -1206,3 +1209,131 @@ func TestProcessCallbackMultiple(t *testing.T) {
 		t.Errorf("Expected hook2 to have 1 expression, got %d", len(*hook2))
 	}
 }
+
+func TestProcessProduceBeforeResponse(t *testing.T) {
+	t.Parallel()
+	p := New()
+	proc := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	doc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// @Accept xml"},
+		{Text: "// @Produce json,xml"},
+		{Text: "// @Param book body object true \"Book\""},
+		{Text: "// @Success 200 {object} object"},
+		{Text: "// @Router /books [post]"},
+	}}
+	op := proc.Process(doc)
+
+	if _, ok := op.RequestBody.Content["text/xml"]; !ok || len(op.RequestBody.Content) != 1 {
+		t.Errorf("request body content = %v, want only text/xml", keys(op.RequestBody.Content))
+	}
+	assertStringSet(t, "response content", keys(op.Responses["200"].Content), []string{"application/json", "text/xml"})
+}
+
+func TestProcessResponseStatusCodes(t *testing.T) {
+	t.Parallel()
+	p := New()
+	proc := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	doc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// @Header all {string} X-Request-ID \"Request identifier\""},
+		{Text: "// @Success 200 {string} string \"Plain text\""},
+		{Text: "// @Success 201 {integer} int64 \"Created ID\""},
+		{Text: "// @Success 206 {file} file \"Partial download\""},
+		{Text: "// @Failure 400,404,422 {object} Error \"Client error\""},
+		{Text: "// @Failure 5xx {object} Error \"Server error\""},
+		{Text: "// @Failure default {object} Error"},
+		{Text: "// @Header 200,201 {string} Location \"Resource URL\""},
+		{Text: "// @Router /items [post]"},
+	}}
+	op := proc.Process(doc)
+
+	assertStringSet(t, "status codes", keys(op.Responses),
+		[]string{"200", "201", "206", "400", "404", "422", "5XX", "default"})
+
+	tests := []struct {
+		code        string
+		contentType string
+		schemaType  string
+		format      string
+		ref         string
+		headers     []string
+	}{
+		{code: "200", contentType: "application/json", schemaType: typeString, headers: []string{"Location", "X-Request-ID"}},
+		{code: "201", contentType: "application/json", schemaType: typeInteger, format: formatInt64, headers: []string{"Location", "X-Request-ID"}},
+		{code: "206", contentType: "application/octet-stream", schemaType: typeString, format: formatBinary, headers: []string{"X-Request-ID"}},
+		{code: "404", contentType: "application/json", ref: "#/components/schemas/Error", headers: []string{"X-Request-ID"}},
+		{code: "5XX", contentType: "application/json", ref: "#/components/schemas/Error", headers: []string{"X-Request-ID"}},
+		{code: "default", contentType: "application/json", ref: "#/components/schemas/Error", headers: []string{"X-Request-ID"}},
+	}
+
+	for _, tt := range tests {
+		response := op.Responses[tt.code]
+		media, ok := response.Content[tt.contentType]
+		if !ok {
+			t.Errorf("%s content = %v, want %s", tt.code, keys(response.Content), tt.contentType)
+			continue
+		}
+		schema := media.Schema
+		if schema.Ref != tt.ref || (tt.ref == "" && (schema.Type != tt.schemaType || schema.Format != tt.format)) {
+			t.Errorf("%s schema = %+v, want type %q format %q ref %q", tt.code, schema, tt.schemaType, tt.format, tt.ref)
+		}
+		assertStringSet(t, tt.code+" headers", keys(response.Headers), tt.headers)
+	}
+
+	if op.Responses["400"] == op.Responses["404"] {
+		t.Error("status codes on one line should get separate responses")
+	}
+}
+
+func TestParseStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		list string
+		want []string
+	}{
+		{list: "200", want: []string{"200"}},
+		{list: "400,404", want: []string{"400", "404"}},
+		{list: "4xx,default", want: []string{"4XX", "default"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			got := parseStatusCodes(tt.list)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseStatusCodes(%q) = %v, want %v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessAudience(t *testing.T) {
+	t.Parallel()
+	p := New()
+	proc := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "single", text: "@x-audience partner", expected: []string{"partner"}},
+		{name: "list", text: "@x-audience partner, internal,mobile-app", expected: []string{"partner", "internal", "mobile-app"}},
+		{name: "duplicates", text: "@x-audience partner,partner", expected: []string{"partner"}},
+		{name: "invalid", text: "@x-audience", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &openapi.Operation{}
+			proc.processAudience(tt.text, op)
+
+			got, _ := op.Extensions["x-audience"].([]string)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("x-audience = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
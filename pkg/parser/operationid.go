@@ -0,0 +1,179 @@
+// Package parser - Operation ID generation
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+	"unicode"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Operation ID strategies. Any strategy containing "{" is a template with
+// the {func}, {receiver}, {method}, {path} and {tag} placeholders.
+const (
+	operationIDNone           = "none"
+	operationIDFuncName       = "funcName"       // GetUser
+	operationIDReceiverMethod = "receiverMethod" // UserHandler.Get
+	operationIDMethodPath     = "methodPath"     // get_users_id
+)
+
+// Operation ID casings.
+const (
+	operationIDCaseNone   = "none"
+	operationIDCaseCamel  = "camel"
+	operationIDCasePascal = "pascal"
+	operationIDCaseSnake  = "snake"
+	operationIDCaseKebab  = "kebab"
+)
+
+// generateOperationID derives an operation ID from the configured strategy,
+// or returns "" when generation is disabled.
+func (p *Parser) generateOperationID(funcDecl *ast.FuncDecl, route RouteInfo, op *openapi.Operation) string {
+	funcName := funcDecl.Name.Name
+	receiver := ""
+	if funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0 {
+		receiver = receiverTypeName(funcDecl.Recv.List[0].Type)
+	}
+	method := strings.ToLower(route.Method)
+	path := pathWords(route.Path)
+	tag := ""
+	if len(op.Tags) > 0 {
+		tag = op.Tags[0]
+	}
+
+	var id string
+	switch {
+	case strings.Contains(p.operationIDStrategy, "{"):
+		id = strings.NewReplacer(
+			"{func}", funcName,
+			"{receiver}", receiver,
+			"{method}", method,
+			"{path}", path,
+			"{tag}", tag,
+		).Replace(p.operationIDStrategy)
+	case strings.EqualFold(p.operationIDStrategy, operationIDFuncName):
+		id = funcName
+	case strings.EqualFold(p.operationIDStrategy, operationIDReceiverMethod):
+		id = funcName
+		if receiver != "" {
+			id = receiver + "." + funcName
+		}
+	case strings.EqualFold(p.operationIDStrategy, operationIDMethodPath):
+		id = method + "_" + path
+	default:
+		return ""
+	}
+
+	return applyOperationIDCase(id, p.operationIDCase)
+}
+
+// pathWords turns a route path into underscore separated words:
+// /users/{id}/posts → users_id_posts.
+func pathWords(path string) string {
+	return strings.Join(splitWords(path), "_")
+}
+
+// applyOperationIDCase re-joins the words of an ID in the requested casing.
+func applyOperationIDCase(id, casing string) string {
+	words := splitWords(id)
+	if len(words) == 0 {
+		return id
+	}
+
+	casing = strings.ToLower(casing)
+	switch casing {
+	case operationIDCaseCamel, operationIDCasePascal:
+		var b strings.Builder
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 || casing == operationIDCasePascal {
+				word = strings.ToUpper(word[:1]) + word[1:]
+			}
+			b.WriteString(word)
+		}
+		return b.String()
+	case operationIDCaseSnake:
+		return strings.ToLower(strings.Join(words, "_"))
+	case operationIDCaseKebab:
+		return strings.ToLower(strings.Join(words, "-"))
+	}
+
+	return id
+}
+
+// splitWords splits an identifier or path at non-alphanumeric characters and
+// lower-to-upper case boundaries, keeping acronyms together (HTTPServer →
+// HTTP, Server).
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// operationLocation describes where an operation, or a node below it, is
+// declared: "GET /users" under a path, its JSON pointer under webhooks,
+// callbacks and components.
+func operationLocation(loc oas.Location) string {
+	if loc.Webhook || !strings.HasPrefix(loc.Pointer, "/paths/") || strings.Contains(loc.Pointer, "/callbacks/") {
+		return loc.Pointer
+	}
+	return strings.ToUpper(loc.Method) + " " + loc.Path
+}
+
+// validateOperationIDs reports operation IDs used by more than one operation.
+func (p *Parser) validateOperationIDs() error {
+	locations := make(map[string][]string)
+	oas.WalkV3(p.openapi, oas.V3Visitor{
+		Operation: func(loc oas.Location, op *openapi.Operation) {
+			if op.OperationID != "" {
+				locations[op.OperationID] = append(locations[op.OperationID], operationLocation(loc))
+			}
+		},
+	})
+
+	var duplicates []string
+	for id, routes := range locations {
+		if len(routes) > 1 {
+			sort.Strings(routes)
+			duplicates = append(duplicates, fmt.Sprintf("%q is used by %s", id, strings.Join(routes, ", ")))
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+	return fmt.Errorf("duplicate operationId: %s", strings.Join(duplicates, "; "))
+}
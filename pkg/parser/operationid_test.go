@@ -0,0 +1,198 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+const operationIDTestSource = `package main
+
+// @title Test API
+// @version 1.0.0
+
+type UserHandler struct{}
+
+// @Tags users
+// @Success 200 {string} string "OK"
+// @Router /users/{id} [get]
+func (h *UserHandler) GetUser() {}
+
+// @Tags users
+// @Success 200 {string} string "OK"
+// @Router /users [get]
+// @Router /people [get]
+func ListUsers() {}
+
+// @ID custom_id
+// @Success 200 {string} string "OK"
+// @Router /health [get]
+func Health() {}
+`
+
+func TestGenerateOperationIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		strategy string
+		casing   string
+		want     map[string]string // path → operationId of its GET operation
+	}{
+		{
+			name:     "none",
+			strategy: "none",
+			want:     map[string]string{"/users/{id}": "", "/users": "", "/people": "", "/health": "custom_id"},
+		},
+		{
+			name:     "func name",
+			strategy: "funcName",
+			want:     map[string]string{"/users/{id}": "GetUser", "/users": "ListUsers", "/people": "ListUsers_2", "/health": "custom_id"},
+		},
+		{
+			name:     "receiver method camel",
+			strategy: "receiverMethod",
+			casing:   "camel",
+			want:     map[string]string{"/users/{id}": "userHandlerGetUser", "/users": "listUsers", "/people": "listUsers2", "/health": "custom_id"},
+		},
+		{
+			name:     "method path",
+			strategy: "methodPath",
+			want:     map[string]string{"/users/{id}": "get_users_id", "/users": "get_users", "/people": "get_people", "/health": "custom_id"},
+		},
+		{
+			name:     "template kebab",
+			strategy: "{tag}_{func}",
+			casing:   "kebab",
+			want:     map[string]string{"/users/{id}": "users-get-user", "/users": "users-list-users", "/people": "users-list-users-2", "/health": "custom_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(operationIDTestSource), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := New()
+			if err := p.SetOperationIDStrategy(tt.strategy); err != nil {
+				t.Fatalf("SetOperationIDStrategy() returned error: %v", err)
+			}
+			if tt.casing != "" {
+				if err := p.SetOperationIDCase(tt.casing); err != nil {
+					t.Fatalf("SetOperationIDCase() returned error: %v", err)
+				}
+			}
+			if err := p.ParseDir(tmpDir); err != nil {
+				t.Fatalf("ParseDir() returned error: %v", err)
+			}
+			if err := p.Validate(); err != nil {
+				t.Fatalf("Validate() returned error: %v", err)
+			}
+
+			for path, want := range tt.want {
+				if got := p.openapi.Paths[path].Get.OperationID; got != want {
+					t.Errorf("%s operationId = %q, want %q", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDuplicateOperationIDs(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+// @ID getThing
+// @Success 200 {string} string "OK"
+// @Router /a [get]
+func A() {}
+
+// @ID getThing
+// @Success 200 {string} string "OK"
+// @Router /b [post]
+func B() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	err := p.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail on duplicate operation IDs")
+	}
+	for _, want := range []string{`"getThing"`, "GET /a", "POST /b"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %q, want it to mention %s", err, want)
+		}
+	}
+}
+
+func TestValidateOperationIDsWebhooksAndCallbacks(t *testing.T) {
+	t.Parallel()
+
+	notify := &openapi.Operation{OperationID: "notify"}
+	p := New()
+	p.openapi.Paths = openapi.Paths{
+		"/subscribe": {Post: &openapi.Operation{
+			OperationID: "subscribe",
+			Callbacks: map[string]*openapi.Callback{
+				"onEvent": {"{$request.body#/url}": {Post: notify}},
+			},
+		}},
+	}
+	p.openapi.Webhooks = map[string]*openapi.PathItem{
+		"event": {Post: &openapi.Operation{OperationID: "notify"}},
+	}
+
+	err := p.validateOperationIDs()
+	if err == nil {
+		t.Fatal("validateOperationIDs() should fail on duplicate IDs in webhooks and callbacks")
+	}
+	for _, want := range []string{`"notify"`, "/webhooks/event/post", "/paths/~1subscribe/post/callbacks/onEvent/{$request.body#~1url}/post"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validateOperationIDs() error = %q, want it to mention %s", err, want)
+		}
+	}
+}
+
+func TestApplyOperationIDCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id     string
+		casing string
+		want   string
+	}{
+		{id: "GetHTTPServer", casing: "camel", want: "getHttpServer"},
+		{id: "get_users_id", casing: "pascal", want: "GetUsersId"},
+		{id: "UserHandler.GetByID", casing: "snake", want: "user_handler_get_by_id"},
+		{id: "listV2Users", casing: "kebab", want: "list-v2-users"},
+		{id: "Keep.As_Is", casing: "none", want: "Keep.As_Is"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id+"/"+tt.casing, func(t *testing.T) {
+			t.Parallel()
+			if got := applyOperationIDCase(tt.id, tt.casing); got != tt.want {
+				t.Errorf("applyOperationIDCase(%q, %q) = %q, want %q", tt.id, tt.casing, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+// Package parser - Type overrides file
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// yamlOverridesRegex detects the structured YAML form of the overrides file.
+var yamlOverridesRegex = regexp.MustCompile(`^(---|(replace|skip|types)\s*:)`)
+
+// overrideSet holds the directives read from an overrides file.
+type overrideSet struct {
+	replace map[string]string          // Go type -> type name or inline JSON schema
+	skip    map[string]bool            // Go types omitted from the documentation
+	types   map[string]*openapi.Schema // Go type -> registered schema fragment
+}
+
+// loadTypeOverrides loads type overrides from file.
+// Three formats are accepted:
+//   - swag's .swaggo line format ("replace a.T b.U", "skip pkg.T", "// comment")
+//   - JSON: {"replace": {...}, "skip": [...], "types": {...}}
+//   - YAML with the same keys
+//
+// Replace targets may be a type name or an inline JSON schema.
+func (p *Parser) loadTypeOverrides() error {
+	if p.overridesFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.overridesFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// No overrides file, nothing to load
+			return nil
+		}
+		return fmt.Errorf("failed to read overrides file %s: %w", p.overridesFile, err)
+	}
+
+	overrides, err := parseOverrides(p.overridesFile, data)
+	if err != nil {
+		return err
+	}
+
+	for typeName, target := range overrides.replace {
+		p.typeOverrides[typeName] = target
+	}
+	for typeName := range overrides.skip {
+		p.skipTypes[typeName] = true
+	}
+	for typeName, schema := range overrides.types {
+		p.RegisterType(typeName, schema)
+	}
+
+	return nil
+}
+
+// parseOverrides detects the overrides file format and parses it.
+func parseOverrides(path string, data []byte) (*overrideSet, error) {
+	switch first := firstSignificantLine(data); {
+	case strings.HasPrefix(first, "{"):
+		return parseOverridesJSON(path, data)
+	case yamlOverridesRegex.MatchString(first):
+		return parseOverridesYAML(path, data)
+	default:
+		return parseOverridesLines(path, data)
+	}
+}
+
+// firstSignificantLine returns the first line that is neither blank nor a comment.
+func firstSignificantLine(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+// parseOverridesLines parses the .swaggo line format.
+func parseOverridesLines(path string, data []byte) (*overrideSet, error) {
+	overrides := newOverrideSet()
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		directive, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch directive {
+		case "replace":
+			source, target, _ := strings.Cut(rest, " ")
+			target = strings.TrimSpace(target)
+			if source == "" || target == "" {
+				return nil, fmt.Errorf("%s:%d: replace requires a source and a target type", path, lineNo)
+			}
+			if err := overrides.addReplace(source, target); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+		case "skip":
+			if rest == "" || strings.Contains(rest, " ") {
+				return nil, fmt.Errorf("%s:%d: skip requires exactly one type", path, lineNo)
+			}
+			overrides.skip[rest] = true
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown directive %q (expected replace or skip)", path, lineNo, directive)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read overrides file %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// parseOverridesJSON parses the JSON form of the overrides file.
+func parseOverridesJSON(path string, data []byte) (*overrideSet, error) {
+	var config interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line := 1 + bytes.Count(data[:syntaxErr.Offset], []byte("\n"))
+			return nil, fmt.Errorf("%s:%d: invalid JSON: %w", path, line, err)
+		}
+		return nil, fmt.Errorf("%s: invalid JSON: %w", path, err)
+	}
+
+	// JSON is valid YAML; decoding it as such keeps the line of every entry
+	return parseOverridesYAML(path, data)
+}
+
+// parseOverridesYAML parses the YAML form of the overrides file.
+func parseOverridesYAML(path string, data []byte) (*overrideSet, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		// yaml.v3 errors already carry "line N"
+		return nil, fmt.Errorf("%s: invalid YAML: %w", path, err)
+	}
+
+	overrides := newOverrideSet()
+	if len(doc.Content) == 0 {
+		return overrides, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: expected a mapping with replace, skip or types keys", path, root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		var err error
+		switch key.Value {
+		case "replace":
+			err = addOverrideNodes(path, key.Value, value, overrides.addReplaceNode)
+		case "types":
+			err = addOverrideNodes(path, key.Value, value, overrides.addTypeNode)
+		case "skip":
+			err = overrides.addSkipNodes(path, value)
+		default:
+			err = fmt.Errorf("%s:%d: unknown key %q (expected replace, skip or types)", path, key.Line, key.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return overrides, nil
+}
+
+// addOverrideNodes adds every entry of a replace or types mapping.
+func addOverrideNodes(path, section string, node *yaml.Node, add func(source string, target *yaml.Node) error) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s:%d: %s: expected a mapping of type names", path, node.Line, section)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if err := add(key.Value, value); err != nil {
+			return fmt.Errorf("%s:%d: %s %s: %w", path, key.Line, section, key.Value, err)
+		}
+	}
+	return nil
+}
+
+// addReplaceNode records a replace entry.
+func (o *overrideSet) addReplaceNode(source string, target *yaml.Node) error {
+	value, err := overrideValue(target)
+	if err != nil {
+		return err
+	}
+	return o.addReplace(source, value)
+}
+
+// addTypeNode records a types entry: a full schema fragment or a type name
+// like "string".
+func (o *overrideSet) addTypeNode(typeName string, target *yaml.Node) error {
+	value, err := overrideValue(target)
+	if err != nil {
+		return err
+	}
+	if !isInlineSchema(value) {
+		o.replace[typeName] = value
+		return nil
+	}
+	schema, err := parseInlineSchema(value)
+	if err != nil {
+		return err
+	}
+	o.types[typeName] = schema
+	return nil
+}
+
+// addSkipNodes records the entries of a skip list.
+func (o *overrideSet) addSkipNodes(path string, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s:%d: skip: expected a list of type names", path, node.Line)
+	}
+	for _, item := range node.Content {
+		typeName := strings.TrimSpace(item.Value)
+		if item.Kind != yaml.ScalarNode || typeName == "" {
+			return fmt.Errorf("%s:%d: skip: expected a type name", path, item.Line)
+		}
+		o.skip[typeName] = true
+	}
+	return nil
+}
+
+// newOverrideSet creates an empty set of overrides.
+func newOverrideSet() *overrideSet {
+	return &overrideSet{
+		replace: make(map[string]string),
+		skip:    make(map[string]bool),
+		types:   make(map[string]*openapi.Schema),
+	}
+}
+
+// addReplace records a replace directive, validating inline schemas.
+func (o *overrideSet) addReplace(source, target string) error {
+	if isInlineSchema(target) {
+		if _, err := parseInlineSchema(target); err != nil {
+			return err
+		}
+	}
+	o.replace[source] = target
+	return nil
+}
+
+// overrideValue converts a replace/types value to its string form.
+// Mappings are re-encoded as inline JSON schemas.
+func overrideValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			return strings.TrimSpace(node.Value), nil
+		}
+	case yaml.MappingNode:
+		var value map[string]interface{}
+		if err := node.Decode(&value); err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("expected a type name or a schema, got %s", strings.TrimPrefix(node.ShortTag(), "!!"))
+}
+
+// isInlineSchema reports whether an override target is an inline JSON schema.
+func isInlineSchema(target string) bool {
+	return strings.HasPrefix(strings.TrimSpace(target), "{")
+}
+
+// parseInlineSchema decodes an inline JSON schema override target.
+func parseInlineSchema(target string) (*openapi.Schema, error) {
+	var schema openapi.Schema
+	if err := json.Unmarshal([]byte(target), &schema); err != nil {
+		return nil, fmt.Errorf("invalid inline schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// matchTypeEntry returns the overrides file entry named after a type. An
+// exact entry wins over a package-qualified one ("github.com/google/uuid.UUID"
+// matches "uuid.UUID"); entries of the same rank are tried in sorted order.
+func matchTypeEntry[V any](entries map[string]V, typeName string) (string, bool) {
+	if typeName == "" {
+		return "", false
+	}
+	if _, ok := entries[typeName]; ok {
+		return typeName, true
+	}
+
+	normalized := normalizeTypeName(typeName)
+	for _, entry := range slices.Sorted(maps.Keys(entries)) {
+		if normalizeTypeName(entry) == normalized {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// matchTypeSuffix returns the first entry, in sorted order, that ends with the
+// type name ("NullInt64" matches "database/sql.NullInt64").
+func matchTypeSuffix[V any](entries map[string]V, typeName string) (string, bool) {
+	if typeName == "" {
+		return "", false
+	}
+	for _, entry := range slices.Sorted(maps.Keys(entries)) {
+		if strings.HasSuffix(entry, "."+typeName) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// GetTypeOverride returns the override type for a given type name.
+// The override is a type name or an inline JSON schema. An unqualified type
+// name also matches a replacement of the same type in any package.
+func (p *Parser) GetTypeOverride(typeName string) (string, bool) {
+	entry, ok := matchTypeEntry(p.typeOverrides, typeName)
+	if !ok {
+		entry, ok = matchTypeSuffix(p.typeOverrides, typeName)
+	}
+	if !ok {
+		return "", false
+	}
+	return p.typeOverrides[entry], true
+}
+
+// IsTypeSkipped reports whether a type is excluded by a skip directive.
+// Fields of skipped types are omitted from the generated schemas. Only the
+// exact or package-qualified name matches, so skipping one package's type
+// leaves same-named types of other packages alone.
+func (p *Parser) IsTypeSkipped(typeName string) bool {
+	_, ok := matchTypeEntry(p.skipTypes, typeName)
+	return ok
+}
+
+// dependencyName returns the type that must be parsed for a referenced type
+// once overrides and skips are applied, or an empty string if there is none.
+func (p *Parser) dependencyName(typeName string) string {
+	if p.IsTypeSkipped(typeName) {
+		return ""
+	}
+
+	if override, ok := p.GetTypeOverride(typeName); ok {
+		if isInlineSchema(override) {
+			return ""
+		}
+		return override
+	}
+
+	return typeName
+}
+
+// checkSkippedReferences reports schemas that still reference a type excluded
+// by a skip directive, such as a @Success or @Param type; no schema is
+// generated for it, so the reference would dangle.
+func (p *Parser) checkSkippedReferences() error {
+	if len(p.skipTypes) == 0 {
+		return nil
+	}
+
+	var err error
+	oas.WalkV3(p.openapi, oas.V3Visitor{
+		Schema: func(loc oas.Location, schema *openapi.Schema) {
+			name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+			if err == nil && ok && p.IsTypeSkipped(name) {
+				err = fmt.Errorf("%s references %s, which is skipped by the overrides file", loc.Pointer, name)
+			}
+		},
+	})
+	return err
+}
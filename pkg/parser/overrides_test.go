@@ -0,0 +1,380 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOverridesFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write overrides file: %v", err)
+	}
+	return path
+}
+
+func TestSetOverridesFileFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "swaggo line format",
+			file: ".swaggo",
+			content: `// Replace sql types
+replace database/sql.NullString string
+replace github.com/acme/money.Amount {"type":"string","format":"decimal"}
+
+# Skip internal types
+skip github.com/acme/internal.Secret
+`,
+		},
+		{
+			name: "yaml",
+			file: "overrides.yaml",
+			content: `# Type overrides
+replace:
+  database/sql.NullString: string
+  github.com/acme/money.Amount:
+    type: string
+    format: decimal
+skip:
+  - github.com/acme/internal.Secret
+`,
+		},
+		{
+			name: "json",
+			file: "overrides.json",
+			content: `{
+	"replace": {
+		"database/sql.NullString": "string",
+		"github.com/acme/money.Amount": {"type": "string", "format": "decimal"}
+	},
+	"skip": ["github.com/acme/internal.Secret"]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := New()
+
+			if err := p.SetOverridesFile(writeOverridesFile(t, tt.file, tt.content)); err != nil {
+				t.Fatalf("SetOverridesFile() returned error: %v", err)
+			}
+
+			if override, ok := p.GetTypeOverride("sql.NullString"); !ok || override != "string" {
+				t.Errorf("GetTypeOverride(sql.NullString) = %q, %v", override, ok)
+			}
+
+			override, ok := p.GetTypeOverride("money.Amount")
+			if !ok || !isInlineSchema(override) {
+				t.Fatalf("GetTypeOverride(money.Amount) = %q, want inline schema", override)
+			}
+			sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+			schema := sp.parseOverrideType(override)
+			if schema.Type != "string" || schema.Format != "decimal" {
+				t.Errorf("inline schema = %+v, want string/decimal", schema)
+			}
+
+			if !p.IsTypeSkipped("internal.Secret") {
+				t.Error("internal.Secret should be skipped")
+			}
+			if p.IsTypeSkipped("internal.Public") {
+				t.Error("internal.Public should not be skipped")
+			}
+		})
+	}
+}
+
+func TestSetOverridesFileErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"unknown directive", ".swaggo", "replace a.T string\n\nrename a.T b.U\n", ".swaggo:3: unknown directive \"rename\""},
+		{"replace without target", ".swaggo", "// header\nreplace a.T\n", ".swaggo:2: replace requires a source and a target type"},
+		{"skip with extra fields", ".swaggo", "skip a.T b.U\n", ".swaggo:1: skip requires exactly one type"},
+		{"invalid inline schema", ".swaggo", "replace a.T {\"type\":\n", ".swaggo:1: invalid inline schema"},
+		{"invalid json", "overrides.json", "{\n  \"replace\": {\n    \"a.T\": \"string\",\n  }\n}", "overrides.json:4: invalid JSON"},
+		{"invalid yaml", "overrides.yaml", "replace:\n  a.T: string\n skip: [\n", "invalid YAML: yaml: line"},
+		{"invalid replace value", "overrides.json", `{"replace": {"a.T": 42}}`, "overrides.json:1: replace a.T: expected a type name or a schema, got int"},
+		{"invalid types value", "overrides.json", "{\n  \"types\": {\n    \"a.T\": [\"string\"]\n  }\n}", "overrides.json:3: types a.T: expected a type name or a schema, got seq"},
+		{"skip is not a list", "overrides.yaml", "replace:\n  a.T: string\nskip: b.U\n", "overrides.yaml:3: skip: expected a list of type names"},
+		{"invalid yaml inline schema", "overrides.yaml", "replace:\n  a.T: string\n  b.U:\n    type: [1, 2\n", "invalid YAML"},
+		{"unknown key", "overrides.yaml", "replace:\n  a.T: string\nrename:\n  b.U: c.V\n", "overrides.yaml:3: unknown key \"rename\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := New()
+
+			err := p.SetOverridesFile(writeOverridesFile(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("SetOverridesFile() expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+			if len(p.typeOverrides) != 0 || len(p.skipTypes) != 0 {
+				t.Error("a malformed file should not load any directive")
+			}
+		})
+	}
+}
+
+func TestSetOverridesFileMissingAndUnreadable(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	if err := p.SetOverridesFile(filepath.Join(t.TempDir(), ".swaggo")); err != nil {
+		t.Errorf("missing overrides file should be ignored, got %v", err)
+	}
+	if err := p.SetOverridesFile(""); err != nil {
+		t.Errorf("empty overrides path should be ignored, got %v", err)
+	}
+	if err := p.SetOverridesFile(t.TempDir()); err == nil {
+		t.Error("unreadable overrides file should return an error")
+	}
+}
+
+func TestParseDirWithOverrides(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+type Secret struct {
+	Token string
+}
+
+type Money struct {
+	Units int64
+}
+
+type Account struct {
+	ID      int64  ` + "`json:\"id\"`" + `
+	Balance Money  ` + "`json:\"balance\"`" + `
+	Secret  Secret ` + "`json:\"secret\"`" + `
+	Owner   Owner  ` + "`json:\"owner\"`" + `
+}
+
+type Owner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Person struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Summary Get account
+// @Param amount query Money true "Amount"
+// @Success 200 {object} Account
+// @Router /accounts [get]
+func GetAccount() {}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	overrides := `replace Money {"type":"string","format":"decimal"}
+replace Owner Person
+skip Secret
+`
+	p := New()
+	if err := p.SetOverridesFile(writeOverridesFile(t, ".swaggo", overrides)); err != nil {
+		t.Fatalf("SetOverridesFile() returned error: %v", err)
+	}
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	schemas := p.openapi.Components.Schemas
+	account := schemas["Account"]
+	if account == nil {
+		t.Fatal("Account schema missing")
+	}
+
+	if balance := account.Properties["balance"]; balance.Type != "string" || balance.Format != "decimal" {
+		t.Errorf("balance = %+v, want inline decimal string", balance)
+	}
+	if _, ok := account.Properties["secret"]; ok {
+		t.Error("fields of skipped types should be omitted")
+	}
+	if owner := account.Properties["owner"]; owner.Ref != "#/components/schemas/Person" {
+		t.Errorf("owner Ref = %q, want #/components/schemas/Person", owner.Ref)
+	}
+	if _, ok := schemas["Person"]; !ok {
+		t.Error("replacement type Person should be parsed")
+	}
+	for _, name := range []string{"Money", "Secret", "Owner"} {
+		if _, ok := schemas[name]; ok {
+			t.Errorf("%s should not be generated", name)
+		}
+	}
+
+	op := p.openapi.Paths["/accounts"].Get
+	if op == nil || len(op.Parameters) != 1 {
+		t.Fatal("expected one parameter on GET /accounts")
+	}
+	if schema := op.Parameters[0].Schema; schema.Format != "decimal" || schema.Ref != "" {
+		t.Errorf("@Param type should use the override, got %+v", schema)
+	}
+}
+
+func TestGetTypeOverridePrecedence(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.typeOverrides = map[string]string{
+		"sql.NullInt64":                 "integer",
+		"database/sql.NullInt64":        "string",
+		"github.com/acme/sql.NullInt64": "number",
+		"acme.Money":                    "number",
+		"zeta.Money":                    "string",
+	}
+
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{"sql.NullInt64", "integer"},
+		{"database/sql.NullInt64", "string"},
+		{"other/sql.NullInt64", "string"},
+		{"Money", "number"},
+	}
+
+	// Repeat the lookups so a map-order dependent match would show up
+	for range 20 {
+		for _, tt := range tests {
+			if got, ok := p.GetTypeOverride(tt.typeName); !ok || got != tt.want {
+				t.Fatalf("GetTypeOverride(%q) = %q, %v, want %q", tt.typeName, got, ok, tt.want)
+			}
+		}
+	}
+}
+
+func TestParseDirSkippedTypeReference(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+type Secret struct {
+	Token string
+}
+
+// @Summary Get secret
+// @Success 200 {object} Secret
+// @Router /secret [get]
+func GetSecret() {}
+`
+
+	for _, goList := range []bool{false, true} {
+		t.Run(fmt.Sprintf("goList=%v", goList), func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n\ngo 1.21\n"), 0644); err != nil {
+				t.Fatalf("Failed to write go.mod: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := New()
+			p.SetParseGoList(goList)
+			if err := p.SetOverridesFile(writeOverridesFile(t, ".swaggo", "skip Secret\n")); err != nil {
+				t.Fatalf("SetOverridesFile() returned error: %v", err)
+			}
+			err := p.ParseDir(tmpDir)
+			if err == nil {
+				t.Fatal("ParseDir() should fail when an operation uses a skipped type")
+			}
+			if want := "/paths/~1secret/get/responses/200/content/application~1json/schema references Secret"; !strings.Contains(err.Error(), want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestParseDirSkipSameNamedTypes(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"main.go": `package main
+
+import "example.com/app/models"
+
+// @title Test API
+// @version 1.0.0
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Account struct {
+	Owner  User        ` + "`json:\"owner\"`" + `
+	Remote models.User ` + "`json:\"remote\"`" + `
+}
+
+// @Summary Get account
+// @Success 200 {object} Account
+// @Router /accounts [get]
+func GetAccount() {}
+`,
+		"models/user.go": `package models
+
+type User struct {
+	Token string ` + "`json:\"token\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	p := New()
+	if err := p.SetOverridesFile(writeOverridesFile(t, ".swaggo", "skip example.com/app/models.User\n")); err != nil {
+		t.Fatalf("SetOverridesFile() returned error: %v", err)
+	}
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	account := p.openapi.Components.Schemas["Account"]
+	if account == nil {
+		t.Fatal("Account schema missing")
+	}
+	if _, ok := account.Properties["remote"]; ok {
+		t.Error("field of the skipped models.User should be omitted")
+	}
+	if owner := account.Properties["owner"]; owner == nil || owner.Ref != "#/components/schemas/User" {
+		t.Errorf("owner = %+v, want a reference to the local User", owner)
+	}
+}
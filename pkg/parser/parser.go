@@ -17,19 +17,27 @@ import (
 
 // Parser parses Go source files and extracts OpenAPI documentation from comments.
 type Parser struct {
-	openapi         *openapi.OpenAPI
-	files           map[string]*ast.File
-	fset            *token.FileSet
-	generalInfoFile string
-	typeCache       map[string]*TypeInfo
-	parsedModules   map[string]bool              // Track parsed modules to avoid infinite recursion
-	referencedTypes map[string]bool              // Track types referenced in operations (selective parsing)
-	importMap       map[string]map[string]string // Map of file path -> (package alias -> import path)
-	parsingExternal bool                         // Flag to indicate we're parsing external packages
+	openapi           *openapi.OpenAPI
+	files             map[string]*ast.File
+	fset              *token.FileSet
+	generalInfoFile   string
+	typeCache         map[string]*TypeInfo
+	parsedModules     map[string]bool              // Track parsed modules to avoid infinite recursion
+	referencedTypes   map[string]bool              // Track types referenced in operations (selective parsing)
+	importMap         map[string]map[string]string // Map of file path -> (package alias -> import path)
+	marshalers        map[string]string            // Types with custom MarshalJSON/MarshalText methods -> marshaler kind
+	inlineStructs     map[string]*inlineStruct     // Anonymous struct types seen so far, keyed by source
+	operationDefaults []operationDefault           // @Default.* annotations from the general API info
+	parsingExternal   bool                         // Flag to indicate we're parsing external packages
+	warnings          []string                     // Problems that did not stop parsing (see Warnings)
 
 	// Configuration options
 	excludePatterns      []string
 	propertyStrategy     string
+	embeddedStrategy     string // How embedded structs are rendered: "flatten" or "allOf"
+	readWriteSplit       string // Request/response schema variants: "none", "inputOutput" or "requestResponse"
+	operationIDStrategy  string // Operation ID generation: "none", "funcName", "receiverMethod", "methodPath" or a template
+	operationIDCase      string // Casing of generated operation IDs: "none", "camel", "pascal", "snake" or "kebab"
 	requiredByDefault    bool
 	parseInternal        bool
 	parseDependency      bool
@@ -42,6 +50,8 @@ type Parser struct {
 	parseFuncBody        bool
 	parseVendor          bool
 	typeOverrides        map[string]string
+	skipTypes            map[string]bool            // Types excluded by the overrides file
+	customTypes          map[string]*openapi.Schema // Registered type schemas (see RegisterType)
 	parseDependencyLevel int
 	codeExampleFilesDir  string
 	generatedTime        bool
@@ -89,10 +99,18 @@ func New() *Parser {
 		parsedModules:        make(map[string]bool),
 		referencedTypes:      make(map[string]bool),
 		importMap:            make(map[string]map[string]string),
+		marshalers:           make(map[string]string),
+		inlineStructs:        make(map[string]*inlineStruct),
 		includeTypes:         []string{"all"}, // Default: include all referenced types
 		propertyStrategy:     "camelcase",
+		embeddedStrategy:     embeddedFlatten,
+		readWriteSplit:       readWriteSplitNone,
+		operationIDStrategy:  operationIDNone,
+		operationIDCase:      operationIDCaseNone,
 		parseDepth:           100,
 		typeOverrides:        make(map[string]string),
+		skipTypes:            make(map[string]bool),
+		customTypes:          make(map[string]*openapi.Schema),
 		instanceName:         "swagger",
 		collectionFormat:     "csv",
 		parseDependencyLevel: 0,
@@ -120,7 +138,7 @@ func (p *Parser) ParseDir(dir string) error {
 		if err := p.parseWithGoList(dir); err != nil {
 			return fmt.Errorf("failed to parse with go list: %w", err)
 		}
-		return nil
+		return p.finishParse()
 	}
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
@@ -166,6 +184,15 @@ func (p *Parser) ParseDir(dir string) error {
 		return err
 	}
 
+	return p.finishParse()
+}
+
+// finishParse generates the schemas of the types referenced by the parsed
+// files and post-processes the document, whichever way the files were found.
+func (p *Parser) finishParse() error {
+	// Merge @Default.* annotations before their types are resolved
+	p.applyOperationDefaults()
+
 	// After parsing all files and operations, resolve type dependencies
 	p.ResolveTypeDependencies()
 
@@ -176,8 +203,17 @@ func (p *Parser) ParseDir(dir string) error {
 		}
 	}
 
+	if err := p.checkSkippedReferences(); err != nil {
+		return err
+	}
+
+	// Split schemas into request and response variants once all are known
+	p.splitReadWriteSchemas()
+
 	return nil
-} // shouldExclude checks if a path matches any exclude pattern.
+}
+
+// shouldExclude checks if a path matches any exclude pattern.
 func (p *Parser) shouldExclude(path string, info os.FileInfo) bool {
 	if len(p.excludePatterns) == 0 {
 		return false
@@ -237,6 +273,9 @@ func (p *Parser) ParseFile(path string) error {
 		p.collectImports(path, file)
 	}
 
+	// Record types that control their own JSON encoding
+	p.collectMarshalers(file)
+
 	// Check if this file should be used for general API info
 	shouldParseGeneralInfo := false
 
@@ -281,6 +320,17 @@ func (p *Parser) GetOpenAPI() *openapi.OpenAPI {
 	return p.openapi
 }
 
+// Warnings returns the problems found while parsing that did not stop it,
+// such as struct tags the specification cannot describe.
+func (p *Parser) Warnings() []string {
+	return p.warnings
+}
+
+// warnf records a parsing warning.
+func (p *Parser) warnf(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
 // hasGeneralInfo checks if the file contains general API information.
 func (p *Parser) hasGeneralInfo(file *ast.File) bool {
 	for _, comment := range file.Comments {
@@ -299,7 +349,18 @@ func (p *Parser) hasGeneralInfo(file *ast.File) bool {
 func (p *Parser) parseGeneralInfo(file *ast.File) error {
 	processor := NewGeneralInfoProcessor(p.openapi)
 
+	// Operation comments carry their own @x- annotations
+	operationDocs := make(map[*ast.CommentGroup]bool)
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Doc != nil && hasRouter(funcDecl.Doc) {
+			operationDocs[funcDecl.Doc] = true
+		}
+	}
+
 	for _, comment := range file.Comments {
+		if operationDocs[comment] {
+			continue
+		}
 		for _, line := range comment.List {
 			text := strings.TrimSpace(strings.TrimPrefix(line.Text, "//"))
 			if text == "" || !strings.HasPrefix(text, "@") {
@@ -312,9 +373,21 @@ func (p *Parser) parseGeneralInfo(file *ast.File) error {
 		}
 	}
 
+	p.operationDefaults = append(p.operationDefaults, processor.defaults...)
+
 	return nil
 }
 
+// hasRouter reports whether a comment group declares an operation route.
+func hasRouter(doc *ast.CommentGroup) bool {
+	for _, line := range doc.List {
+		if routerRegex.MatchString(strings.TrimSpace(strings.TrimPrefix(line.Text, "//"))) {
+			return true
+		}
+	}
+	return false
+}
+
 // parseOperations extracts operation information from function comments.
 // Respects includeTypes filter for func category.
 func (p *Parser) parseOperations(file *ast.File) error {
@@ -325,21 +398,31 @@ func (p *Parser) parseOperations(file *ast.File) error {
 		}
 
 		processor := NewOperationProcessor(p, p.openapi, p.typeCache)
-		op := processor.Process(funcDecl.Doc)
-		if op == nil {
-			return true
-		}
 
-		// Process function body comments if parseFuncBody is enabled
-		if p.parseFuncBody && funcDecl.Body != nil {
-			// Iterate through all comments in the file
-			for _, commentGroup := range file.Comments {
-				// Check if comment is within the function body range
-				if commentGroup.Pos() > funcDecl.Body.Lbrace &&
-					commentGroup.End() < funcDecl.Body.Rbrace {
-					processor.Process(commentGroup)
+		// Each route gets its own operation, so process the comments per route
+		buildOperation := func() *openapi.Operation {
+			op := processor.Process(funcDecl.Doc)
+			if op == nil {
+				return nil
+			}
+
+			// Process function body comments if parseFuncBody is enabled
+			if p.parseFuncBody && funcDecl.Body != nil {
+				// Iterate through all comments in the file
+				for _, commentGroup := range file.Comments {
+					// Check if comment is within the function body range
+					if commentGroup.Pos() > funcDecl.Body.Lbrace &&
+						commentGroup.End() < funcDecl.Body.Rbrace {
+						processor.Process(commentGroup)
+					}
 				}
 			}
+			return op
+		}
+
+		op := buildOperation()
+		if op == nil {
+			return true
 		}
 
 		// Check if operation should be included based on tag filters
@@ -352,40 +435,25 @@ func (p *Parser) parseOperations(file *ast.File) error {
 			return true
 		}
 
-		// Extract path and method from @Router annotation
-		routeInfo := processor.GetRouteInfo(funcDecl.Doc)
-		if routeInfo.Path == "" || routeInfo.Method == "" {
-			return true
-		}
-
-		// Add operation to path
-		pathItem := p.openapi.Paths[routeInfo.Path]
-		if pathItem == nil {
-			pathItem = &openapi.PathItem{}
-			p.openapi.Paths[routeInfo.Path] = pathItem
-		}
-
-		// Set operation based on HTTP method
-		switch strings.ToLower(routeInfo.Method) {
-		case "get":
-			pathItem.Get = op
-		case "post":
-			pathItem.Post = op
-		case "put":
-			pathItem.Put = op
-		case "delete":
-			pathItem.Delete = op
-		case "patch":
-			pathItem.Patch = op
-		case "options":
-			pathItem.Options = op
-		case "head":
-			pathItem.Head = op
-		case "trace":
-			pathItem.Trace = op
-		case "query":
-			// QUERY method is new in OpenAPI 3.2.0
-			pathItem.Query = op
+		// Extract paths and methods from @Router annotations
+		usedIDs := make(map[string]bool)
+		for i, route := range processor.GetRoutes(funcDecl.Doc) {
+			if i > 0 {
+				op = buildOperation()
+			}
+			generated := op.OperationID == ""
+			if generated {
+				op.OperationID = p.generateOperationID(funcDecl, route, op)
+			}
+			// A handler mounted on several routes gets numbered IDs
+			if op.OperationID != "" && usedIDs[op.OperationID] {
+				op.OperationID = fmt.Sprintf("%s_%d", op.OperationID, i+1)
+				if generated {
+					op.OperationID = applyOperationIDCase(op.OperationID, p.operationIDCase)
+				}
+			}
+			usedIDs[op.OperationID] = true
+			p.addOperation(route, op, processor.pathParams, processor.pathServers)
 		}
 
 		return true
@@ -394,28 +462,77 @@ func (p *Parser) parseOperations(file *ast.File) error {
 	return nil
 }
 
+// addOperation registers an operation under its route and merges @PathParam
+// parameters and @PathServer servers into the path item.
+func (p *Parser) addOperation(route RouteInfo, op *openapi.Operation, pathParams []openapi.Parameter, pathServers []openapi.Server) {
+	if route.Path == "" || route.Method == "" {
+		return
+	}
+
+	pathItem := p.openapi.Paths[route.Path]
+	if pathItem == nil {
+		pathItem = &openapi.PathItem{}
+		p.openapi.Paths[route.Path] = pathItem
+	}
+
+	for _, param := range pathParams {
+		if !hasParameter(pathItem.Parameters, param.Name, param.In) {
+			pathItem.Parameters = append(pathItem.Parameters, param)
+		}
+	}
+
+	for _, server := range pathServers {
+		if !hasServer(pathItem.Servers, server.URL) {
+			pathItem.Servers = append(pathItem.Servers, server)
+		}
+	}
+
+	// Set operation based on HTTP method
+	switch strings.ToLower(route.Method) {
+	case "get":
+		pathItem.Get = op
+	case "post":
+		pathItem.Post = op
+	case "put":
+		pathItem.Put = op
+	case "delete":
+		pathItem.Delete = op
+	case "patch":
+		pathItem.Patch = op
+	case "options":
+		pathItem.Options = op
+	case "head":
+		pathItem.Head = op
+	case "trace":
+		pathItem.Trace = op
+	case "query":
+		// QUERY method is new in OpenAPI 3.2.0
+		pathItem.Query = op
+	}
+}
+
 // parseSchemas extracts schema definitions from type declarations.
 // Only processes structs that are referenced in operations or their dependencies.
 // Respects includeTypes filter for type categories.
 func (p *Parser) parseSchemas(file *ast.File) error {
 	processor := NewSchemaProcessor(p, p.openapi, p.typeCache)
 
-	ast.Inspect(file, func(n ast.Node) bool {
-		typeSpec, ok := n.(*ast.TypeSpec)
-		if !ok {
-			return true
+	// Single type declarations keep their doc comment on the GenDecl
+	declDocs := make(map[*ast.TypeSpec]*ast.CommentGroup)
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && len(genDecl.Specs) == 1 {
+			if typeSpec, ok := genDecl.Specs[0].(*ast.TypeSpec); ok {
+				declDocs[typeSpec] = genDecl.Doc
+			}
 		}
+	}
 
-		structType, ok := typeSpec.Type.(*ast.StructType)
+	ast.Inspect(file, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
 		if !ok {
 			return true
 		}
 
-		// Check if struct category should be included
-		if !p.ShouldIncludeTypeCategory("struct") {
-			return true
-		}
-
 		// Use simple name for types in current package
 		schemaName := typeSpec.Name.Name
 
@@ -426,12 +543,37 @@ func (p *Parser) parseSchemas(file *ast.File) error {
 			qualifiedName = packageName + "." + typeSpec.Name.Name
 		}
 
-		// Check if this type is referenced (skip if not)
-		if !p.IsTypeReferenced(schemaName) && !p.IsTypeReferenced(qualifiedName) {
-			return true
+		var schema *openapi.Schema
+		if kind := p.marshalerKind(qualifiedName, schemaName); kind != "" {
+			// Custom marshalers decide their own wire format, whatever the underlying type
+			if !p.IsTypeReferenced(schemaName) && !p.IsTypeReferenced(qualifiedName) {
+				return true
+			}
+
+			doc := typeSpec.Doc
+			if doc == nil {
+				doc = declDocs[typeSpec]
+			}
+			schema = processor.ProcessMarshaler(kind, doc)
+		} else {
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			// Check if struct category should be included
+			if !p.ShouldIncludeTypeCategory("struct") {
+				return true
+			}
+
+			// Check if this type is referenced (skip if not)
+			if !p.IsTypeReferenced(schemaName) && !p.IsTypeReferenced(qualifiedName) {
+				return true
+			}
+
+			schema = processor.ProcessStruct(structType, typeSpec.Doc, typeSpec.Name.Name)
 		}
 
-		schema := processor.ProcessStruct(structType, typeSpec.Doc, typeSpec.Name.Name)
 		if schema != nil {
 			if packageName != "main" && packageName != "" {
 				// Register both names to support both reference styles
@@ -470,19 +612,17 @@ func (p *Parser) Validate() error {
 		return errors.New("API version is required (@version)")
 	}
 
-	// Validate that all schema references exist
-	for path, pathItem := range p.openapi.Paths {
-		operations := []*openapi.Operation{
-			pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete,
-			pathItem.Patch, pathItem.Options, pathItem.Head, pathItem.Trace,
-			pathItem.Query, // QUERY method (OpenAPI 3.2.0)
-		}
+	if err := p.validateOperationIDs(); err != nil {
+		return err
+	}
 
-		for _, op := range operations {
-			if op == nil {
-				continue
-			}
+	if err := p.validateLinks(); err != nil {
+		return err
+	}
 
+	// Validate that all schema references exist
+	for path, pathItem := range p.openapi.Paths {
+		for _, op := range pathItemOperations(pathItem) {
 			if err := p.validateOperation(op, path); err != nil {
 				return err
 			}
@@ -585,18 +725,30 @@ func (p *Parser) AddReferencedType(typeName string) {
 		return
 	}
 
+	// Apply overrides file replace/skip directives
+	typeName = p.dependencyName(typeName)
+	if typeName == "" {
+		return
+	}
+
 	// Skip primitive types
 	primitives := map[string]bool{
 		"string": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true,
 		"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
 		"float32": true, "float64": true, "bool": true, "byte": true, "rune": true,
-		"time.Time": true, "interface{}": true, "any": true,
+		"interface{}": true, "any": true,
+		"integer": true, "number": true, "boolean": true, "object": true, "array": true,
 	}
 
 	if primitives[typeName] {
 		return
 	}
 
+	// Well-known and registered types are rendered inline
+	if _, ok := p.LookupType(typeName); ok {
+		return
+	}
+
 	p.referencedTypes[typeName] = true
 }
 
@@ -613,97 +765,206 @@ func (p *Parser) GetReferencedTypes() map[string]bool {
 // ResolveTypeDependencies recursively resolves all type dependencies.
 // This method processes structs referenced in operations and extracts their field types,
 // including nested structs, to ensure all related types are included in the schema.
+// Every type is visited once, so recursive and mutually recursive types terminate.
 func (p *Parser) ResolveTypeDependencies() {
-	// Keep processing until no new types are discovered
-	maxIterations := 10
-	iterations := 0
+	resolved := make(map[string]bool)
+
 	for {
-		iterations++
-		if iterations > maxIterations {
+		pending := make([]string, 0)
+		for typeName := range p.referencedTypes {
+			if !resolved[typeName] {
+				pending = append(pending, typeName)
+			}
+		}
+
+		// Stop if no new types were discovered
+		if len(pending) == 0 {
 			break
 		}
-		newTypesFound := false
-		currentTypes := make([]string, 0, len(p.referencedTypes))
 
-		// Copy current referenced types
-		for typeName := range p.referencedTypes {
-			currentTypes = append(currentTypes, typeName)
-		}
-
-		// Process each referenced type
-		for _, typeName := range currentTypes {
-			found := false
-			// Find the type in parsed files
-			for _, file := range p.files {
-				ast.Inspect(file, func(n ast.Node) bool {
-					typeSpec, ok := n.(*ast.TypeSpec)
-					if !ok {
-						return true
-					}
+		for _, typeName := range pending {
+			resolved[typeName] = true
 
-					// Check if this is the type we're looking for
-					if typeSpec.Name.Name != typeName {
-						// Also check package.Type format
-						packageName := file.Name.Name
-						qualifiedName := packageName + "." + typeSpec.Name.Name
-						if qualifiedName != typeName {
-							return true
-						}
-					}
+			// If type not found in parsed files, try to parse it from external dependencies
+			if !p.collectTypeDependencies(typeName) {
+				if err := p.parseExternalType(typeName); err == nil {
+					p.collectTypeDependencies(typeName)
+				}
+			}
+		}
+	}
+}
 
-					// Process struct fields to find dependencies
-					structType, ok := typeSpec.Type.(*ast.StructType)
-					if !ok {
-						return true
-					}
+// collectTypeDependencies marks the types used by the fields of a struct as
+// referenced. It reports whether the type was found in the parsed files.
+func (p *Parser) collectTypeDependencies(typeName string) bool {
+	found := false
 
-					// Extract field types
-					for _, field := range structType.Fields.List {
-						// Check for swaggertype override first
-						if field.Tag != nil {
-							tagStr := strings.Trim(field.Tag.Value, "`")
-							swaggerType := extractTag(tagStr, "swaggertype")
-
-							// If swaggertype is primitive, skip dependency tracking
-							if swaggerType != "" && !p.isPrimitiveSwaggerType(swaggerType) {
-								// Extract the actual type from swaggertype
-								actualType := p.extractTypeFromSwaggerType(swaggerType)
-								if actualType != "" && !p.referencedTypes[actualType] {
-									p.referencedTypes[actualType] = true
-									newTypesFound = true
-								}
-								continue
-							} else if swaggerType != "" {
-								// Primitive swaggertype override, no need to track original type
-								continue
-							}
-						}
-
-						// Extract dependencies from field type
-						fieldType := p.extractFieldTypeName(field.Type)
-						if fieldType != "" && !p.referencedTypes[fieldType] {
-							p.referencedTypes[fieldType] = true
-							newTypesFound = true
-						}
-					}
+	for _, file := range p.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
 
+			// Check if this is the type we're looking for
+			if typeSpec.Name.Name != typeName {
+				// Also check package.Type format
+				packageName := file.Name.Name
+				qualifiedName := packageName + "." + typeSpec.Name.Name
+				if qualifiedName != typeName {
 					return true
-				})
+				}
 			}
+			found = true
 
-			// If type not found in parsed files, try to parse it from external dependencies
-			if !found {
-				if err := p.parseExternalType(typeName); err == nil {
-					newTypesFound = true
+			// Process struct fields to find dependencies
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			// Custom marshalers are not expanded, so their fields are not dependencies
+			if p.marshalerKind(typeName) != "" {
+				return true
+			}
+
+			p.collectFieldDependencies(structType, map[*ast.StructType]bool{structType: true})
+
+			return true
+		})
+	}
+
+	return found
+}
+
+// collectFieldDependencies marks the field types of a struct as referenced,
+// descending into anonymous struct fields and flattened embedded structs.
+func (p *Parser) collectFieldDependencies(structType *ast.StructType, visited map[*ast.StructType]bool) {
+	for _, field := range structType.Fields.List {
+		// Flattened embedded structs contribute their fields, not a schema of their own
+		if embedded := p.flattenedEmbeddedStruct(field); embedded != nil {
+			if !visited[embedded] {
+				visited[embedded] = true
+				p.collectFieldDependencies(embedded, visited)
+			}
+			continue
+		}
+
+		// Check for swaggertype override first
+		if field.Tag != nil {
+			tagStr := strings.Trim(field.Tag.Value, "`")
+
+			// Fields omitted by encoding/json contribute no types
+			if extractTag(tagStr, "json") == "-" {
+				continue
+			}
+
+			swaggerType := extractTag(tagStr, "swaggertype")
+
+			// If swaggertype is primitive, skip dependency tracking
+			if swaggerType != "" && !p.isPrimitiveSwaggerType(swaggerType) {
+				// Extract the actual type from swaggertype
+				if actualType := p.extractTypeFromSwaggerType(swaggerType); actualType != "" {
+					p.referencedTypes[actualType] = true
 				}
+				continue
+			} else if swaggerType != "" {
+				// Primitive swaggertype override, no need to track original type
+				continue
 			}
 		}
 
-		// Stop if no new types were discovered
-		if !newTypesFound {
-			break
+		// Anonymous structs are expanded inline, so their fields are dependencies too
+		if inline := inlineStructType(field.Type); inline != nil {
+			p.collectFieldDependencies(inline, visited)
+			continue
+		}
+
+		// Extract dependencies from field type
+		fieldType := p.dependencyName(p.extractFieldTypeName(field.Type))
+		if _, ok := p.LookupType(fieldType); ok {
+			continue
+		}
+		if fieldType != "" {
+			p.referencedTypes[fieldType] = true
+		}
+	}
+}
+
+// flattenedEmbeddedStruct returns the struct type of an embedded field that
+// the flatten strategy merges into its parent, or nil otherwise.
+func (p *Parser) flattenedEmbeddedStruct(field *ast.Field) *ast.StructType {
+	if strings.EqualFold(p.embeddedStrategy, embeddedAllOf) {
+		return nil
+	}
+
+	if len(field.Names) > 0 && !strings.Contains(extractTag(fieldTag(field), "json"), ",inline") {
+		return nil
+	}
+	if len(field.Names) == 0 && jsonTagName(extractTag(fieldTag(field), "json")) != "" {
+		return nil
+	}
+
+	typeName := embeddedTypeName(field.Type)
+	if typeName == "" || p.marshalerKind(typeName) != "" {
+		return nil
+	}
+
+	typeSpec, _ := p.findTypeSpec(typeName)
+	if typeSpec == nil {
+		return nil
+	}
+
+	structType, _ := typeSpec.Type.(*ast.StructType)
+	return structType
+}
+
+// fieldTag returns the raw struct tag of a field without backquotes.
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	return strings.Trim(field.Tag.Value, "`")
+}
+
+// inlineStructType returns the anonymous struct type behind pointers, slices
+// and maps, or nil when the expression does not contain one.
+func inlineStructType(expr ast.Expr) *ast.StructType {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		return t
+	case *ast.StarExpr:
+		return inlineStructType(t.X)
+	case *ast.ArrayType:
+		return inlineStructType(t.Elt)
+	case *ast.MapType:
+		return inlineStructType(t.Value)
+	}
+	return nil
+}
+
+// findTypeSpec returns the declaration of a named type from the parsed files.
+// Both "Type" and "package.Type" names are accepted.
+func (p *Parser) findTypeSpec(typeName string) (*ast.TypeSpec, *ast.File) {
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if typeSpec.Name.Name == typeName || file.Name.Name+"."+typeSpec.Name.Name == typeName {
+					return typeSpec, file
+				}
+			}
 		}
 	}
+	return nil, nil
 }
 
 // isPrimitiveSwaggerType checks if a swaggertype value represents a primitive type.
@@ -773,6 +1034,75 @@ func (p *Parser) extractFieldTypeName(expr ast.Expr) string {
 	return ""
 }
 
+// Marshaler kinds recorded by collectMarshalers.
+const (
+	marshalerJSON = "json"
+	marshalerText = "text"
+)
+
+// collectMarshalers records the types of a file that implement json.Marshaler
+// or encoding.TextMarshaler. encoding/json prefers MarshalJSON over MarshalText.
+func (p *Parser) collectMarshalers(file *ast.File) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+
+		var kind string
+		switch funcDecl.Name.Name {
+		case "MarshalJSON":
+			kind = marshalerJSON
+		case "MarshalText":
+			kind = marshalerText
+		default:
+			continue
+		}
+
+		typeName := receiverTypeName(funcDecl.Recv.List[0].Type)
+		if typeName == "" {
+			continue
+		}
+
+		names := []string{typeName}
+		if packageName := file.Name.Name; packageName != "main" && packageName != "" {
+			names = append(names, packageName+"."+typeName)
+		}
+
+		for _, name := range names {
+			if p.marshalers[name] != marshalerJSON {
+				p.marshalers[name] = kind
+			}
+		}
+	}
+}
+
+// receiverTypeName returns the base type name of a method receiver.
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	}
+	return ""
+}
+
+// marshalerKind returns the marshaler kind of the first name that has custom
+// JSON encoding, or an empty string when none does.
+func (p *Parser) marshalerKind(names ...string) string {
+	for _, name := range names {
+		if kind, ok := p.marshalers[name]; ok {
+			return kind
+		}
+	}
+	return ""
+}
+
 // collectImports collects import statements from a file and stores them in importMap.
 // This allows resolving qualified type names (e.g., errors.BadRequest) to their full import paths.
 func (p *Parser) collectImports(filePath string, file *ast.File) {
@@ -2908,3 +2908,148 @@ func TestValidateOperationSchemaRefs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDirCustomMarshalers(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	content := `package main
+
+import "encoding/json"
+
+// @title Test API
+// @version 1.0.0
+
+// Status is encoded as its name.
+type Status int
+
+func (s Status) MarshalText() ([]byte, error) { return nil, nil }
+
+// Payload has its own JSON encoding.
+type Payload struct {
+	Hidden Internal
+}
+
+func (p *Payload) MarshalJSON() ([]byte, error) { return nil, nil }
+
+// Both prefers MarshalJSON like encoding/json does.
+type Both struct{}
+
+func (Both) MarshalText() ([]byte, error) { return nil, nil }
+func (Both) MarshalJSON() ([]byte, error) { return nil, nil }
+
+type Internal struct {
+	Value string
+}
+
+type Order struct {
+	Status  Status          ` + "`json:\"status\"`" + `
+	Payload Payload         ` + "`json:\"payload\"`" + `
+	Both    Both            ` + "`json:\"both\"`" + `
+	Extra   json.RawMessage ` + "`json:\"extra\"`" + `
+}
+
+// @Summary Get order
+// @Success 200 {object} Order
+// @Router /orders [get]
+func GetOrder() {}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	schemas := p.openapi.Components.Schemas
+
+	status, ok := schemas["Status"]
+	if !ok {
+		t.Fatal("Status schema missing")
+	}
+	if status.Type != "string" || status.Description != "Status is encoded as its name." {
+		t.Errorf("Status = %+v, want string with description", status)
+	}
+
+	payload, ok := schemas["Payload"]
+	if !ok {
+		t.Fatal("Payload schema missing")
+	}
+	if payload.Type != nil || len(payload.Properties) != 0 {
+		t.Errorf("Payload should be free-form, got Type=%v Properties=%v", payload.Type, payload.Properties)
+	}
+	if _, ok := schemas["Internal"]; ok {
+		t.Error("fields of a custom marshaler should not be resolved as dependencies")
+	}
+
+	if both, ok := schemas["Both"]; !ok || both.Type != nil {
+		t.Errorf("Both should be free-form, got %+v", both)
+	}
+
+	order := schemas["Order"]
+	if order == nil {
+		t.Fatal("Order schema missing")
+	}
+	if extra := order.Properties["extra"]; extra == nil || extra.Ref != "" || extra.Type != nil {
+		t.Errorf("json.RawMessage should be free-form, got %+v", extra)
+	}
+}
+
+func TestResolveTypeDependenciesRecursive(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	// A chain longer than the old iteration limit plus mutual recursion
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+type Node struct {
+	Next *T1 ` + "`json:\"next\"`" + `
+	Self []Node ` + "`json:\"self\"`" + `
+}
+type T1 struct{ Next T2 }
+type T2 struct{ Next T3 }
+type T3 struct{ Next T4 }
+type T4 struct{ Next T5 }
+type T5 struct{ Next T6 }
+type T6 struct{ Next T7 }
+type T7 struct{ Next T8 }
+type T8 struct{ Next T9 }
+type T9 struct{ Next T10 }
+type T10 struct{ Next T11 }
+type T11 struct{ Next T12 }
+type T12 struct {
+	Back  *Node ` + "`json:\"back\"`" + `
+	Inner struct {
+		Leaf Leaf ` + "`json:\"leaf\"`" + `
+	} ` + "`json:\"inner\"`" + `
+}
+type Leaf struct{ Value string }
+
+// @Summary Get node
+// @Success 200 {object} Node
+// @Router /nodes [get]
+func GetNode() {}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	for _, name := range []string{"Node", "T1", "T6", "T12", "Leaf"} {
+		if _, ok := p.openapi.Components.Schemas[name]; !ok {
+			t.Errorf("schema %s missing", name)
+		}
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+}
@@ -0,0 +1,399 @@
+// Package parser - Request and response schema variants
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	oas "github.com/fsvxavier/nexs-swag/pkg/openapi"
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Read/write split naming styles.
+const (
+	readWriteSplitNone            = "none"
+	readWriteSplitInputOutput     = "inputOutput"     // UserInput / UserOutput
+	readWriteSplitRequestResponse = "requestResponse" // User.Request / User.Response
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// readWriteSuffixes returns the input and output name suffixes for the
+// configured split style, or false when splitting is disabled.
+func (p *Parser) readWriteSuffixes() (string, string, bool) {
+	switch {
+	case strings.EqualFold(p.readWriteSplit, readWriteSplitInputOutput):
+		return "Input", "Output", true
+	case strings.EqualFold(p.readWriteSplit, readWriteSplitRequestResponse):
+		return ".Request", ".Response", true
+	}
+	return "", "", false
+}
+
+// splitReadWriteSchemas replaces every component that contains readOnly or
+// writeOnly properties, directly or through its references, with an input
+// variant without readOnly properties and an output variant without writeOnly
+// properties. Request bodies and parameters are rewritten to the input
+// variants and responses to the output variants. A variant that nothing
+// references is dropped unless its counterpart is unreferenced too.
+func (p *Parser) splitReadWriteSchemas() {
+	inputSuffix, outputSuffix, ok := p.readWriteSuffixes()
+	if !ok {
+		return
+	}
+
+	schemas := p.openapi.Components.Schemas
+	names := p.accessRestrictedSchemas()
+	if len(names) == 0 {
+		return
+	}
+
+	inputs := make(map[string]string, len(names))
+	outputs := make(map[string]string, len(names))
+	for _, name := range names {
+		inputs[name] = p.uniqueSchemaName(name + inputSuffix)
+		schemas[inputs[name]] = &openapi.Schema{}
+		outputs[name] = p.uniqueSchemaName(name + outputSuffix)
+		schemas[outputs[name]] = &openapi.Schema{}
+	}
+
+	for _, name := range names {
+		schemas[inputs[name]] = restrictSchema(schemas[name], false, inputs)
+		schemas[outputs[name]] = restrictSchema(schemas[name], true, outputs)
+	}
+
+	for _, item := range p.pathItems() {
+		item.Parameters = restrictParameters(item.Parameters, inputs)
+		for _, op := range pathItemOperations(item) {
+			restrictOperation(op, inputs, outputs)
+		}
+	}
+
+	components := p.openapi.Components
+	for _, param := range components.Parameters {
+		param.Schema = restrictSchema(param.Schema, false, inputs)
+	}
+	for _, body := range components.RequestBodies {
+		restrictContent(body.Content, false, inputs)
+	}
+	for _, resp := range components.Responses {
+		restrictResponse(resp, outputs)
+	}
+	for _, header := range components.Headers {
+		header.Schema = restrictSchema(header.Schema, true, outputs)
+	}
+
+	// Every reference now points to a variant
+	for _, name := range names {
+		delete(schemas, name)
+	}
+
+	p.pruneUnusedVariants(names, inputs, outputs)
+}
+
+// pruneUnusedVariants removes the input or output variant of a split schema
+// when only the other one is reachable from the document. Components whose
+// variants are both unreachable keep both, like any unreferenced component.
+func (p *Parser) pruneUnusedVariants(names []string, inputs, outputs map[string]string) {
+	schemas := p.openapi.Components.Schemas
+
+	var roots []string
+	oas.WalkV3(p.openapi, oas.V3Visitor{
+		Schema: func(loc oas.Location, schema *openapi.Schema) {
+			if !strings.HasPrefix(loc.Pointer, "/components/schemas/") {
+				roots = append(roots, referencedSchemas(schema)...)
+			}
+		},
+	})
+
+	reachable := p.reachableSchemas(roots)
+	var orphans []string
+	for _, name := range names {
+		if !reachable[inputs[name]] && !reachable[outputs[name]] {
+			orphans = append(orphans, inputs[name], outputs[name])
+		}
+	}
+	if len(orphans) > 0 {
+		reachable = p.reachableSchemas(append(roots, orphans...))
+	}
+
+	for _, name := range names {
+		for _, variant := range []string{inputs[name], outputs[name]} {
+			if !reachable[variant] {
+				delete(schemas, variant)
+			}
+		}
+	}
+}
+
+// reachableSchemas returns the components reachable from the given names
+// through references.
+func (p *Parser) reachableSchemas(roots []string) map[string]bool {
+	schemas := p.openapi.Components.Schemas
+
+	reachable := make(map[string]bool)
+	for len(roots) > 0 {
+		name := roots[len(roots)-1]
+		roots = roots[:len(roots)-1]
+		if reachable[name] || schemas[name] == nil {
+			continue
+		}
+		reachable[name] = true
+		walkInlineSchemas(schemas[name], func(s *openapi.Schema) {
+			roots = append(roots, referencedSchemas(s)...)
+		})
+	}
+	return reachable
+}
+
+// referencedSchemas returns the component names a single schema node refers
+// to through its $ref or discriminator mapping.
+func referencedSchemas(schema *openapi.Schema) []string {
+	var names []string
+	if name, ok := strings.CutPrefix(schema.Ref, schemaRefPrefix); ok {
+		names = append(names, name)
+	}
+	if schema.Discriminator != nil {
+		for _, ref := range schema.Discriminator.Mapping {
+			if name, ok := strings.CutPrefix(ref, schemaRefPrefix); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// accessRestrictedSchemas returns the sorted names of components that contain
+// a readOnly or writeOnly property, directly or through a $ref.
+func (p *Parser) accessRestrictedSchemas() []string {
+	schemas := p.openapi.Components.Schemas
+
+	restricted := make(map[string]bool)
+	refs := make(map[string][]string, len(schemas))
+	for name, schema := range schemas {
+		if hasAccessRestriction(schema) {
+			restricted[name] = true
+		}
+		refs[name] = schemaRefs(schema, nil)
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for name, targets := range refs {
+			if restricted[name] {
+				continue
+			}
+			for _, target := range targets {
+				if restricted[target] {
+					restricted[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(restricted))
+	for name := range restricted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasAccessRestriction reports whether a schema has a readOnly or writeOnly
+// property in its inline subtree.
+func hasAccessRestriction(schema *openapi.Schema) bool {
+	found := false
+	walkInlineSchemas(schema, func(s *openapi.Schema) {
+		for _, prop := range s.Properties {
+			if prop != nil && (prop.ReadOnly || prop.WriteOnly) {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// schemaRefs appends the component names referenced in a schema's inline subtree.
+func schemaRefs(schema *openapi.Schema, refs []string) []string {
+	walkInlineSchemas(schema, func(s *openapi.Schema) {
+		if strings.HasPrefix(s.Ref, schemaRefPrefix) {
+			refs = append(refs, strings.TrimPrefix(s.Ref, schemaRefPrefix))
+		}
+	})
+	return refs
+}
+
+// walkInlineSchemas calls fn for a schema and every nested schema, without
+// following references.
+func walkInlineSchemas(schema *openapi.Schema, fn func(*openapi.Schema)) {
+	if schema == nil {
+		return
+	}
+
+	fn(schema)
+
+	for _, prop := range schema.Properties {
+		walkInlineSchemas(prop, fn)
+	}
+	walkInlineSchemas(schema.Items, fn)
+	walkInlineSchemas(schema.Not, fn)
+	for _, item := range schema.PrefixItems {
+		walkInlineSchemas(item, fn)
+	}
+	if additional, ok := schema.AdditionalProperties.(*openapi.Schema); ok {
+		walkInlineSchemas(additional, fn)
+	}
+	for _, list := range [][]openapi.Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i := range list {
+			walkInlineSchemas(&list[i], fn)
+		}
+	}
+}
+
+// restrictSchema returns a copy of a schema without readOnly properties
+// (input) or writeOnly properties (output), with references renamed to the
+// matching variants.
+func restrictSchema(schema *openapi.Schema, output bool, rename map[string]string) *openapi.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	clone := cloneSchema(schema)
+	walkInlineSchemas(clone, func(s *openapi.Schema) {
+		if name, ok := rename[strings.TrimPrefix(s.Ref, schemaRefPrefix)]; ok && s.Ref != "" {
+			s.Ref = schemaRefPrefix + name
+		}
+
+		for name, prop := range s.Properties {
+			if prop == nil || (output && !prop.WriteOnly) || (!output && !prop.ReadOnly) {
+				continue
+			}
+			delete(s.Properties, name)
+			s.Required = removeString(s.Required, name)
+		}
+
+		if s.Discriminator != nil && len(s.Discriminator.Mapping) > 0 {
+			discriminator := *s.Discriminator
+			discriminator.Mapping = make(map[string]string, len(s.Discriminator.Mapping))
+			for value, ref := range s.Discriminator.Mapping {
+				if name, ok := rename[strings.TrimPrefix(ref, schemaRefPrefix)]; ok {
+					ref = schemaRefPrefix + name
+				}
+				discriminator.Mapping[value] = ref
+			}
+			s.Discriminator = &discriminator
+		}
+	})
+
+	if len(clone.Required) == 0 {
+		clone.Required = nil
+	}
+	return clone
+}
+
+// restrictOperation points request bodies and parameters at input variants
+// and responses at output variants, callbacks included.
+func restrictOperation(op *openapi.Operation, inputs, outputs map[string]string) {
+	op.Parameters = restrictParameters(op.Parameters, inputs)
+	if op.RequestBody != nil {
+		restrictContent(op.RequestBody.Content, false, inputs)
+	}
+	for _, resp := range op.Responses {
+		restrictResponse(resp, outputs)
+	}
+	for _, callback := range op.Callbacks {
+		if callback == nil {
+			continue
+		}
+		for _, item := range *callback {
+			if item == nil {
+				continue
+			}
+			item.Parameters = restrictParameters(item.Parameters, inputs)
+			for _, callbackOp := range pathItemOperations(item) {
+				restrictOperation(callbackOp, inputs, outputs)
+			}
+		}
+	}
+}
+
+// restrictParameters rewrites parameter schemas to input variants.
+func restrictParameters(params []openapi.Parameter, inputs map[string]string) []openapi.Parameter {
+	for i := range params {
+		params[i].Schema = restrictSchema(params[i].Schema, false, inputs)
+	}
+	return params
+}
+
+// restrictResponse rewrites response content and headers to output variants.
+func restrictResponse(resp *openapi.Response, outputs map[string]string) {
+	if resp == nil {
+		return
+	}
+	restrictContent(resp.Content, true, outputs)
+	for _, header := range resp.Headers {
+		if header != nil {
+			header.Schema = restrictSchema(header.Schema, true, outputs)
+		}
+	}
+}
+
+// restrictContent rewrites the schemas of every media type.
+func restrictContent(content map[string]*openapi.MediaType, output bool, rename map[string]string) {
+	for _, media := range content {
+		if media == nil {
+			continue
+		}
+		media.Schema = restrictSchema(media.Schema, output, rename)
+		media.ItemSchema = restrictSchema(media.ItemSchema, output, rename)
+	}
+}
+
+// pathItems returns every path item in the document: paths, webhooks and
+// reusable path items.
+func (p *Parser) pathItems() []*openapi.PathItem {
+	var items []*openapi.PathItem
+	for _, item := range p.openapi.Paths {
+		items = append(items, item)
+	}
+	for _, item := range p.openapi.Webhooks {
+		items = append(items, item)
+	}
+	if p.openapi.Components != nil {
+		for _, item := range p.openapi.Components.PathItems {
+			items = append(items, item)
+		}
+	}
+
+	result := items[:0]
+	for _, item := range items {
+		if item != nil {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// pathItemOperations returns the non-nil operations of a path item.
+func pathItemOperations(item *openapi.PathItem) []*openapi.Operation {
+	var ops []*openapi.Operation
+	for _, method := range openapi.Methods {
+		if op := item.Operation(method); op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// removeString returns list without value.
+func removeString(list []string, value string) []string {
+	result := list[:0]
+	for _, item := range list {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+	return result
+}
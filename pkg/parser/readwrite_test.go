@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestSplitReadWriteSchemas(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+type Profile struct {
+	Bio       string ` + "`json:\"bio\"`" + `
+	UpdatedAt string ` + "`json:\"updatedAt\" readonly:\"true\"`" + `
+}
+
+type User struct {
+	ID       int     ` + "`json:\"id\" readonly:\"true\" binding:\"required\"`" + `
+	Name     string  ` + "`json:\"name\" binding:\"required\"`" + `
+	Password string  ` + "`json:\"password\" writeonly:\"true\"`" + `
+	Profile  Profile ` + "`json:\"profile\"`" + `
+}
+
+type Team struct {
+	Members []User ` + "`json:\"members\"`" + `
+}
+
+type Error struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// @Summary Create user
+// @Param user body User true "User"
+// @Success 201 {object} User
+// @Failure 400 {object} Error
+// @Router /users [post]
+func CreateUser() {}
+
+// @Summary Get team
+// @Success 200 {object} Team
+// @Router /team [get]
+func GetTeam() {}
+`
+
+	tests := []struct {
+		name       string
+		naming     string
+		input      string
+		output     string
+		teamInput  string
+		teamOutput string
+	}{
+		{name: "input output", naming: "inputOutput", input: "UserInput", output: "UserOutput", teamInput: "TeamInput", teamOutput: "TeamOutput"},
+		{name: "request response", naming: "requestResponse", input: "User.Request", output: "User.Response", teamInput: "Team.Request", teamOutput: "Team.Response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			p := New()
+			if err := p.SetReadWriteSplit(tt.naming); err != nil {
+				t.Fatalf("SetReadWriteSplit() returned error: %v", err)
+			}
+			if err := p.ParseDir(tmpDir); err != nil {
+				t.Fatalf("ParseDir() returned error: %v", err)
+			}
+			if err := p.Validate(); err != nil {
+				t.Fatalf("Validate() returned error: %v", err)
+			}
+
+			schemas := p.openapi.Components.Schemas
+			if _, ok := schemas["User"]; ok {
+				t.Error("original User schema should be replaced by its variants")
+			}
+			if _, ok := schemas["Error"]; !ok {
+				t.Error("Error has no readOnly/writeOnly fields and should be kept")
+			}
+
+			input, ok := schemas[tt.input]
+			if !ok {
+				t.Fatalf("%s schema missing", tt.input)
+			}
+			assertStringSet(t, "input properties", keys(input.Properties), []string{"name", "password", "profile"})
+			assertStringSet(t, "input required", input.Required, []string{"name"})
+
+			output, ok := schemas[tt.output]
+			if !ok {
+				t.Fatalf("%s schema missing", tt.output)
+			}
+			assertStringSet(t, "output properties", keys(output.Properties), []string{"id", "name", "profile"})
+			assertStringSet(t, "output required", output.Required, []string{"id", "name"})
+
+			// Nested references follow the variant of their parent
+			profileInput := input.Properties["profile"].Ref
+			if profileInput == "" || schemas[profileInput[len(schemaRefPrefix):]] == nil {
+				t.Fatalf("input profile ref = %q", profileInput)
+			}
+			if _, ok := schemas[profileInput[len(schemaRefPrefix):]].Properties["updatedAt"]; ok {
+				t.Error("input Profile should not contain readOnly updatedAt")
+			}
+
+			create := p.openapi.Paths["/users"].Post
+			if got := create.RequestBody.Content["application/json"].Schema.Ref; got != schemaRefPrefix+tt.input {
+				t.Errorf("request body ref = %q, want %q", got, schemaRefPrefix+tt.input)
+			}
+			if got := create.Responses["201"].Content["application/json"].Schema.Ref; got != schemaRefPrefix+tt.output {
+				t.Errorf("response ref = %q, want %q", got, schemaRefPrefix+tt.output)
+			}
+			if got := create.Responses["400"].Content["application/json"].Schema.Ref; got != schemaRefPrefix+"Error" {
+				t.Errorf("error response ref = %q, want unchanged", got)
+			}
+
+			// Team only contains writeOnly fields through User
+			team := p.openapi.Paths["/team"].Get.Responses["200"].Content["application/json"].Schema.Ref
+			if team != schemaRefPrefix+tt.teamOutput {
+				t.Fatalf("team response ref = %q, want %q", team, schemaRefPrefix+tt.teamOutput)
+			}
+			teamSchema := schemas[tt.teamOutput]
+			if teamSchema == nil {
+				t.Fatalf("%s schema missing", tt.teamOutput)
+			}
+			if got := teamSchema.Properties["members"].Items.Ref; got != schemaRefPrefix+tt.output {
+				t.Errorf("team members ref = %q, want %q", got, schemaRefPrefix+tt.output)
+			}
+
+			// Team is never sent in a request, so its input variant is pruned
+			if _, ok := schemas[tt.teamInput]; ok {
+				t.Errorf("%s is unreferenced and should be pruned", tt.teamInput)
+			}
+		})
+	}
+}
+
+func TestSplitReadWriteSchemasDisabled(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.openapi.Components.Schemas["User"] = &openapi.Schema{
+		Type: typeObject,
+		Properties: map[string]*openapi.Schema{
+			"id": {Type: typeInteger, ReadOnly: true},
+		},
+	}
+
+	p.splitReadWriteSchemas()
+
+	if len(p.openapi.Components.Schemas) != 1 {
+		t.Errorf("schemas = %v, want only User", keys(p.openapi.Components.Schemas))
+	}
+}
+
+func TestSplitReadWriteSchemasPruning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		audit bool
+		want  []string
+	}{
+		{name: "response only", want: []string{"UserOutput"}},
+		// AuditInput is unreferenced but kept with AuditOutput, and keeps UserInput
+		{name: "unreferenced parent", audit: true, want: []string{"AuditInput", "AuditOutput", "UserInput", "UserOutput"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := New()
+			if err := p.SetReadWriteSplit("inputOutput"); err != nil {
+				t.Fatalf("SetReadWriteSplit() returned error: %v", err)
+			}
+			schemas := p.openapi.Components.Schemas
+			schemas["User"] = &openapi.Schema{
+				Type: typeObject,
+				Properties: map[string]*openapi.Schema{
+					"id": {Type: typeInteger, ReadOnly: true},
+				},
+			}
+			if tt.audit {
+				schemas["Audit"] = &openapi.Schema{
+					Type: typeObject,
+					Properties: map[string]*openapi.Schema{
+						"actor": {Ref: schemaRefPrefix + "User"},
+					},
+				}
+			}
+			p.openapi.Paths = map[string]*openapi.PathItem{
+				"/users": {
+					Get: &openapi.Operation{
+						Responses: openapi.Responses{
+							"200": {Content: map[string]*openapi.MediaType{
+								"application/json": {Schema: &openapi.Schema{Ref: schemaRefPrefix + "User"}},
+							}},
+						},
+					},
+				},
+			}
+
+			p.splitReadWriteSchemas()
+
+			assertStringSet(t, "schemas", keys(p.openapi.Components.Schemas), tt.want)
+		})
+	}
+}
+
+func TestRestrictSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := &openapi.Schema{
+		AllOf: []openapi.Schema{
+			{Ref: schemaRefPrefix + "Base"},
+			{
+				Type: typeObject,
+				Properties: map[string]*openapi.Schema{
+					"id":     {Type: typeInteger, ReadOnly: true},
+					"secret": {Type: typeString, WriteOnly: true},
+				},
+				Required: []string{"id", "secret"},
+			},
+		},
+	}
+	rename := map[string]string{"Base": "BaseInput"}
+
+	input := restrictSchema(schema, false, rename)
+	if input.AllOf[0].Ref != schemaRefPrefix+"BaseInput" {
+		t.Errorf("allOf ref = %q, want renamed", input.AllOf[0].Ref)
+	}
+	assertStringSet(t, "properties", keys(input.AllOf[1].Properties), []string{"secret"})
+	assertStringSet(t, "required", input.AllOf[1].Required, []string{"secret"})
+
+	// The source schema is left untouched
+	if schema.AllOf[0].Ref != schemaRefPrefix+"Base" || len(schema.AllOf[1].Properties) != 2 {
+		t.Error("restrictSchema modified its input")
+	}
+}
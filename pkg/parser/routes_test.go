@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMultipleRoutes(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+
+// @Summary Get user
+// @ID getUser
+// @PathParam id path int true "User ID"
+// @Param fields query string false "Fields"
+// @Success 200 {string} string "OK"
+// @Router /v1/users/{id} [get]
+// @Router /v2/users/{id} [get,head]
+func GetUser() {}
+
+// @Summary Update user
+// @ID updateUser
+// @PathParam id path int true "User ID"
+// @Success 204 {string} string "Updated"
+// @Router /v2/users/{id} [put]
+func UpdateUser() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	v1 := p.openapi.Paths["/v1/users/{id}"]
+	v2 := p.openapi.Paths["/v2/users/{id}"]
+	if v1 == nil || v2 == nil {
+		t.Fatalf("paths = %v, want both versions", keys(p.openapi.Paths))
+	}
+	if v1.Get == nil || v2.Get == nil || v2.Head == nil || v2.Put == nil {
+		t.Fatal("every @Router line and method should produce an operation")
+	}
+	if v1.Get == v2.Get {
+		t.Error("routes should not share an operation")
+	}
+
+	ids := []string{v1.Get.OperationID, v2.Get.OperationID, v2.Head.OperationID, v2.Put.OperationID}
+	assertStringSet(t, "operation IDs", ids, []string{"getUser", "getUser_2", "getUser_3", "updateUser"})
+
+	// @PathParam is shared through the path item, @Param stays on the operation
+	for name, item := range map[string]struct {
+		params, opParams int
+	}{
+		"/v1/users/{id}": {params: len(v1.Parameters), opParams: len(v1.Get.Parameters)},
+		"/v2/users/{id}": {params: len(v2.Parameters), opParams: len(v2.Get.Parameters)},
+	} {
+		if item.params != 1 || item.opParams != 1 {
+			t.Errorf("%s has %d path item and %d operation parameters, want 1 and 1", name, item.params, item.opParams)
+		}
+	}
+	if v2.Parameters[0].Name != "id" || v2.Parameters[0].In != "path" || !v2.Parameters[0].Required {
+		t.Errorf("path item parameter = %+v, want required path id", v2.Parameters[0])
+	}
+}
+
+func TestGetRoutes(t *testing.T) {
+	t.Parallel()
+	p := New()
+	proc := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	doc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// @Summary Users"},
+		{Text: "// @Router /users [get, post]"},
+		{Text: "// @Router /people [get]"},
+	}}
+
+	routes := proc.GetRoutes(doc)
+	want := []RouteInfo{{"/users", "get"}, {"/users", "post"}, {"/people", "get"}}
+	if len(routes) != len(want) {
+		t.Fatalf("GetRoutes() = %v, want %v", routes, want)
+	}
+	for i := range want {
+		if routes[i] != want[i] {
+			t.Errorf("GetRoutes()[%d] = %v, want %v", i, routes[i], want[i])
+		}
+	}
+
+	if first := proc.GetRouteInfo(doc); first != want[0] {
+		t.Errorf("GetRouteInfo() = %v, want %v", first, want[0])
+	}
+}
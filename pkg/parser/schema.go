@@ -53,7 +53,10 @@ type SchemaProcessor struct {
 	parser    *Parser
 	openapi   *openapi.OpenAPI
 	typeCache map[string]*TypeInfo
-	depth     int // Current parsing depth for nested structures
+	depth     int      // Current parsing depth for nested structures
+	typeName  string   // Named type currently being processed
+	fieldPath []string // Go field names leading to the current field
+	xmlStruct bool     // The struct being processed is encoded with encoding/xml
 }
 
 // NewSchemaProcessor creates a new schema processor.
@@ -68,6 +71,15 @@ func NewSchemaProcessor(p *Parser, spec *openapi.OpenAPI, typeCache map[string]*
 
 // ProcessStruct processes a struct type and returns an OpenAPI schema.
 func (s *SchemaProcessor) ProcessStruct(structType *ast.StructType, doc *ast.CommentGroup, typeName string) *openapi.Schema {
+	// Named types start a new field path; anonymous structs extend the current one
+	if typeName != "" {
+		previousType, previousPath, previousXML := s.typeName, s.fieldPath, s.xmlStruct
+		s.typeName, s.fieldPath, s.xmlStruct = typeName, nil, false
+		defer func() {
+			s.typeName, s.fieldPath, s.xmlStruct = previousType, previousPath, previousXML
+		}()
+	}
+
 	schema := &openapi.Schema{
 		Type:       "object",
 		Properties: make(map[string]*openapi.Schema),
@@ -79,11 +91,45 @@ func (s *SchemaProcessor) ProcessStruct(structType *ast.StructType, doc *ast.Com
 		s.parseStructDoc(doc, schema)
 	}
 
-	// Parse struct fields
-	for _, field := range structType.Fields.List {
-		s.processField(field, schema)
+	// XMLName xml.Name `xml:"ns name"` names the root element
+	schema.XML = structXML(structType)
+
+	// Parse struct fields, including the ones promoted from embedded structs
+	visited := map[*ast.StructType]bool{structType: true}
+	fields, refs := s.collectStructFields(structType, 0, false, "", visited)
+	s.applyStructFields(schema, fields, refs)
+
+	return schema
+}
+
+// ProcessMarshaler returns the schema of a type that implements json.Marshaler
+// or encoding.TextMarshaler. Such types are never expanded as structs: text
+// marshalers encode as strings and JSON marshalers as free-form values, unless
+// the type documentation declares the emitted type with @swaggertype/@format.
+func (s *SchemaProcessor) ProcessMarshaler(kind string, doc *ast.CommentGroup) *openapi.Schema {
+	schema := &openapi.Schema{}
+	if kind == marshalerText {
+		schema.Type = typeString
+	}
+
+	if doc == nil {
+		return schema
 	}
 
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+
+		switch {
+		case strings.HasPrefix(text, "@swaggertype "):
+			schema.Type = nil
+			s.applySwaggerType(strings.TrimSpace(strings.TrimPrefix(text, "@swaggertype ")), schema)
+		case strings.HasPrefix(text, "@format "):
+			schema.Format = strings.TrimSpace(strings.TrimPrefix(text, "@format "))
+		}
+	}
+
+	s.parseStructDoc(doc, schema)
+
 	return schema
 }
 
@@ -136,27 +182,27 @@ func (s *SchemaProcessor) parseStructDoc(doc *ast.CommentGroup, schema *openapi.
 	}
 }
 
-// processField processes a single struct field.
-func (s *SchemaProcessor) processField(field *ast.Field, schema *openapi.Schema) {
-	if len(field.Names) == 0 {
-		// Handle embedded fields
-		s.processEmbeddedField(field, schema)
-		return
-	}
-
-	fieldName := field.Names[0].Name
-
+// processNamedField processes a struct field under the given Go field name.
+// It returns nil when the field is not part of the JSON encoding.
+func (s *SchemaProcessor) processNamedField(field *ast.Field, fieldName string) *structField {
 	// Skip unexported fields
 	if !ast.IsExported(fieldName) {
-		return
+		return nil
 	}
 
 	// Check swaggerignore tag first (before parsing all tags)
-	if field.Tag != nil {
-		tagStr := strings.Trim(field.Tag.Value, "`")
-		if ignore := extractTag(tagStr, "swaggerignore"); strings.EqualFold(ignore, "true") {
-			return // Skip this field completely
-		}
+	if isSwaggerIgnored(field) {
+		return nil
+	}
+
+	// Skip fields whose type is excluded by the overrides file
+	if s.parser.IsTypeSkipped(s.parser.extractFieldTypeName(field.Type)) {
+		return nil
+	}
+
+	// XMLName only names the XML element of the enclosing struct
+	if isXMLNameField(field, fieldName) {
+		return nil
 	}
 
 	// Parse struct tags
@@ -164,7 +210,7 @@ func (s *SchemaProcessor) processField(field *ast.Field, schema *openapi.Schema)
 
 	// Skip if json tag is "-"
 	if tags.JSON == "-" {
-		return
+		return nil
 	}
 
 	// Get JSON name using property naming strategy
@@ -177,7 +223,14 @@ func (s *SchemaProcessor) processField(field *ast.Field, schema *openapi.Schema)
 	}
 
 	// Create field schema
+	s.fieldPath = append(s.fieldPath, fieldName)
 	fieldSchema := s.processFieldType(field.Type)
+	s.fieldPath = s.fieldPath[:len(s.fieldPath)-1]
+
+	// The ",string" option encodes numbers and booleans inside a JSON string
+	if tags.AsString {
+		applyStringOption(fieldSchema)
+	}
 
 	// Add field documentation
 	if field.Doc != nil {
@@ -186,41 +239,51 @@ func (s *SchemaProcessor) processField(field *ast.Field, schema *openapi.Schema)
 
 	// Apply struct tag validations and attributes
 	s.applyStructTagAttributes(tags, fieldSchema)
+	if err := applyXMLTag(tags.XML, jsonName, fieldName, s.xmlStruct, fieldSchema); err != nil {
+		s.parser.warnf("%s.%s: %v", s.typeName, fieldName, err)
+	}
 
-	// Add to properties
-	schema.Properties[jsonName] = fieldSchema
+	required := s.shouldBeRequired(tags, isPointer)
 
-	// Determine if field should be required
-	if s.shouldBeRequired(tags, isPointer) {
-		schema.Required = append(schema.Required, jsonName)
+	return &structField{
+		name:         jsonName,
+		schema:       fieldSchema,
+		required:     required,
+		wantRequired: required,
+		tagged:       jsonTagName(tags.JSON) != "",
 	}
 }
 
-// processEmbeddedField processes an embedded/anonymous field.
-func (s *SchemaProcessor) processEmbeddedField(field *ast.Field, schema *openapi.Schema) {
-	// Get the type name of the embedded field
-	var typeName string
+// isSwaggerIgnored reports whether a field is hidden with swaggerignore:"true".
+func isSwaggerIgnored(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tagStr := strings.Trim(field.Tag.Value, "`")
+	return strings.EqualFold(extractTag(tagStr, "swaggerignore"), valueTrue)
+}
 
-	switch t := field.Type.(type) {
+// applyStringOption rewrites a schema for the encoding/json ",string" option.
+// The option only affects integer, number and boolean values.
+func applyStringOption(schema *openapi.Schema) {
+	switch schema.Type {
+	case typeInteger, typeNumber, typeBoolean:
+		schema.Type = typeString
+		schema.Format = ""
+	}
+}
+
+// embeddedFieldName returns the implicit Go field name of an embedded field.
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
 	case *ast.Ident:
-		typeName = t.Name
+		return t.Name
 	case *ast.StarExpr:
-		if ident, ok := t.X.(*ast.Ident); ok {
-			typeName = ident.Name
-		}
+		return embeddedFieldName(t.X)
 	case *ast.SelectorExpr:
-		if ident, ok := t.X.(*ast.Ident); ok {
-			typeName = ident.Name + "." + t.Sel.Name
-		}
-	}
-
-	// Use allOf composition for embedded fields
-	if typeName != "" {
-		ref := openapi.Schema{
-			Ref: "#/components/schemas/" + typeName,
-		}
-		schema.AllOf = append(schema.AllOf, ref)
+		return t.Sel.Name
 	}
+	return ""
 }
 
 // parseFieldDoc parses field-level documentation.
@@ -277,8 +340,12 @@ type StructTags struct {
 	Pattern     string
 	Required    bool
 	OmitEmpty   bool
+	XML         string
+	AsString    bool // json ",string" option
+	Inline      bool // json ",inline" option
 	ReadOnly    bool
 	WriteOnly   bool
+	Audience    []string // audience and visibility tags
 }
 
 // parseStructTags parses struct tags.
@@ -294,11 +361,20 @@ func (s *SchemaProcessor) parseStructTags(field *ast.Field) StructTags {
 	// Parse JSON tag
 	if jsonTag := extractTag(tagStr, "json"); jsonTag != "" {
 		tags.JSON = jsonTag
-		if strings.Contains(jsonTag, "omitempty") {
-			tags.OmitEmpty = true
+		for _, option := range jsonTagOptions(jsonTag) {
+			switch option {
+			case "omitempty", "omitzero":
+				tags.OmitEmpty = true
+			case "string":
+				tags.AsString = true
+			case "inline":
+				tags.Inline = true
+			}
 		}
 	}
 
+	tags.XML = extractTag(tagStr, "xml")
+
 	// Parse binding tag (gin framework)
 	if bindingTag := extractTag(tagStr, "binding"); bindingTag != "" {
 		tags.Binding = bindingTag
@@ -328,6 +404,9 @@ func (s *SchemaProcessor) parseStructTags(field *ast.Field) StructTags {
 	tags.MaxLength = extractTag(tagStr, "maxLength")
 	tags.Pattern = extractTag(tagStr, "pattern")
 
+	// Audiences that see the field: audience:"partner,internal" or visibility:"private"
+	tags.Audience = splitAudiences(extractTag(tagStr, "audience") + "," + extractTag(tagStr, "visibility"))
+
 	// Check for readonly/writeonly
 	if extractTag(tagStr, "readonly") == valueTrue {
 		tags.ReadOnly = true
@@ -339,6 +418,21 @@ func (s *SchemaProcessor) parseStructTags(field *ast.Field) StructTags {
 	return tags
 }
 
+// jsonTagName returns the name part of a json struct tag.
+func jsonTagName(jsonTag string) string {
+	name, _, _ := strings.Cut(jsonTag, ",")
+	return name
+}
+
+// jsonTagOptions returns the options that follow the name in a json struct tag.
+func jsonTagOptions(jsonTag string) []string {
+	_, options, found := strings.Cut(jsonTag, ",")
+	if !found {
+		return nil
+	}
+	return strings.Split(options, ",")
+}
+
 // applyStructTagAttributes applies struct tag attributes to schema.
 func (s *SchemaProcessor) applyStructTagAttributes(tags StructTags, schema *openapi.Schema) {
 	// Apply swaggertype override first (highest priority)
@@ -351,6 +445,13 @@ func (s *SchemaProcessor) applyStructTagAttributes(tags StructTags, schema *open
 		s.applyExtensions(tags.Extensions, schema)
 	}
 
+	if len(tags.Audience) > 0 {
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+		schema.Extensions["x-audience"] = tags.Audience
+	}
+
 	// Apply explicit tag attributes
 	if tags.Example != "" {
 		schema.Example = tags.Example
@@ -373,13 +474,13 @@ func (s *SchemaProcessor) applyStructTagAttributes(tags StructTags, schema *open
 
 	if tags.Minimum != "" {
 		if minVal, err := strconv.ParseFloat(tags.Minimum, 64); err == nil {
-			schema.Minimum = minVal
+			schema.Minimum = &minVal
 		}
 	}
 
 	if tags.Maximum != "" {
 		if maxVal, err := strconv.ParseFloat(tags.Maximum, 64); err == nil {
-			schema.Maximum = maxVal
+			schema.Maximum = &maxVal
 		}
 	}
 
@@ -456,7 +557,7 @@ func (s *SchemaProcessor) applyBindingValidations(binding string, schema *openap
 				}
 			case typeInteger, typeNumber:
 				if minVal, err := strconv.ParseFloat(value, 64); err == nil {
-					schema.Minimum = minVal
+					schema.Minimum = &minVal
 				}
 			}
 		}
@@ -475,7 +576,7 @@ func (s *SchemaProcessor) applyBindingValidations(binding string, schema *openap
 				}
 			case typeInteger, typeNumber:
 				if maxVal, err := strconv.ParseFloat(value, 64); err == nil {
-					schema.Maximum = maxVal
+					schema.Maximum = &maxVal
 				}
 			}
 		}
@@ -493,7 +594,7 @@ func (s *SchemaProcessor) applyBindingValidations(binding string, schema *openap
 		if strings.HasPrefix(rule, "gte=") {
 			value := strings.TrimPrefix(rule, "gte=")
 			if minVal, err := strconv.ParseFloat(value, 64); err == nil {
-				schema.Minimum = minVal
+				schema.Minimum = &minVal
 			}
 		}
 
@@ -501,7 +602,7 @@ func (s *SchemaProcessor) applyBindingValidations(binding string, schema *openap
 		if strings.HasPrefix(rule, "lte=") {
 			value := strings.TrimPrefix(rule, "lte=")
 			if maxVal, err := strconv.ParseFloat(value, 64); err == nil {
-				schema.Maximum = maxVal
+				schema.Maximum = &maxVal
 			}
 		}
 
@@ -578,14 +679,8 @@ func (s *SchemaProcessor) applyValidateRules(validate string, schema *openapi.Sc
 }
 
 // processFieldType processes a field type and returns a schema.
+// Named types always become $refs, so recursive types need no depth limit.
 func (s *SchemaProcessor) processFieldType(expr ast.Expr) *openapi.Schema {
-	// Check depth limit if parseDepth is set
-	maxDepth := s.parser.GetParseDepth()
-	if maxDepth > 0 && s.depth >= maxDepth {
-		// Return empty schema when depth limit is reached
-		return &openapi.Schema{}
-	}
-
 	schema := &openapi.Schema{}
 
 	switch t := expr.(type) {
@@ -625,6 +720,11 @@ func (s *SchemaProcessor) processFieldType(expr ast.Expr) *openapi.Schema {
 				return s.parseOverrideType(override)
 			}
 
+			// Well-known types (time.Time, uuid.UUID, ...) are rendered inline
+			if known, ok := s.parser.LookupType(typeName); ok {
+				return known
+			}
+
 			schema.Ref = "#/components/schemas/" + typeName
 		}
 		return schema
@@ -634,11 +734,8 @@ func (s *SchemaProcessor) processFieldType(expr ast.Expr) *openapi.Schema {
 		return schema
 
 	case *ast.StructType:
-		// Inline struct - process it with depth tracking
-		s.depth++
-		result := s.ProcessStruct(t, nil, "")
-		s.depth--
-		return result
+		// Inline struct - expanded once, shared as a component when repeated
+		return s.processInlineStruct(t)
 	}
 
 	return schema
@@ -678,7 +775,17 @@ func (s *SchemaProcessor) identToSchema(name string) *openapi.Schema {
 	case "rune":
 		schema.Type = typeInteger
 		schema.Format = formatInt32
+	case "any":
+		// Free-form value, same as interface{}
 	default:
+		// Check for type override from the overrides file
+		if override, exists := s.parser.GetTypeOverride(name); exists {
+			return s.parseOverrideType(override)
+		}
+		// Types registered for this package are rendered inline
+		if known, ok := s.parser.LookupType(name); ok {
+			return known
+		}
 		// Reference to another schema
 		schema.Ref = "#/components/schemas/" + name
 	}
@@ -733,12 +840,7 @@ func (s *SchemaProcessor) applySwaggerType(swaggerType string, schema *openapi.S
 	}
 }
 
-// applyExtensions applies extensions tag to schema.
-// Supports formats:
-// - "x-nullable" - boolean true
-// - "x-abc=def" - string value
-// - "!x-omitempty" - boolean false (negation)
-// - "x-nullable,x-abc=def,!x-omitempty" - multiple extensions.
+// applyExtensions applies extensions tag to schema (see parseExtensionList).
 func (s *SchemaProcessor) applyExtensions(extensionsTag string, schema *openapi.Schema) {
 	if extensionsTag == "" {
 		return
@@ -748,46 +850,8 @@ func (s *SchemaProcessor) applyExtensions(extensionsTag string, schema *openapi.
 		schema.Extensions = make(map[string]interface{})
 	}
 
-	// Parse: "x-nullable,x-abc=def,!x-omitempty"
-	extensions := strings.Split(extensionsTag, ",")
-	for _, ext := range extensions {
-		ext = strings.TrimSpace(ext)
-		if ext == "" {
-			continue
-		}
-
-		switch {
-		case strings.HasPrefix(ext, "!"):
-			// Negation: !x-omitempty → x-omitempty: false
-			key := strings.TrimPrefix(ext, "!")
-			if strings.HasPrefix(key, "x-") {
-				schema.Extensions[key] = false
-			}
-		case strings.Contains(ext, "="):
-			// With value: x-abc=def
-			parts := strings.SplitN(ext, "=", 2)
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			if strings.HasPrefix(key, "x-") {
-				// Try to parse as number, otherwise string
-				switch {
-				case func() bool { n, e := strconv.ParseFloat(value, 64); return e == nil && n == n }():
-					numValue, _ := strconv.ParseFloat(value, 64)
-					schema.Extensions[key] = numValue
-				case value == valueTrue:
-					schema.Extensions[key] = true
-				case value == valueFalse:
-					schema.Extensions[key] = false
-				default:
-					schema.Extensions[key] = value
-				}
-			}
-		default:
-			// Boolean true: x-nullable
-			if strings.HasPrefix(ext, "x-") {
-				schema.Extensions[ext] = true
-			}
-		}
+	for key, value := range parseExtensionList(extensionsTag) {
+		schema.Extensions[key] = value
 	}
 }
 
@@ -804,10 +868,19 @@ func extractTag(tagStr, key string) string {
 }
 
 // parseOverrideType parses a type override string into a schema.
-// Supports: "string", "number", "integer", "boolean", "time.Time", etc.
+// Supports: "string", "number", "integer", "boolean", well-known types such as
+// "time.Time" and inline JSON schemas.
 func (s *SchemaProcessor) parseOverrideType(override string) *openapi.Schema {
 	schema := &openapi.Schema{}
 
+	// Inline JSON schema: replace a.T {"type":"string","format":"decimal"}
+	if isInlineSchema(override) {
+		if inline, err := parseInlineSchema(override); err == nil {
+			return inline
+		}
+		return schema
+	}
+
 	switch override {
 	case typeString:
 		schema.Type = typeString
@@ -817,10 +890,11 @@ func (s *SchemaProcessor) parseOverrideType(override string) *openapi.Schema {
 		schema.Type = typeInteger
 	case typeBoolean:
 		schema.Type = typeBoolean
-	case "time.Time":
-		schema.Type = typeString
-		schema.Format = formatDateTime
 	default:
+		// Well-known and registered types (time.Time, uuid.UUID, ...)
+		if known, ok := s.parser.LookupType(override); ok {
+			return known
+		}
 		// If not a primitive, treat as a reference
 		schema.Ref = "#/components/schemas/" + override
 	}
@@ -1460,3 +1460,229 @@ func TestExhaustiveProcessAnnotations(t *testing.T) {
 		_ = gproc.Process("@tag.description User management")
 	}
 }
+
+func TestProcessStructJSONOptions(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Account struct {
+	ID       int64           ` + "`json:\"id,string\"`" + `
+	Ratio    float64         ` + "`json:\"ratio,string,omitempty\"`" + `
+	Active   bool            ` + "`json:\",string\"`" + `
+	Name     string          ` + "`json:\"name,string\"`" + `
+	Secret   string          ` + "`json:\"-\"`" + `
+	Dash     string          ` + "`json:\"-,\"`" + `
+	Raw      json.RawMessage ` + "`json:\"raw\"`" + `
+	Value    any             ` + "`json:\"value\"`" + `
+	Min, Max int             ` + "`json:\",omitempty\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	var structType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if st, ok := n.(*ast.StructType); ok {
+			structType = st
+			return false
+		}
+		return true
+	})
+
+	p := New()
+	sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+	schema := sp.ProcessStruct(structType, nil, "Account")
+
+	tests := []struct {
+		property   string
+		wantType   interface{}
+		wantFormat string
+	}{
+		{"id", "string", ""},
+		{"ratio", "string", ""},
+		{"active", "string", ""},
+		{"name", "string", ""},
+		{"-", "string", ""},
+		{"raw", nil, ""},
+		{"value", nil, ""},
+		{"min", "integer", "int32"},
+		{"max", "integer", "int32"},
+	}
+
+	for _, tt := range tests {
+		prop, ok := schema.Properties[tt.property]
+		if !ok {
+			t.Errorf("property %q missing", tt.property)
+			continue
+		}
+		if prop.Type != tt.wantType {
+			t.Errorf("property %q Type = %v, want %v", tt.property, prop.Type, tt.wantType)
+		}
+		if prop.Format != tt.wantFormat {
+			t.Errorf("property %q Format = %q, want %q", tt.property, prop.Format, tt.wantFormat)
+		}
+		if prop.Ref != "" {
+			t.Errorf("property %q Ref = %q, want empty", tt.property, prop.Ref)
+		}
+	}
+
+	if _, ok := schema.Properties["secret"]; ok {
+		t.Error("field tagged json:\"-\" should be omitted")
+	}
+	if len(schema.Properties) != len(tests) {
+		t.Errorf("Properties length = %d, want %d", len(schema.Properties), len(tests))
+	}
+}
+
+func TestProcessStructEmbeddedJSON(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Order struct {
+	Base                         ` + "`json:\"base\"`" + `
+	*Audit                       ` + "`json:\"-\"`" + `
+	Meta     Metadata            ` + "`json:\",inline\"`" + `
+	Timestamps
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	var structType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if st, ok := n.(*ast.StructType); ok {
+			structType = st
+			return false
+		}
+		return true
+	})
+
+	p := New()
+	sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+	schema := sp.ProcessStruct(structType, nil, "Order")
+
+	base, ok := schema.Properties["base"]
+	if !ok {
+		t.Fatal("embedded field with a json name should be a property")
+	}
+	if base.Ref != "#/components/schemas/Base" {
+		t.Errorf("base Ref = %q, want %q", base.Ref, "#/components/schemas/Base")
+	}
+	if _, ok := schema.Properties["meta"]; ok {
+		t.Error("field tagged json:\",inline\" should not be a property")
+	}
+
+	var refs []string
+	for _, s := range schema.AllOf {
+		refs = append(refs, s.Ref)
+	}
+	want := []string{"#/components/schemas/Metadata", "#/components/schemas/Timestamps"}
+	if fmt.Sprint(refs) != fmt.Sprint(want) {
+		t.Errorf("AllOf refs = %v, want %v", refs, want)
+	}
+}
+
+func TestProcessMarshaler(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+// Money is encoded as a decimal string.
+// @swaggertype primitive,number
+// @format decimal
+type Money struct{}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+	doc := file.Decls[0].(*ast.GenDecl).Doc
+
+	tests := []struct {
+		name        string
+		kind        string
+		doc         *ast.CommentGroup
+		wantType    interface{}
+		wantFormat  string
+		description string
+	}{
+		{"text without doc", marshalerText, nil, "string", "", ""},
+		{"json without doc", marshalerJSON, nil, nil, "", ""},
+		{"json with declared type", marshalerJSON, doc, "number", "decimal", "Money is encoded as a decimal string."},
+		{"text with declared type", marshalerText, doc, "number", "decimal", "Money is encoded as a decimal string."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := New()
+			sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+			schema := sp.ProcessMarshaler(tt.kind, tt.doc)
+
+			if schema.Type != tt.wantType {
+				t.Errorf("Type = %v, want %v", schema.Type, tt.wantType)
+			}
+			if schema.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", schema.Format, tt.wantFormat)
+			}
+			if schema.Description != tt.description {
+				t.Errorf("Description = %q, want %q", schema.Description, tt.description)
+			}
+			if len(schema.Properties) != 0 {
+				t.Errorf("Properties length = %d, want 0", len(schema.Properties))
+			}
+		})
+	}
+}
+
+func TestProcessStructWithAudienceTags(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Account struct {
+	Name  string ` + "`json:\"name\"`" + `
+	SSN   string ` + "`json:\"ssn\" visibility:\"private\"`" + `
+	Quota int    ` + "`json:\"quota\" audience:\"partner, internal\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	p := New()
+	sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+
+	var structType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if st, ok := n.(*ast.StructType); ok {
+			structType = st
+			return false
+		}
+		return true
+	})
+
+	schema := sp.ProcessStruct(structType, nil, "Account")
+	tests := map[string][]string{
+		"name":  nil,
+		"ssn":   {"private"},
+		"quota": {"partner", "internal"},
+	}
+	for name, want := range tests {
+		property := schema.Properties[name]
+		if property == nil {
+			t.Fatalf("property %s missing", name)
+		}
+		got, _ := property.Extensions["x-audience"].([]string)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s x-audience = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Package parser - Security scheme and requirement annotations
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Security scheme types.
+const (
+	securityTypeAPIKey        = "apiKey"
+	securityTypeHTTP          = "http"
+	securityTypeOAuth2        = "oauth2"
+	securityTypeOpenIDConnect = "openIdConnect"
+	securityTypeMutualTLS     = "mutualTLS"
+)
+
+var (
+	// @securityDefinitions.<kind> <name> [arguments], where kind is basic,
+	// apikey, bearer, http, openIdConnect, mutualTLS or oauth2.<flow>.
+	securityDefinitionRegex = regexp.MustCompile(`^@(?i:securityDefinitions)\.((?i:basic|apikey|bearer|http|openidconnect|mutualtls|oauth2\.\w+))\s+(\S+)\s*(.*)$`)
+
+	// Attributes of the security definition above them, one per line.
+	securityAttributeRegex = regexp.MustCompile(`^@((?i:in|name|description|bearerFormat|openIdConnectUrl|tokenUrl|authorizationUrl|refreshUrl)|scope\.\S+)\s+(.+)$`)
+)
+
+// processSecurityDefinition starts a security scheme from a
+// @securityDefinitions.<kind> annotation. Its attributes follow on the next
+// lines:
+//
+//	@securityDefinitions.oauth2.authorizationCode OAuth2
+//	@authorizationUrl https://example.com/oauth/authorize
+//	@tokenUrl https://example.com/oauth/token
+//	@scope.read Grants read access
+func (g *GeneralInfoProcessor) processSecurityDefinition(text string) {
+	matches := securityDefinitionRegex.FindStringSubmatch(text)
+	kind := strings.ToLower(matches[1])
+	name := matches[2]
+	args := strings.TrimSpace(matches[3])
+
+	schemes := g.openapi.Components.SecuritySchemes
+	scheme := &openapi.SecurityScheme{}
+	g.lastFlow = nil
+
+	switch {
+	case kind == "basic":
+		scheme.Type, scheme.Scheme, scheme.Description = securityTypeHTTP, "basic", args
+
+	case kind == "bearer":
+		scheme.Type, scheme.Scheme, scheme.Description = securityTypeHTTP, "bearer", args
+
+	case kind == "http":
+		// @securityDefinitions.http DigestAuth digest [description]
+		scheme.Type = securityTypeHTTP
+		scheme.Scheme, scheme.Description, _ = strings.Cut(args, " ")
+		scheme.Description = strings.TrimSpace(scheme.Description)
+
+	case kind == "apikey":
+		// Either inline (<param> <in> [description]) or with @in and @name
+		scheme.Type = securityTypeAPIKey
+		if fields := strings.Fields(args); len(fields) >= 2 {
+			scheme.Name, scheme.In = fields[0], fields[1]
+			scheme.Description = strings.TrimSpace(strings.Join(fields[2:], " "))
+		} else {
+			scheme.Description = args
+		}
+
+	case kind == "openidconnect":
+		// @securityDefinitions.openIdConnect OIDC <discovery url> [description]
+		scheme.Type = securityTypeOpenIDConnect
+		scheme.OpenIDConnectURL, scheme.Description, _ = strings.Cut(args, " ")
+		scheme.Description = strings.TrimSpace(scheme.Description)
+
+	case kind == "mutualtls":
+		scheme.Type, scheme.Description = securityTypeMutualTLS, args
+
+	case strings.HasPrefix(kind, "oauth2."):
+		// Flows declared under the same name share one scheme
+		if existing, ok := schemes[name]; ok && existing.Type == securityTypeOAuth2 {
+			scheme = existing
+		}
+		scheme.Type = securityTypeOAuth2
+		if args != "" {
+			scheme.Description = args
+		}
+		if scheme.Flows == nil {
+			scheme.Flows = &openapi.OAuthFlows{}
+		}
+		g.lastFlow = addOAuthFlow(scheme.Flows, strings.TrimPrefix(kind, "oauth2."))
+	}
+
+	schemes[name] = scheme
+	g.lastScheme = scheme
+}
+
+// addOAuthFlow adds an empty flow by its swag or OpenAPI name and returns it.
+func addOAuthFlow(flows *openapi.OAuthFlows, flowName string) *openapi.OAuthFlow {
+	flow := &openapi.OAuthFlow{Scopes: make(map[string]string)}
+
+	switch flowName {
+	case "implicit":
+		flows.Implicit = flow
+	case "password":
+		flows.Password = flow
+	case "application", "clientcredentials":
+		flows.ClientCredentials = flow
+	case "accesscode", "authorizationcode":
+		flows.AuthorizationCode = flow
+	case "deviceauthorization":
+		flows.DeviceAuthorization = flow
+	default:
+		return nil
+	}
+
+	return flow
+}
+
+// processSecurityAttribute applies an attribute line to the last security
+// definition and reports whether the line was one.
+func (g *GeneralInfoProcessor) processSecurityAttribute(text string) bool {
+	matches := securityAttributeRegex.FindStringSubmatch(text)
+	if matches == nil || g.lastScheme == nil {
+		return false
+	}
+
+	scheme := g.lastScheme
+	value := strings.TrimSpace(matches[2])
+
+	if scope, ok := strings.CutPrefix(matches[1], "scope."); ok {
+		if g.lastFlow == nil {
+			return false
+		}
+		g.lastFlow.Scopes[scope] = value
+		return true
+	}
+
+	switch strings.ToLower(matches[1]) {
+	case "in":
+		scheme.In = value
+	case "name":
+		scheme.Name = value
+	case "description":
+		if scheme.Description != "" {
+			scheme.Description += "\n"
+		}
+		scheme.Description += value
+	case "bearerformat":
+		scheme.BearerFormat = value
+	case "openidconnecturl":
+		scheme.OpenIDConnectURL = value
+	case "tokenurl", "authorizationurl", "refreshurl":
+		if g.lastFlow == nil {
+			return false
+		}
+		switch strings.ToLower(matches[1]) {
+		case "tokenurl":
+			g.lastFlow.TokenURL = value
+		case "authorizationurl":
+			g.lastFlow.AuthorizationURL = value
+		default:
+			g.lastFlow.RefreshURL = value
+		}
+	}
+
+	return true
+}
+
+// parseSecurityRequirements parses the expression of a @Security annotation.
+// "||" separates alternatives and "&&" combines schemes that are all
+// required: `ApiKey && OAuth2[read,write] || BasicAuth`.
+func parseSecurityRequirements(expr string) []openapi.SecurityRequirement {
+	var requirements []openapi.SecurityRequirement
+
+	for _, alternative := range strings.Split(expr, "||") {
+		requirement := make(openapi.SecurityRequirement)
+		for _, item := range strings.Split(alternative, "&&") {
+			item = strings.TrimSpace(item)
+			name, scopeList, hasScopes := strings.Cut(item, "[")
+			fields := strings.Fields(name)
+			if len(fields) == 0 {
+				continue
+			}
+			name = fields[0]
+
+			scopes := []string{}
+			if hasScopes {
+				for _, scope := range strings.Split(strings.TrimSuffix(scopeList, "]"), ",") {
+					if scope = strings.TrimSpace(scope); scope != "" {
+						scopes = append(scopes, scope)
+					}
+				}
+			}
+			requirement[name] = scopes
+		}
+		if len(requirement) > 0 {
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	return requirements
+}
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestParseSecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+// @description Security test API
+//
+// @securityDefinitions.basic BasicAuth
+// @securityDefinitions.apikey ApiKey
+// @in header
+// @name X-API-Key
+// @description Key issued by the admin console
+// @securityDefinitions.bearer BearerAuth
+// @bearerFormat JWT
+// @securityDefinitions.http DigestAuth digest Digest access authentication
+// @securityDefinitions.openIdConnect OIDC https://id.example.com/.well-known/openid-configuration
+// @securityDefinitions.mutualTLS ClientCert Client certificate
+// @securitydefinitions.oauth2.authorizationCode OAuth2
+// @authorizationUrl https://id.example.com/authorize
+// @tokenUrl https://id.example.com/token
+// @refreshUrl https://id.example.com/refresh
+// @scope.read Grants read access
+// @scope.write Grants write access
+// @securityDefinitions.oauth2.application OAuth2
+// @tokenUrl https://id.example.com/token
+// @scope.admin Grants admin access
+
+// @Summary List items
+// @Security ApiKey && OAuth2[read, write] || BearerAuth
+// @Security BasicAuth
+// @Success 200 {string} string "OK"
+// @Router /items [get]
+func List() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	if p.openapi.Info.Description != "Security test API" {
+		t.Errorf("info description = %q", p.openapi.Info.Description)
+	}
+
+	want := map[string]*openapi.SecurityScheme{
+		"BasicAuth": {Type: "http", Scheme: "basic"},
+		"ApiKey": {
+			Type: "apiKey", In: "header", Name: "X-API-Key",
+			Description: "Key issued by the admin console",
+		},
+		"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		"DigestAuth": {Type: "http", Scheme: "digest", Description: "Digest access authentication"},
+		"OIDC": {
+			Type:             "openIdConnect",
+			OpenIDConnectURL: "https://id.example.com/.well-known/openid-configuration",
+		},
+		"ClientCert": {Type: "mutualTLS", Description: "Client certificate"},
+		"OAuth2": {
+			Type: "oauth2",
+			Flows: &openapi.OAuthFlows{
+				AuthorizationCode: &openapi.OAuthFlow{
+					AuthorizationURL: "https://id.example.com/authorize",
+					TokenURL:         "https://id.example.com/token",
+					RefreshURL:       "https://id.example.com/refresh",
+					Scopes:           map[string]string{"read": "Grants read access", "write": "Grants write access"},
+				},
+				ClientCredentials: &openapi.OAuthFlow{
+					TokenURL: "https://id.example.com/token",
+					Scopes:   map[string]string{"admin": "Grants admin access"},
+				},
+			},
+		},
+	}
+	for name, wantScheme := range want {
+		if got := p.openapi.Components.SecuritySchemes[name]; !reflect.DeepEqual(got, wantScheme) {
+			t.Errorf("scheme %s = %+v, want %+v", name, got, wantScheme)
+		}
+	}
+
+	wantSecurity := []openapi.SecurityRequirement{
+		{"ApiKey": {}, "OAuth2": {"read", "write"}},
+		{"BearerAuth": {}},
+		{"BasicAuth": {}},
+	}
+	if got := p.openapi.Paths["/items"].Get.Security; !reflect.DeepEqual(got, wantSecurity) {
+		t.Errorf("security = %v, want %v", got, wantSecurity)
+	}
+}
+
+func TestParseSecurityRequirements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr string
+		want []openapi.SecurityRequirement
+	}{
+		{"ApiKey", []openapi.SecurityRequirement{{"ApiKey": {}}}},
+		{"OAuth2[read,write]", []openapi.SecurityRequirement{{"OAuth2": {"read", "write"}}}},
+		{"ApiKey && OAuth2[read]", []openapi.SecurityRequirement{{"ApiKey": {}, "OAuth2": {"read"}}}},
+		{"ApiKey || BasicAuth", []openapi.SecurityRequirement{{"ApiKey": {}}, {"BasicAuth": {}}}},
+		{" || ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			if got := parseSecurityRequirements(tt.expr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSecurityRequirements(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
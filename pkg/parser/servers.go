@@ -0,0 +1,62 @@
+// Package parser - Server annotations
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+var (
+	// @server.variable region us-east enum(us-east,eu-west) "Deployment region"
+	// (@Server.variable and @PathServer.variable in operations)
+	serverVariableRegex = regexp.MustCompile(`^@(?:server|Server|PathServer)\.variable\s+(\w+)\s+(\S+)(?:\s+enum\(([^)]*)\))?(?:\s+"([^"]*)")?\s*$`)
+
+	// Operation and path item servers: @Server / @PathServer <url> ["description"]
+	operationServerRegex = regexp.MustCompile(`^@Server\s+(\S+)\s*(.*)$`)
+	pathServerRegex      = regexp.MustCompile(`^@PathServer\s+(\S+)\s*(.*)$`)
+)
+
+// parseServer parses the url and optional description of a server annotation.
+func parseServer(regex *regexp.Regexp, text string) openapi.Server {
+	matches := regex.FindStringSubmatch(text)
+	return openapi.Server{
+		URL:         matches[1],
+		Description: strings.Trim(strings.TrimSpace(matches[2]), `"`),
+	}
+}
+
+// addServerVariable parses a @server.variable annotation into the last server.
+func addServerVariable(servers []openapi.Server, text string) {
+	matches := serverVariableRegex.FindStringSubmatch(text)
+	if matches == nil || len(servers) == 0 {
+		return
+	}
+
+	variable := &openapi.ServerVariable{
+		Default:     matches[2],
+		Description: matches[4],
+	}
+	for _, value := range strings.Split(matches[3], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			variable.Enum = append(variable.Enum, value)
+		}
+	}
+
+	server := &servers[len(servers)-1]
+	if server.Variables == nil {
+		server.Variables = make(map[string]*openapi.ServerVariable)
+	}
+	server.Variables[matches[1]] = variable
+}
+
+// hasServer reports whether a server list contains url.
+func hasServer(servers []openapi.Server, url string) bool {
+	for _, server := range servers {
+		if server.URL == url {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestParseServers(t *testing.T) {
+	t.Parallel()
+
+	content := `package main
+
+// @title Test API
+// @version 1.0.0
+// @server https://{region}.api.example.com/{version} Production
+// @server.variable region us-east enum(us-east,eu-west) "Deployment region"
+// @server.variable version v1
+
+// @Summary Upload file
+// @Server https://uploads.example.com "Upload endpoint"
+// @Server.variable tier standard enum(standard,premium)
+// @PathServer https://files.example.com
+// @Success 200 {string} string "OK"
+// @Router /files [post]
+func Upload() {}
+
+// @Summary List files
+// @PathServer https://files.example.com
+// @Success 200 {string} string "OK"
+// @Router /files [get]
+func List() {}
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	p := New()
+	if err := p.ParseDir(tmpDir); err != nil {
+		t.Fatalf("ParseDir() returned error: %v", err)
+	}
+
+	wantVariables := map[string]*openapi.ServerVariable{
+		"region":  {Default: "us-east", Enum: []string{"us-east", "eu-west"}, Description: "Deployment region"},
+		"version": {Default: "v1"},
+	}
+	if got := p.openapi.Servers[0].Variables; !reflect.DeepEqual(got, wantVariables) {
+		t.Errorf("server variables = %+v, want %+v", got, wantVariables)
+	}
+
+	item := p.openapi.Paths["/files"]
+	wantOp := []openapi.Server{{
+		URL:         "https://uploads.example.com",
+		Description: "Upload endpoint",
+		Variables: map[string]*openapi.ServerVariable{
+			"tier": {Default: "standard", Enum: []string{"standard", "premium"}},
+		},
+	}}
+	if !reflect.DeepEqual(item.Post.Servers, wantOp) {
+		t.Errorf("operation servers = %+v, want %+v", item.Post.Servers, wantOp)
+	}
+	if len(item.Get.Servers) != 0 {
+		t.Errorf("GET servers = %+v, want none", item.Get.Servers)
+	}
+
+	// Both handlers declare the same path server; it is listed once
+	if len(item.Servers) != 1 || item.Servers[0].URL != "https://files.example.com" {
+		t.Errorf("path item servers = %+v, want files.example.com once", item.Servers)
+	}
+}
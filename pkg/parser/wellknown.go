@@ -0,0 +1,227 @@
+// Package parser - Well-known Go type registry
+package parser
+
+import (
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// Common patterns used by well-known types.
+const (
+	patternUUID    = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	patternDecimal = `^-?[0-9]+(\.[0-9]+)?$`
+	patternULID    = `^[0-9A-HJKMNP-TV-Z]{26}$`
+	patternHex24   = `^[0-9a-f]{24}$`
+)
+
+// wellKnownTypes maps Go types from the standard library and popular modules
+// to the schema of their JSON encoding. Keys use the "package.Type" form;
+// import paths are reduced to their last segment before lookup.
+// The sql.Null* wrappers have no MarshalJSON method and encode as objects
+// such as {"String": "a", "Valid": true}; APIs that encode them as their
+// value can map them in the overrides file.
+var wellKnownTypes = map[string]openapi.Schema{
+	// time
+	"time.Time":     {Type: typeString, Format: formatDateTime},
+	"time.Duration": {Type: typeInteger, Format: formatInt64},
+
+	// encoding/json
+	"json.Number":     {Type: typeNumber},
+	"json.RawMessage": {},
+
+	// math/big
+	"big.Int":   {Type: typeInteger},
+	"big.Float": {Type: typeString, Pattern: patternDecimal},
+	"big.Rat":   {Type: typeString, Pattern: `^-?[0-9]+(/[0-9]+)?$`},
+
+	// net, net/netip
+	"net.IP":         {Type: typeString, Format: "ip"},
+	"netip.Addr":     {Type: typeString, Format: "ip"},
+	"netip.Prefix":   {Type: typeString, Format: "cidr"},
+	"netip.AddrPort": {Type: typeString},
+
+	// database/sql
+	"sql.NullString":  sqlNull("String", openapi.Schema{Type: typeString}),
+	"sql.NullInt64":   sqlNull("Int64", openapi.Schema{Type: typeInteger, Format: formatInt64}),
+	"sql.NullInt32":   sqlNull("Int32", openapi.Schema{Type: typeInteger, Format: formatInt32}),
+	"sql.NullInt16":   sqlNull("Int16", openapi.Schema{Type: typeInteger, Format: formatInt32}),
+	"sql.NullByte":    sqlNull("Byte", openapi.Schema{Type: typeInteger, Format: formatInt32}),
+	"sql.NullFloat64": sqlNull("Float64", openapi.Schema{Type: typeNumber, Format: formatDouble}),
+	"sql.NullBool":    sqlNull("Bool", openapi.Schema{Type: typeBoolean}),
+	"sql.NullTime":    sqlNull("Time", openapi.Schema{Type: typeString, Format: formatDateTime}),
+
+	// github.com/google/uuid, github.com/gofrs/uuid
+	"uuid.UUID":     {Type: typeString, Format: formatUUID, Pattern: patternUUID},
+	"uuid.NullUUID": {Type: typeString, Format: formatUUID, Pattern: patternUUID},
+
+	// github.com/shopspring/decimal
+	"decimal.Decimal":     {Type: typeString, Format: "decimal", Pattern: patternDecimal},
+	"decimal.NullDecimal": {Type: typeString, Format: "decimal", Pattern: patternDecimal},
+
+	// github.com/oklog/ulid
+	"ulid.ULID": {Type: typeString, Pattern: patternULID},
+
+	// go.mongodb.org/mongo-driver/bson/primitive
+	"primitive.ObjectID": {Type: typeString, Pattern: patternHex24},
+}
+
+// sqlNull returns the schema of a database/sql Null* struct, whose value
+// is in the named field next to Valid.
+func sqlNull(field string, value openapi.Schema) openapi.Schema {
+	return openapi.Schema{
+		Type: typeObject,
+		Properties: map[string]*openapi.Schema{
+			field:   &value,
+			"Valid": {Type: typeBoolean},
+		},
+		Required: []string{field, "Valid"},
+	}
+}
+
+// RegisterType maps a Go type name to the schema of its JSON encoding.
+// Registered types take precedence over the built-in well-known types.
+func (p *Parser) RegisterType(typeName string, schema *openapi.Schema) {
+	if typeName == "" || schema == nil {
+		return
+	}
+	p.customTypes[normalizeTypeName(typeName)] = cloneSchema(schema)
+}
+
+// LookupType returns a fresh copy of the schema registered for a Go type,
+// checking registered types before the built-in well-known types.
+func (p *Parser) LookupType(typeName string) (*openapi.Schema, bool) {
+	name := normalizeTypeName(typeName)
+	if name == "" {
+		return nil, false
+	}
+
+	if schema, ok := p.customTypes[name]; ok {
+		return cloneSchema(schema), true
+	}
+
+	if schema, ok := wellKnownTypes[name]; ok {
+		return cloneSchema(&schema), true
+	}
+
+	return nil, false
+}
+
+// normalizeTypeName reduces "github.com/google/uuid.UUID" to "uuid.UUID".
+func normalizeTypeName(typeName string) string {
+	typeName = strings.TrimPrefix(strings.TrimSpace(typeName), "*")
+	if idx := strings.LastIndex(typeName, "/"); idx >= 0 {
+		typeName = typeName[idx+1:]
+	}
+	return typeName
+}
+
+// cloneSchema returns a deep copy of a schema so callers can modify it freely.
+func cloneSchema(schema *openapi.Schema) *openapi.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	clone := *schema
+
+	if schema.Enum != nil {
+		clone.Enum = append([]interface{}(nil), schema.Enum...)
+	}
+	if schema.Required != nil {
+		clone.Required = append([]string(nil), schema.Required...)
+	}
+	switch types := schema.Type.(type) {
+	case []interface{}:
+		clone.Type = append([]interface{}(nil), types...)
+	case []string:
+		clone.Type = append([]string(nil), types...)
+	}
+	if schema.Examples != nil {
+		clone.Examples = append([]interface{}(nil), schema.Examples...)
+	}
+
+	if schema.Minimum != nil {
+		minimum := *schema.Minimum
+		clone.Minimum = &minimum
+	}
+	if schema.Maximum != nil {
+		maximum := *schema.Maximum
+		clone.Maximum = &maximum
+	}
+
+	clone.Items = cloneSchema(schema.Items)
+	clone.Not = cloneSchema(schema.Not)
+
+	if schema.PrefixItems != nil {
+		clone.PrefixItems = make([]*openapi.Schema, len(schema.PrefixItems))
+		for i, item := range schema.PrefixItems {
+			clone.PrefixItems[i] = cloneSchema(item)
+		}
+	}
+
+	if schema.Properties != nil {
+		clone.Properties = make(map[string]*openapi.Schema, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			clone.Properties[name] = cloneSchema(prop)
+		}
+	}
+
+	if additional, ok := schema.AdditionalProperties.(*openapi.Schema); ok {
+		clone.AdditionalProperties = cloneSchema(additional)
+	}
+
+	clone.AllOf = cloneSchemaList(schema.AllOf)
+	clone.OneOf = cloneSchemaList(schema.OneOf)
+	clone.AnyOf = cloneSchemaList(schema.AnyOf)
+
+	if schema.XML != nil {
+		xml := *schema.XML
+		xml.Extensions = cloneExtensions(xml.Extensions)
+		clone.XML = &xml
+	}
+	if schema.Discriminator != nil {
+		discriminator := *schema.Discriminator
+		if discriminator.Mapping != nil {
+			discriminator.Mapping = make(map[string]string, len(schema.Discriminator.Mapping))
+			for key, value := range schema.Discriminator.Mapping {
+				discriminator.Mapping[key] = value
+			}
+		}
+		discriminator.Extensions = cloneExtensions(discriminator.Extensions)
+		clone.Discriminator = &discriminator
+	}
+	if schema.ExternalDocs != nil {
+		docs := *schema.ExternalDocs
+		docs.Extensions = cloneExtensions(docs.Extensions)
+		clone.ExternalDocs = &docs
+	}
+
+	clone.Extensions = cloneExtensions(schema.Extensions)
+
+	return &clone
+}
+
+// cloneExtensions copies an extensions map.
+func cloneExtensions(extensions map[string]interface{}) map[string]interface{} {
+	if extensions == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(extensions))
+	for key, value := range extensions {
+		clone[key] = value
+	}
+	return clone
+}
+
+// cloneSchemaList deep copies a list of composition schemas.
+func cloneSchemaList(schemas []openapi.Schema) []openapi.Schema {
+	if schemas == nil {
+		return nil
+	}
+
+	clone := make([]openapi.Schema, len(schemas))
+	for i := range schemas {
+		clone[i] = *cloneSchema(&schemas[i])
+	}
+	return clone
+}
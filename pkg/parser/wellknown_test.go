@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestLookupTypeWellKnown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typeName   string
+		wantType   interface{}
+		wantFormat string
+		hasPattern bool
+	}{
+		{"time.Time", "string", "date-time", false},
+		{"time.Duration", "integer", "int64", false},
+		{"uuid.UUID", "string", "uuid", true},
+		{"github.com/google/uuid.UUID", "string", "uuid", true},
+		{"*uuid.UUID", "string", "uuid", true},
+		{"decimal.Decimal", "string", "decimal", true},
+		{"big.Int", "integer", "", false},
+		{"netip.Addr", "string", "ip", false},
+		{"sql.NullString", "object", "", false},
+		{"sql.NullInt64", "object", "", false},
+		{"json.Number", "number", "", false},
+		{"json.RawMessage", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			t.Parallel()
+			p := New()
+
+			schema, ok := p.LookupType(tt.typeName)
+			if !ok {
+				t.Fatalf("LookupType(%q) not found", tt.typeName)
+			}
+			if schema.Type != tt.wantType {
+				t.Errorf("Type = %v, want %v", schema.Type, tt.wantType)
+			}
+			if schema.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", schema.Format, tt.wantFormat)
+			}
+			if (schema.Pattern != "") != tt.hasPattern {
+				t.Errorf("Pattern = %q, want pattern: %v", schema.Pattern, tt.hasPattern)
+			}
+		})
+	}
+}
+
+func TestLookupTypeSQLNull(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	// encoding/json writes the struct fields: {"Int64": 1, "Valid": true}
+	schema, _ := p.LookupType("sql.NullInt64")
+	if value := schema.Properties["Int64"]; value == nil || value.Type != "integer" || value.Format != "int64" {
+		t.Errorf("Int64 = %+v, want an int64 property", value)
+	}
+	if valid := schema.Properties["Valid"]; valid == nil || valid.Type != "boolean" {
+		t.Errorf("Valid = %+v, want a boolean property", valid)
+	}
+}
+
+func TestLookupTypeUnknown(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	for _, name := range []string{"", "User", "UUID", "models.User"} {
+		if _, ok := p.LookupType(name); ok {
+			t.Errorf("LookupType(%q) should not be found", name)
+		}
+	}
+}
+
+func TestLookupTypeReturnsCopy(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	first, _ := p.LookupType("uuid.UUID")
+	first.Format = "changed"
+
+	second, _ := p.LookupType("uuid.UUID")
+	if second.Format != "uuid" {
+		t.Errorf("LookupType() should return a fresh copy, got Format %q", second.Format)
+	}
+}
+
+func TestCloneSchemaCopiesNestedObjects(t *testing.T) {
+	t.Parallel()
+
+	schema := &openapi.Schema{
+		Type:          []string{"string", "null"},
+		Examples:      []interface{}{"a"},
+		XML:           &openapi.XML{Name: "pet", Extensions: map[string]interface{}{"x-a": 1}},
+		Discriminator: &openapi.Discriminator{PropertyName: "kind", Mapping: map[string]string{"cat": "#/components/schemas/Cat"}},
+		ExternalDocs:  &openapi.ExternalDocs{URL: "https://example.com"},
+	}
+	clone := cloneSchema(schema)
+
+	clone.Type.([]string)[0] = "integer"
+	clone.Examples[0] = "b"
+	clone.XML.Name = "changed"
+	clone.XML.Extensions["x-a"] = 2
+	clone.Discriminator.Mapping["cat"] = "changed"
+	clone.ExternalDocs.URL = "changed"
+
+	if schema.Type.([]string)[0] != "string" || schema.Examples[0] != "a" || schema.XML.Name != "pet" || schema.XML.Extensions["x-a"] != 1 ||
+		schema.Discriminator.Mapping["cat"] != "#/components/schemas/Cat" || schema.ExternalDocs.URL != "https://example.com" {
+		t.Errorf("cloneSchema() shares nested values with the original: %+v", schema)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	money := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"amount":   {Type: "string"},
+			"currency": {Type: "string", Enum: []interface{}{"USD", "EUR"}},
+		},
+		Required:   []string{"amount"},
+		Extensions: map[string]interface{}{"x-money": true},
+	}
+	p.RegisterType("github.com/acme/money.Money", money)
+	p.RegisterType("time.Time", &openapi.Schema{Type: "integer", Format: "unix"})
+	p.RegisterType("", money)
+	p.RegisterType("ignored.Nil", nil)
+
+	// Mutating the original must not change the registry
+	money.Properties["amount"].Type = "number"
+
+	got, ok := p.LookupType("money.Money")
+	if !ok {
+		t.Fatal("money.Money should be registered")
+	}
+	if got.Properties["amount"].Type != "string" {
+		t.Errorf("amount Type = %v, want string", got.Properties["amount"].Type)
+	}
+	if len(got.Properties["currency"].Enum) != 2 || got.Extensions["x-money"] != true {
+		t.Errorf("registered schema not copied completely: %+v", got)
+	}
+
+	if tm, _ := p.LookupType("time.Time"); tm.Format != "unix" {
+		t.Errorf("registered types should take precedence, got Format %q", tm.Format)
+	}
+	if _, ok := p.LookupType("ignored.Nil"); ok {
+		t.Error("nil schema should not be registered")
+	}
+}
+
+func TestProcessStructWellKnownTypes(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Payment struct {
+	ID       uuid.UUID       ` + "`json:\"id\"`" + `
+	Amount   decimal.Decimal ` + "`json:\"amount\"`" + `
+	Timeout  time.Duration   ` + "`json:\"timeout\"`" + `
+	Note     *sql.NullString ` + "`json:\"note\"`" + `
+	Tags     []netip.Addr    ` + "`json:\"tags\"`" + `
+	Currency Currency        ` + "`json:\"currency\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	var structType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if st, ok := n.(*ast.StructType); ok {
+			structType = st
+			return false
+		}
+		return true
+	})
+
+	p := New()
+	p.RegisterType("Currency", &openapi.Schema{Type: "string", Pattern: "^[A-Z]{3}$"})
+	sp := NewSchemaProcessor(p, p.openapi, p.typeCache)
+	schema := sp.ProcessStruct(structType, nil, "Payment")
+
+	for name, prop := range schema.Properties {
+		if prop.Ref != "" {
+			t.Errorf("property %q should be inline, got $ref %q", name, prop.Ref)
+		}
+	}
+	if schema.Properties["id"].Format != "uuid" {
+		t.Errorf("id Format = %q, want uuid", schema.Properties["id"].Format)
+	}
+	if schema.Properties["tags"].Items.Format != "ip" {
+		t.Errorf("tags items Format = %q, want ip", schema.Properties["tags"].Items.Format)
+	}
+	if schema.Properties["currency"].Pattern != "^[A-Z]{3}$" {
+		t.Errorf("currency Pattern = %q, want ^[A-Z]{3}$", schema.Properties["currency"].Pattern)
+	}
+}
+
+func TestParseSchemaTypeWellKnown(t *testing.T) {
+	t.Parallel()
+	p := New()
+	op := NewOperationProcessor(p, p.openapi, p.typeCache)
+
+	schema := op.parseSchemaType("[]uuid.UUID")
+	if schema.Items == nil || schema.Items.Format != "uuid" || schema.Items.Ref != "" {
+		t.Errorf("parseSchemaType([]uuid.UUID) = %+v, want inline uuid items", schema.Items)
+	}
+
+	p.AddReferencedType("uuid.UUID")
+	if p.IsTypeReferenced("uuid.UUID") {
+		t.Error("well-known types should not be tracked as referenced schemas")
+	}
+}
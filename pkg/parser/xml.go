@@ -0,0 +1,179 @@
+// Package parser - XML metadata from xml struct tags
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+// XML node types (OpenAPI 3.2.0).
+const (
+	xmlNodeText  = "text"
+	xmlNodeCDATA = "cdata"
+)
+
+// xmlTag is a parsed encoding/xml struct tag.
+type xmlTag struct {
+	namespace string
+	path      []string // Element names; "a>b" nests b inside a
+	attr      bool
+	nodeType  string
+}
+
+// parseXMLTag parses `xml:"ns name,attr"`, `xml:",chardata"` and `xml:"a>b"`.
+func parseXMLTag(tag string) (xmlTag, bool) {
+	if tag == "" || tag == "-" {
+		return xmlTag{}, false
+	}
+
+	var result xmlTag
+	name, options, _ := strings.Cut(tag, ",")
+	if namespace, local, found := strings.Cut(name, " "); found {
+		result.namespace, name = namespace, local
+	}
+	if name != "" {
+		result.path = strings.Split(name, ">")
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		switch option {
+		case "attr":
+			result.attr = true
+		case "chardata":
+			result.nodeType = xmlNodeText
+		case "cdata":
+			result.nodeType = xmlNodeCDATA
+		}
+	}
+
+	return result, true
+}
+
+// applyXMLTag sets the XML object of a property from its xml tag. Like
+// encoding/xml, the fields of an XML-encoded struct are named after the Go
+// field unless their tag names them. The XML name is only emitted when it
+// differs from the property name. For arrays, `xml:"a>b"` wraps the items
+// named b in an element a, and a plain name applies to each repeated item.
+// An error reports the parts of a tag the XML object cannot describe.
+func applyXMLTag(tag, propertyName, fieldName string, xmlStruct bool, schema *openapi.Schema) error {
+	parsed, ok := parseXMLTag(tag)
+	if !ok && (tag != "" || !xmlStruct) {
+		return nil
+	}
+	if len(parsed.path) == 0 && parsed.nodeType == "" && xmlStruct {
+		parsed.path = []string{fieldName}
+	}
+
+	xml := &openapi.XML{
+		Namespace: parsed.namespace,
+		Attribute: parsed.attr,
+		NodeType:  parsed.nodeType,
+	}
+
+	var err error
+	if schema.Type == typeArray && schema.Items != nil && len(parsed.path) > 0 {
+		itemName := parsed.path[len(parsed.path)-1]
+		if len(parsed.path) > 1 {
+			xml.Name = parsed.path[0]
+			xml.Wrapped = true
+		}
+		if len(parsed.path) > 2 {
+			err = fmt.Errorf("xml tag %q: only one wrapper element can be described, %s was dropped", tag, strings.Join(parsed.path[1:len(parsed.path)-1], ">"))
+		}
+		if itemName != propertyName || xml.Wrapped {
+			schema.Items.XML = mergeXML(schema.Items.XML, &openapi.XML{Name: itemName})
+		}
+	} else if len(parsed.path) > 0 {
+		xml.Name = parsed.path[len(parsed.path)-1]
+		if len(parsed.path) > 1 {
+			err = fmt.Errorf("xml tag %q: parent elements of a non-array field cannot be described, %s was dropped", tag, strings.Join(parsed.path[:len(parsed.path)-1], ">"))
+		}
+	}
+
+	if xml.Name == propertyName && !xml.Wrapped {
+		xml.Name = ""
+	}
+
+	schema.XML = mergeXML(schema.XML, xml)
+	return err
+}
+
+// isXMLStruct reports whether a struct is meant for encoding/xml: it has
+// an XMLName field or a field with an xml tag.
+func isXMLStruct(structType *ast.StructType) bool {
+	if structType.Fields == nil {
+		return false
+	}
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 1 && isXMLNameField(field, field.Names[0].Name) {
+			return true
+		}
+		if field.Tag != nil && extractTag(strings.Trim(field.Tag.Value, "`"), "xml") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeXML fills the unset fields of dst from src and returns nil when
+// the result carries no information.
+func mergeXML(dst, src *openapi.XML) *openapi.XML {
+	if dst == nil {
+		dst = &openapi.XML{}
+	}
+	if src.Name != "" {
+		dst.Name = src.Name
+	}
+	if src.Namespace != "" {
+		dst.Namespace = src.Namespace
+	}
+	if src.NodeType != "" {
+		dst.NodeType = src.NodeType
+	}
+	dst.Attribute = dst.Attribute || src.Attribute
+	dst.Wrapped = dst.Wrapped || src.Wrapped
+
+	if reflect.DeepEqual(*dst, openapi.XML{}) {
+		return nil
+	}
+	return dst
+}
+
+// isXMLNameField reports whether a field is the XMLName xml.Name marker.
+func isXMLNameField(field *ast.Field, fieldName string) bool {
+	if fieldName != "XMLName" {
+		return false
+	}
+	sel, ok := field.Type.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Name"
+}
+
+// structXML returns the root element metadata declared by an XMLName field.
+func structXML(structType *ast.StructType) *openapi.XML {
+	if structType.Fields == nil {
+		return nil
+	}
+
+	for _, field := range structType.Fields.List {
+		if len(field.Names) != 1 || !isXMLNameField(field, field.Names[0].Name) || field.Tag == nil {
+			continue
+		}
+
+		parsed, ok := parseXMLTag(extractTag(strings.Trim(field.Tag.Value, "`"), "xml"))
+		if !ok {
+			return nil
+		}
+
+		xml := &openapi.XML{Namespace: parsed.namespace}
+		if len(parsed.path) > 0 {
+			xml.Name = parsed.path[len(parsed.path)-1]
+		}
+		return mergeXML(nil, xml)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	openapi "github.com/fsvxavier/nexs-swag/pkg/openapi/v3"
+)
+
+func TestProcessStructXMLTags(t *testing.T) {
+	t.Parallel()
+	content := `package main
+
+type Book struct {
+	XMLName xml.Name ` + "`xml:\"urn:books book\"`" + `
+	ID      string   ` + "`json:\"id\" xml:\"id,attr\"`" + `
+	Title   string   ` + "`json:\"title\" xml:\"title\"`" + `
+	Text    string   ` + "`json:\"text\" xml:\",chardata\"`" + `
+	Authors []string ` + "`json:\"authors\" xml:\"authors>author\"`" + `
+	Tags    []string ` + "`json:\"tags\" xml:\"tag\"`" + `
+	Year    int      ` + "`json:\"year\" xml:\"published\"`" + `
+	Notes   string   ` + "`json:\"notes\" xml:\"-\"`" + `
+	Pages   int      ` + "`json:\"pages\"`" + `
+	Lang    string   ` + "`json:\"lang\" xml:\",attr\"`" + `
+	Series  string   ` + "`json:\"series\" xml:\"meta>series\"`" + `
+}
+
+type Plain struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+	p := New()
+	processTypes(t, p, content)
+	book := p.openapi.Components.Schemas["Book"]
+
+	if _, ok := book.Properties["XMLName"]; ok {
+		t.Error("XMLName should not be a property")
+	}
+	if book.XML == nil || book.XML.Name != "book" || book.XML.Namespace != "urn:books" {
+		t.Errorf("Book XML = %+v, want name book in namespace urn:books", book.XML)
+	}
+
+	tests := []struct {
+		property string
+		want     *openapi.XML
+		items    *openapi.XML
+	}{
+		{property: "id", want: &openapi.XML{Attribute: true}},
+		{property: "title"},
+		{property: "text", want: &openapi.XML{NodeType: "text"}},
+		{property: "authors", want: &openapi.XML{Name: "authors", Wrapped: true}, items: &openapi.XML{Name: "author"}},
+		{property: "tags", items: &openapi.XML{Name: "tag"}},
+		{property: "year", want: &openapi.XML{Name: "published"}},
+		{property: "notes"},
+		{property: "pages", want: &openapi.XML{Name: "Pages"}},
+		{property: "lang", want: &openapi.XML{Name: "Lang", Attribute: true}},
+		{property: "series"},
+	}
+
+	for _, tt := range tests {
+		prop := book.Properties[tt.property]
+		if prop == nil {
+			t.Errorf("property %q missing", tt.property)
+			continue
+		}
+		if !equalXML(prop.XML, tt.want) {
+			t.Errorf("property %q XML = %+v, want %+v", tt.property, prop.XML, tt.want)
+		}
+		if prop.Items != nil && !equalXML(prop.Items.XML, tt.items) {
+			t.Errorf("property %q items XML = %+v, want %+v", tt.property, prop.Items.XML, tt.items)
+		}
+	}
+
+	// Structs without xml tags keep their JSON names
+	if name := p.openapi.Components.Schemas["Plain"].Properties["name"]; name == nil || name.XML != nil {
+		t.Errorf("Plain name = %+v, want no XML object", name)
+	}
+
+	warnings := p.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Book.Series") || !strings.Contains(warnings[0], "meta was dropped") {
+		t.Errorf("warnings = %v, want one for Book.Series", warnings)
+	}
+}
+
+func TestParseXMLTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag  string
+		ok   bool
+		want xmlTag
+	}{
+		{tag: "", ok: false},
+		{tag: "-", ok: false},
+		{tag: "name", ok: true, want: xmlTag{path: []string{"name"}}},
+		{tag: "name,attr,omitempty", ok: true, want: xmlTag{path: []string{"name"}, attr: true}},
+		{tag: ",cdata", ok: true, want: xmlTag{nodeType: "cdata"}},
+		{tag: "urn:x a>b", ok: true, want: xmlTag{namespace: "urn:x", path: []string{"a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parseXMLTag(tt.tag)
+			if ok != tt.ok {
+				t.Fatalf("parseXMLTag(%q) ok = %v, want %v", tt.tag, ok, tt.ok)
+			}
+			if got.namespace != tt.want.namespace || got.attr != tt.want.attr || got.nodeType != tt.want.nodeType ||
+				len(got.path) != len(tt.want.path) {
+				t.Fatalf("parseXMLTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+			}
+			for i := range got.path {
+				if got.path[i] != tt.want.path[i] {
+					t.Errorf("parseXMLTag(%q) path = %v, want %v", tt.tag, got.path, tt.want.path)
+				}
+			}
+		})
+	}
+}
+
+func equalXML(got, want *openapi.XML) bool {
+	return reflect.DeepEqual(got, want)
+}